@@ -12,6 +12,7 @@ const (
 	NetCreatedReason              string                  = "NetCreated"
 	NetReadyCondition             clusterv1.ConditionType = "NetReady"
 	NetReconciliationFailedReason string                  = "NetReconciliationFailed"
+	DhcpOptionsCreatedReason      string                  = "DhcpOptionsCreated"
 )
 
 const (
@@ -20,18 +21,37 @@ const (
 	NetPeeringReconciliationFailedReason string                  = "NetPeeringReconciliationFailed"
 )
 
+// AdditionalNetPeeringReadyCondition returns the condition of an additional net peering.
+func AdditionalNetPeeringReadyCondition(name string) clusterv1.ConditionType {
+	return clusterv1.ConditionType("NetPeeringReady-" + name)
+}
+
 const (
 	NetAccessPointCreatedReason               string                  = "NetAccessPointCreated"
 	NetAccessPointsReadyCondition             clusterv1.ConditionType = "NetAccessPointsReady"
 	NetAccessPointsReconciliationFailedReason string                  = "NetAccessPointsReconciliationFailed"
 )
 
+const (
+	// AirgapPreflightReadyCondition reports missing prerequisites of an airgapped cluster.
+	AirgapPreflightReadyCondition    clusterv1.ConditionType = "AirgapPreflightReady"
+	AirgapPrerequisitesMissingReason string                  = "AirgapPrerequisitesMissing"
+	AirgapPreflightFailedReason      string                  = "AirgapPreflightFailed"
+)
+
 const (
 	SubnetCreatedReason               string                  = "SubnetCreated"
 	SubnetsReadyCondition             clusterv1.ConditionType = "SubnetsReady"
 	SubnetsReconciliationFailedReason string                  = "SubnetsReconciliationFailed"
 )
 
+const (
+	SubnetsRemovedCondition clusterv1.ConditionType = "SubnetsRemoved"
+	SubnetDeletedReason     string                  = "SubnetDeleted"
+	SubnetInUseReason       string                  = "SubnetInUse"
+	SubnetLowCapacityReason string                  = "SubnetLowCapacity"
+)
+
 const (
 	InternetServicesCreatedReason  string                  = "InternetServiceCreated"
 	InternetServicesReadyCondition clusterv1.ConditionType = "InternetServiceReady"
@@ -42,6 +62,7 @@ const (
 	NatServicesCreatedReason              string                  = "NatServicesCreated"
 	NatServicesReadyCondition             clusterv1.ConditionType = "NatServicesReady"
 	NatServicesReconciliationFailedReason string                  = "NatServicesReconciliationFailed"
+	NatInstanceReplacedReason             string                  = "NatInstanceReplaced"
 )
 
 const (
@@ -50,6 +71,26 @@ const (
 	RouteTableReconciliationFailedReason string                  = "RouteTableReconciliationFailed"
 )
 
+const (
+	ControlPlaneNicCreatedReason              string                  = "ControlPlaneNicCreated"
+	ControlPlaneNicsReadyCondition            clusterv1.ConditionType = "ControlPlaneNicsReady"
+	ControlPlaneNicReconciliationFailedReason string                  = "ControlPlaneNicReconciliationFailed"
+	WaitingForControlPlaneNicReason           string                  = "WaitingForControlPlaneNic"
+)
+
+const (
+	VpnCreatedReason              string                  = "VpnCreated"
+	VpnReadyCondition             clusterv1.ConditionType = "VpnReady"
+	VpnReconciliationFailedReason string                  = "VpnReconciliationFailed"
+)
+
+const (
+	DirectLinkCreatedReason               string                  = "DirectLinkCreated"
+	DirectLinkReadyCondition              clusterv1.ConditionType = "DirectLinkReady"
+	DirectLinkReconciliationFailedReason  string                  = "DirectLinkReconciliationFailed"
+	DirectLinkInterfaceNotAvailableReason string                  = "DirectLinkInterfaceNotAvailable"
+)
+
 const (
 	VmReadyCondition                      clusterv1.ConditionType = "VmReady"
 	VmNotFoundReason                      string                  = "VmNotFound"
@@ -57,15 +98,26 @@ const (
 	VmStoppedReason                       string                  = "VmStopped"
 	VmNotReadyReason                      string                  = "VmNotReady"
 	VmCreatedReason                       string                  = "VmCreated"
+	VmSecurityGroupsUpdatedReason         string                  = "VmSecurityGroupsUpdated"
 	VmProvisionFailedReason               string                  = "VmProvisionFailed"
 	WaitingForClusterInfrastructureReason string                  = "WaitingForClusterInfrastructure"
 	WaitingForBootstrapDataReason         string                  = "WaitingForBoostrapData"
 )
 
+const (
+	IPAddressClaimedCondition   clusterv1.ConditionType = "IPAddressClaimed"
+	IPAddressClaimCreatedReason string                  = "IPAddressClaimCreated"
+	WaitingForIPAddressReason   string                  = "WaitingForIPAddress"
+	IPAddressClaimFailedReason  string                  = "IPAddressClaimFailed"
+)
+
 const (
 	SecurityGroupCreatedReason              string                  = "SecurityGroupCreated"
 	SecurityGroupReadyCondition             clusterv1.ConditionType = "SecurityGroupsReady"
 	SecurityGroupReconciliationFailedReason string                  = "SecurityGroupReconciliationFailed"
+	// SecurityGroupDriftCondition is set when the rules of a securityGroup in audit mode differ from the spec.
+	SecurityGroupDriftCondition      clusterv1.ConditionType = "SecurityGroupDrift"
+	SecurityGroupDriftDetectedReason string                  = "SecurityGroupDriftDetected"
 )
 
 const (
@@ -78,3 +130,20 @@ const (
 	VolumeReadyCondition             clusterv1.ConditionType = "VolumeReady"
 	VolumeReconciliationFailedReason string                  = "VolumeFailed"
 )
+
+const (
+	ImageReadyCondition      clusterv1.ConditionType = "ImageReady"
+	ImageCreatedReason       string                  = "ImageCreated"
+	ImageNotReadyReason      string                  = "ImageNotReady"
+	ImageFailedReason        string                  = "ImageFailed"
+	WaitingForSourceVmReason string                  = "WaitingForSourceVm"
+)
+
+const (
+	ImageDistributedCondition     clusterv1.ConditionType = "ImageDistributed"
+	ImageCopiedReason             string                  = "ImageCopied"
+	ImageCopyDeletedReason        string                  = "ImageCopyDeleted"
+	ImageCopyNotDeletedReason     string                  = "ImageCopyNotDeleted"
+	ImageCopyNotReadyReason       string                  = "ImageCopyNotReady"
+	ImageDistributionFailedReason string                  = "ImageDistributionFailed"
+)
@@ -23,6 +23,12 @@ type OscClusterSpec struct {
 	Credentials          OscCredentials        `json:"credentials,omitempty"`
 	Network              OscNetwork            `json:"network,omitempty"`
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+	// The HTTP proxy used by containerd and kubelet on all nodes.
+	// +optional
+	Proxy OscProxy `json:"proxy,omitempty"`
+	// Deliver the bootstrap data of nodes through an OOS bucket, instead of the VM user data.
+	// +optional
+	BootstrapStorage OscBootstrapStorage `json:"bootstrapStorage,omitempty"`
 }
 
 // OscClusterStatus defines the observed state of OscCluster
@@ -35,6 +41,30 @@ type OscClusterStatus struct {
 	FailureDomains       clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Conditions           clusterv1.Conditions     `json:"conditions,omitempty"`
 	VmState              *VmState                 `json:"vmState,omitempty"`
+	// The remaining capacity of the cluster subnets.
+	// +optional
+	SubnetCapacity []OscSubnetCapacity `json:"subnetCapacity,omitempty"`
+	// The last time subnet capacity was refreshed.
+	// +optional
+	SubnetCapacityUpdatedAt *metav1.Time `json:"subnetCapacityUpdatedAt,omitempty"`
+	// The last time the rules of securityGroups in audit mode were compared to the spec.
+	// +optional
+	SecurityGroupAuditedAt *metav1.Time `json:"securityGroupAuditedAt,omitempty"`
+	// The version of the CNI presets applied to the automatic security groups.
+	// +optional
+	CNIPresetVersion string `json:"cniPresetVersion,omitempty"`
+	// The IDs of the additional net peerings already accepted.
+	// +optional
+	AcceptedNetPeerings []string `json:"acceptedNetPeerings,omitempty"`
+}
+
+type OscSubnetCapacity struct {
+	SubnetId      string    `json:"subnetId"`
+	IpSubnetRange string    `json:"ipSubnetRange,omitempty"`
+	SubregionName string    `json:"subregionName,omitempty"`
+	Roles         []OscRole `json:"roles,omitempty"`
+	// The number of IPs still available in the subnet.
+	AvailableIps int32 `json:"availableIps"`
 }
 
 //+kubebuilder:object:root=true
@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -30,17 +32,253 @@ const (
 func ValidateOscClusterSpec(spec OscClusterSpec) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, ValidateNet(spec.Network.Net, spec.Network.UseExisting)...)
+	allErrs = append(allErrs, ValidateDhcpOptions(spec.Network.Net.DhcpOptions)...)
 	allErrs = append(allErrs, ValidateSubnets(spec.Network.Subnets, spec.Network.Net, spec.Network.UseExisting)...)
 	allErrs = append(allErrs, ValidateNatServices(spec.Network.NatServices, spec.Network.Subnets, spec.Network.Net, spec.Network.UseExisting)...)
+	allErrs = append(allErrs, ValidateNatInstances(spec.Network)...)
+	allErrs = append(allErrs, ValidateAirgapped(spec.Network)...)
 	allErrs = append(allErrs, ValidateSecurityGroups(spec.Network.SecurityGroups, spec.Network.Net, spec.Network.UseExisting)...)
 	allErrs = append(allErrs, ValidateLoadbalancer(spec.Network.LoadBalancer)...)
 	allErrs = append(allErrs, ValidateAllowFromIPs(spec.Network.AllowFromIPRanges)...)
+	allErrs = append(allErrs, ValidateControlPlaneNics(spec.Network.ControlPlaneNics)...)
+	allErrs = append(allErrs, ValidateSubnetPlan(spec.Network)...)
+	allErrs = append(allErrs, ValidateVpn(spec.Network.Vpn)...)
+	allErrs = append(allErrs, ValidateDirectLink(spec.Network.DirectLink)...)
+	allErrs = append(allErrs, ValidateAdditionalNetPeerings(spec.Network.AdditionalNetPeerings)...)
+	allErrs = append(allErrs, ValidateProxy(spec.Proxy)...)
+	allErrs = append(allErrs, ValidateBootstrapStorage(spec.BootstrapStorage)...)
 	return allErrs
 }
 
+// ValidateProxy checks the proxy URLs.
+func ValidateProxy(spec OscProxy) field.ErrorList {
+	var erl field.ErrorList
+	p := field.NewPath("proxy")
+	erl = AppendValidation(erl, ValidateProxyURL(p.Child("httpProxy"), spec.HTTPProxy), ValidateProxyURL(p.Child("httpsProxy"), spec.HTTPSProxy))
+	if len(spec.NoProxy) > 0 && !spec.IsEnabled() {
+		erl = append(erl, field.Forbidden(p.Child("noProxy"), "noProxy requires httpProxy or httpsProxy"))
+	}
+	for i, noProxy := range spec.NoProxy {
+		if noProxy == "" || strings.ContainsAny(noProxy, ", ") {
+			erl = append(erl, field.Invalid(p.Child("noProxy").Index(i), noProxy, "a single host, domain or CIDR is required"))
+		}
+	}
+	return erl
+}
+
+// ValidateProxyURL checks that a proxy URL is an http or https URL.
+func ValidateProxyURL(p *field.Path, proxyURL string) *field.Error {
+	if proxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	switch {
+	case err != nil:
+		return field.Invalid(p, proxyURL, "invalid URL")
+	case u.Scheme != "http" && u.Scheme != "https":
+		return field.Invalid(p, proxyURL, "only http and https URLs are allowed")
+	case u.Host == "":
+		return field.Invalid(p, proxyURL, "a host is required")
+	}
+	return nil
+}
+
+// ValidateBootstrapStorage checks the delivery of bootstrap data through OOS.
+func ValidateBootstrapStorage(spec OscBootstrapStorage) field.ErrorList {
+	var erl field.ErrorList
+	if !spec.Enable {
+		return nil
+	}
+	p := field.NewPath("bootstrapStorage")
+	if spec.Bucket == "" {
+		erl = append(erl, field.Required(p.Child("bucket"), "a bucket is required to store bootstrap data"))
+	}
+	erl = AppendValidation(erl, ValidateProxyURL(p.Child("endpoint"), spec.Endpoint))
+	if spec.URLExpiration != nil && spec.URLExpiration.Duration < 0 {
+		erl = append(erl, field.Invalid(p.Child("urlExpiration"), spec.URLExpiration.Duration.String(), "must be positive"))
+	}
+	return erl
+}
+
+// ValidateAirgapped checks that the network config is compatible with the airgapped mode.
+func ValidateAirgapped(spec OscNetwork) field.ErrorList {
+	var erl field.ErrorList
+	if spec.Mode != NetworkModeAirgapped {
+		return nil
+	}
+	if spec.Bastion.Enable {
+		erl = append(erl, field.Forbidden(field.NewPath("network", "bastion", "enable"), "a bastion requires internet access and cannot be enabled in airgapped mode"))
+	}
+	if spec.LoadBalancer.LoadBalancerType != "" && spec.LoadBalancer.LoadBalancerType != "internal" {
+		erl = append(erl, field.Invalid(field.NewPath("network", "loadBalancer", "loadbalancertype"), spec.LoadBalancer.LoadBalancerType, "only internal is allowed in airgapped mode"))
+	}
+	return erl
+}
+
+// ValidateAdditionalNetPeerings checks that additional net peerings have unique names, a peer net and destination ranges.
+func ValidateAdditionalNetPeerings(specs []OscAdditionalNetPeering) field.ErrorList {
+	var erl field.ErrorList
+	names := map[string]bool{}
+	for i, spec := range specs {
+		p := field.NewPath("network", "additionalNetPeerings").Index(i)
+		erl = AppendValidation(erl, ValidateRequired(p.Child("name"), spec.Name, "name is required"))
+		switch {
+		case spec.Name == "default":
+			erl = append(erl, field.Invalid(p.Child("name"), spec.Name, "default is a reserved name"))
+		case names[spec.Name]:
+			erl = append(erl, field.Duplicate(p.Child("name"), spec.Name))
+		}
+		names[spec.Name] = true
+		erl = AppendValidation(erl, ValidateRequired(p.Child("netId"), spec.NetId, "netId is required"))
+		if len(spec.DestinationIpRanges) == 0 {
+			erl = append(erl, field.Required(p.Child("destinationIpRanges"), "at least one destination IP range is required"))
+		}
+		for j, r := range spec.DestinationIpRanges {
+			erl = AppendValidation(erl, ValidateCidr(p.Child("destinationIpRanges").Index(j), r))
+		}
+	}
+	return erl
+}
+
+// ValidateDhcpOptions checks the DHCP options of the net.
+func ValidateDhcpOptions(spec OscDhcpOptions) field.ErrorList {
+	var erl field.ErrorList
+	p := field.NewPath("network", "net", "dhcpOptions")
+	for i, server := range spec.DomainNameServers {
+		if server == "OutscaleProvidedDNS" {
+			continue
+		}
+		if _, err := netip.ParseAddr(server); err != nil {
+			erl = append(erl, field.Invalid(p.Child("domainNameServers").Index(i), server, "invalid IP address"))
+		}
+	}
+	for i, server := range spec.NtpServers {
+		if _, err := netip.ParseAddr(server); err != nil {
+			erl = append(erl, field.Invalid(p.Child("ntpServers").Index(i), server, "invalid IP address"))
+		}
+	}
+	return erl
+}
+
+// ValidateDirectLink checks the DirectLink interface parameters.
+func ValidateDirectLink(spec OscDirectLink) field.ErrorList {
+	p := field.NewPath("network", "directLink")
+	if spec.DirectLinkId == "" {
+		if spec.Vlan != 0 || len(spec.OnPremiseRanges) > 0 {
+			return field.ErrorList{field.Required(p.Child("directLinkId"), "directLinkId is required")}
+		}
+		return nil
+	}
+	var erl field.ErrorList
+	if spec.Vlan < 1 || spec.Vlan > 4094 {
+		erl = append(erl, field.Invalid(p.Child("vlan"), spec.Vlan, "vlan must be between 1 and 4094"))
+	}
+	if spec.ClientPrivateIp != "" {
+		erl = AppendValidation(erl, ValidateCidr(p.Child("clientPrivateIp"), spec.ClientPrivateIp))
+	}
+	if spec.OutscalePrivateIp != "" {
+		erl = AppendValidation(erl, ValidateCidr(p.Child("outscalePrivateIp"), spec.OutscalePrivateIp))
+	}
+	for i, r := range spec.OnPremiseRanges {
+		erl = AppendValidation(erl, ValidateCidr(p.Child("onPremiseRanges").Index(i), r))
+	}
+	return erl
+}
+
+// ValidateVpn checks that VPN connections have unique names and valid IPs.
+func ValidateVpn(spec OscVpn) field.ErrorList {
+	var erl field.ErrorList
+	p := field.NewPath("network", "vpn")
+	if !spec.Enable && len(spec.Connections) > 0 {
+		erl = append(erl, field.Forbidden(p.Child("connections"), "vpn must be enabled to define connections"))
+	}
+	names := map[string]bool{}
+	for i, conn := range spec.Connections {
+		cp := p.Child("connections").Index(i)
+		erl = AppendValidation(erl, ValidateRequired(cp.Child("name"), conn.Name, "name is required"))
+		if names[conn.Name] {
+			erl = append(erl, field.Duplicate(cp.Child("name"), conn.Name))
+		}
+		names[conn.Name] = true
+		if _, err := netip.ParseAddr(conn.PublicIp); err != nil {
+			erl = append(erl, field.Invalid(cp.Child("publicIp"), conn.PublicIp, "invalid IP address"))
+		}
+		for j, route := range conn.StaticRoutes {
+			erl = AppendValidation(erl, ValidateCidr(cp.Child("staticRoutes").Index(j), route))
+		}
+	}
+	return erl
+}
+
+// ValidateSubnetPlan checks that the subnet plan is valid and fits in the net.
+func ValidateSubnetPlan(spec OscNetwork) field.ErrorList {
+	if spec.SubnetPlan == nil {
+		return nil
+	}
+	var erl field.ErrorList
+	p := field.NewPath("network", "subnetPlan")
+	if len(spec.Subnets) > 0 {
+		erl = append(erl, field.Forbidden(p, "subnetPlan cannot be used with subnets"))
+	}
+	for i, r := range spec.SubnetPlan.ReservedRanges {
+		erl = AppendValidation(erl, ValidateCidr(p.Child("reservedRanges").Index(i), r))
+	}
+	for _, l := range []struct {
+		name   string
+		length int32
+	}{
+		{"publicPrefixLength", spec.SubnetPlan.PublicPrefixLength},
+		{"workerPrefixLength", spec.SubnetPlan.WorkerPrefixLength},
+		{"controlPlanePrefixLength", spec.SubnetPlan.ControlPlanePrefixLength},
+	} {
+		if l.length != 0 {
+			erl = AppendValidation(erl, ValidateRange(p.Child(l.name), l.length, minSubnetPrefixLength, maxSubnetPrefixLength))
+		}
+	}
+	if spec.SubnetPlan.WorkerSubnetsPerSubregion < 0 {
+		erl = append(erl, field.Invalid(p.Child("workerSubnetsPerSubregion"), spec.SubnetPlan.WorkerSubnetsPerSubregion, "must be positive"))
+	}
+	if len(erl) > 0 {
+		return erl
+	}
+	ipRange := spec.Net.IpRange
+	if ipRange == "" {
+		ipRange = DefaultNet.IpRange
+	}
+	subregions := spec.Subregions
+	if len(subregions) == 0 {
+		subregions = []string{spec.SubregionName}
+	}
+	if _, err := spec.SubnetPlan.Plan(ipRange, subregions); err != nil {
+		erl = append(erl, field.Invalid(p, ipRange, err.Error()))
+	}
+	return erl
+}
+
+// ValidateControlPlaneNics checks that NIC slots have unique names and valid private IPs.
+func ValidateControlPlaneNics(spec OscControlPlaneNics) field.ErrorList {
+	var erl field.ErrorList
+	names := map[string]bool{}
+	p := field.NewPath("network", "controlPlaneNics", "slots")
+	for i, slot := range spec.Slots {
+		erl = AppendValidation(erl,
+			ValidateRequired(p.Index(i).Child("name"), slot.Name, "name is required"),
+			ValidateSubregion(p.Index(i).Child("subregionName"), slot.SubregionName),
+		)
+		if names[slot.Name] {
+			erl = append(erl, field.Duplicate(p.Index(i).Child("name"), slot.Name))
+		}
+		names[slot.Name] = true
+		if _, err := netip.ParseAddr(slot.PrivateIp); slot.PrivateIp != "" && err != nil {
+			erl = append(erl, field.Invalid(p.Index(i).Child("privateIp"), slot.PrivateIp, "invalid IP address"))
+		}
+	}
+	return erl
+}
+
 func ValidateNet(spec OscNet, reuse OscReuse) field.ErrorList {
 	switch {
-	case spec == OscNet{}:
+	case spec.IsZero() && spec.Name == "" && spec.ClusterName == "":
 		return nil
 	case reuse.Net:
 		return MergeValidation(
@@ -89,6 +327,27 @@ func ValidateNatServices(specs []OscNatService, subnets []OscSubnet, net OscNet,
 	return erl
 }
 
+// ValidateNatInstances checks that NAT instances have an image.
+func ValidateNatInstances(spec OscNetwork) field.ErrorList {
+	var erl field.ErrorList
+	validate := func(p *field.Path, nat OscNatService) {
+		if nat.Mode != NatServiceModeInstance {
+			return
+		}
+		if nat.Instance.ImageId == "" && nat.Instance.ImageName == "" {
+			erl = append(erl, field.Required(p.Child("instance", "imageId"), "imageId or imageName is required in instance mode"))
+		}
+		if nat.Instance.VmType != "" {
+			erl = AppendValidation(erl, ValidateVmType(p.Child("instance", "vmType"), nat.Instance.VmType))
+		}
+	}
+	validate(field.NewPath("network", "natService"), spec.NatService)
+	for i, nat := range spec.NatServices {
+		validate(field.NewPath("network", "natServices").Index(i), nat)
+	}
+	return erl
+}
+
 func ValidateSecurityGroups(specs []OscSecurityGroup, net OscNet, reuse OscReuse) field.ErrorList {
 	var erl field.ErrorList
 	for _, spec := range specs {
@@ -108,6 +367,9 @@ func ValidateSecurityGroups(specs []OscSecurityGroup, net OscNet, reuse OscReuse
 			)
 			erl = AppendValidation(erl, ValidateSecurityGroupRules(spec.SecurityGroupRules)...)
 		}
+		if spec.Audit && spec.Authoritative {
+			erl = append(erl, field.Invalid(field.NewPath("network", "securityGroups", "audit"), spec.Audit, "audit and authoritative are mutually exclusive"))
+		}
 	}
 	return erl
 }
@@ -119,8 +381,9 @@ func ValidateSecurityGroupRules(specs []OscSecurityGroupRule) field.ErrorList {
 			ValidateFlow(field.NewPath("network", "securityGroups", "securityGroupRules", "flow"), spec.Flow),
 			ValidateIpProtocol(field.NewPath("network", "securityGroups", "securityGroupRules", "ipProtocol"), spec.IpProtocol),
 			Or(
-				ValidateRequired(field.NewPath("network", "securityGroups", "securityGroupRules", "ipRange"), spec.IpRange, "ipRange or ipRanges must be set"),
-				ValidateRequiredSlice(field.NewPath("network", "securityGroups", "securityGroupRules", "ipRanges"), spec.IpRanges, "ipRange or ipRanges must be set"),
+				ValidateRequired(field.NewPath("network", "securityGroups", "securityGroupRules", "ipRange"), spec.IpRange, "ipRange, ipRanges or securityGroupMembers must be set"),
+				ValidateRequiredSlice(field.NewPath("network", "securityGroups", "securityGroupRules", "ipRanges"), spec.IpRanges, "ipRange, ipRanges or securityGroupMembers must be set"),
+				ValidateRequiredSlice(field.NewPath("network", "securityGroups", "securityGroupRules", "securityGroupMembers"), spec.SecurityGroupMembers, "ipRange, ipRanges or securityGroupMembers must be set"),
 			),
 			ValidateRange(field.NewPath("network", "securityGroups", "securityGroupRules", "fromPortRange"), spec.FromPortRange, minPort, maxPort),
 			ValidateRange(field.NewPath("network", "securityGroups", "securityGroupRules", "toPortRange"), spec.ToPortRange, minPort, maxPort),
@@ -139,6 +402,17 @@ func ValidateSecurityGroupRules(specs []OscSecurityGroupRule) field.ErrorList {
 				)
 			}
 		}
+		for _, member := range spec.SecurityGroupMembers {
+			set := 0
+			for _, v := range []string{member.Name, string(member.Role), member.SecurityGroupId} {
+				if v != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				erl = append(erl, field.Invalid(field.NewPath("network", "securityGroups", "securityGroupRules", "securityGroupMembers"), member, "exactly one of name, role or securityGroupId must be set"))
+			}
+		}
 	}
 	return erl
 }
@@ -161,6 +435,20 @@ func ValidateLoadbalancer(spec OscLoadBalancer) field.ErrorList {
 		Optional(ValidateRange(field.NewPath("network", "loadBalancer", "healthCheck", "healthythreshold"), spec.HealthCheck.HealthyThreshold, minThreshold, maxThreshold)),
 		Optional(ValidateRange(field.NewPath("network", "loadBalancer", "healthCheck", "unhealthythreshold"), spec.HealthCheck.UnhealthyThreshold, minThreshold, maxThreshold)),
 	)
+	ports := map[int32]bool{}
+	for i, listener := range spec.Listeners {
+		p := field.NewPath("network", "loadBalancer", "listeners").Index(i)
+		erl = AppendValidation(erl,
+			ValidateRange(p.Child("loadbalancerport"), listener.LoadBalancerPort, minPort, maxPort),
+			Optional(ValidateProtocol(p.Child("loadbalancerprotocol"), listener.LoadBalancerProtocol)),
+			Optional(ValidateRange(p.Child("backendport"), listener.BackendPort, minPort, maxPort)),
+			Optional(ValidateProtocol(p.Child("backendprotocol"), listener.BackendProtocol)),
+		)
+		if ports[listener.LoadBalancerPort] {
+			erl = append(erl, field.Duplicate(p.Child("loadbalancerport"), listener.LoadBalancerPort))
+		}
+		ports[listener.LoadBalancerPort] = true
+	}
 	return erl
 }
 
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package v1beta1
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -74,12 +77,126 @@ func (OscClusterWebhook) ValidateUpdate(_ context.Context, obj runtime.Object, o
 				r.Spec.Network.LoadBalancer.LoadBalancerType, "field is immutable"),
 		)
 	}
+	if apiPort := apiLoadBalancerPort(r.Spec.Network.LoadBalancer); apiPort != apiLoadBalancerPort(old.Spec.Network.LoadBalancer) {
+		path := field.NewPath("network", "loadBalancer", "listener", "loadbalancerport")
+		if len(r.Spec.Network.LoadBalancer.Listeners) > 0 {
+			path = field.NewPath("network", "loadBalancer", "listeners").Index(0).Child("loadbalancerport")
+		}
+		allErrs = append(allErrs, field.Invalid(path, apiPort, "field is immutable, the first listener exposes the Kubernetes API"))
+	}
+	if len(old.Status.Resources.Subnet) > 0 && !subnetPlanUpdateAllowed(old.Spec.Network.SubnetPlan, r.Spec.Network.SubnetPlan) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("network", "subnetPlan"), "subnetPlan cannot be changed once subnets have been created"),
+		)
+	}
+	if r.Spec.Network.Mode != old.Spec.Network.Mode {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("network", "mode"),
+				r.Spec.Network.Mode, "field is immutable"),
+		)
+	}
+	if r.Spec.Network.NatService.Mode != old.Spec.Network.NatService.Mode {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("network", "natService", "mode"),
+				r.Spec.Network.NatService.Mode, "field is immutable"),
+		)
+	}
+	for i, nat := range r.Spec.Network.NatServices {
+		idx := slices.IndexFunc(old.Spec.Network.NatServices, func(o OscNatService) bool { return o.Name == nat.Name })
+		if idx >= 0 && nat.Mode != old.Spec.Network.NatServices[idx].Mode {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("network", "natServices").Index(i).Child("mode"),
+					nat.Mode, "field is immutable"),
+			)
+		}
+	}
+	for i, np := range r.Spec.Network.AdditionalNetPeerings {
+		idx := slices.IndexFunc(old.Spec.Network.AdditionalNetPeerings, func(o OscAdditionalNetPeering) bool { return o.Name == np.Name })
+		if idx < 0 {
+			continue
+		}
+		oldNp := old.Spec.Network.AdditionalNetPeerings[idx]
+		path := field.NewPath("network", "additionalNetPeerings").Index(i)
+		if np.NetId != oldNp.NetId {
+			allErrs = append(allErrs, field.Invalid(path.Child("netId"), np.NetId, "field is immutable, rename the net peering to replace it"))
+		}
+		if np.AccountId != oldNp.AccountId {
+			allErrs = append(allErrs, field.Invalid(path.Child("accountId"), np.AccountId, "field is immutable, rename the net peering to replace it"))
+		}
+	}
+	allErrs = append(allErrs, validateDirectLinkUpdate(old.Spec.Network.DirectLink, r.Spec.Network.DirectLink)...)
+	allErrs = append(allErrs, validateVpnConnectionsUpdate(old.Spec.Network.Vpn.Connections, r.Spec.Network.Vpn.Connections)...)
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
 	return nil, apierrors.NewInvalid(GroupVersion.WithKind("OscCluster").GroupKind(), r.Name, allErrs)
 }
 
+// apiLoadBalancerPort returns the port of the listener exposing the Kubernetes API.
+func apiLoadBalancerPort(lb OscLoadBalancer) int32 {
+	return cmp.Or(lb.GetListeners()[0].LoadBalancerPort, APIPort)
+}
+
+// subnetPlanUpdateAllowed checks that an update of the subnet plan does not move existing subnets.
+// An empty plan gives the default layout, and may be added to a cluster without plan.
+func subnetPlanUpdateAllowed(old, plan *OscSubnetPlan) bool {
+	if old == nil && plan != nil {
+		return equality.Semantic.DeepEqual(*plan, OscSubnetPlan{})
+	}
+	return equality.Semantic.DeepEqual(old, plan)
+}
+
+// validateVpnConnectionsUpdate checks that the endpoint of existing VPN connections is not changed.
+// A connection needs to be renamed to be replaced.
+func validateVpnConnectionsUpdate(old, conns []OscVpnConnection) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, conn := range conns {
+		idx := slices.IndexFunc(old, func(o OscVpnConnection) bool { return o.Name == conn.Name })
+		if idx < 0 {
+			continue
+		}
+		oldConn := old[idx]
+		path := field.NewPath("network", "vpn", "connections").Index(i)
+		if conn.PublicIp != oldConn.PublicIp {
+			allErrs = append(allErrs, field.Invalid(path.Child("publicIp"), conn.PublicIp, "field is immutable, rename the connection to replace it"))
+		}
+		if conn.BgpAsn != oldConn.BgpAsn {
+			allErrs = append(allErrs, field.Invalid(path.Child("bgpAsn"), conn.BgpAsn, "field is immutable, rename the connection to replace it"))
+		}
+		if (len(conn.StaticRoutes) > 0) != (len(oldConn.StaticRoutes) > 0) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("staticRoutes"), "cannot switch between static and BGP routing, rename the connection to replace it"))
+		}
+	}
+	return allErrs
+}
+
+// validateDirectLinkUpdate checks that the interface settings of an enabled DirectLink are not changed.
+// The DirectLink needs to be removed to delete its interface, before being added back.
+func validateDirectLinkUpdate(old, dl OscDirectLink) field.ErrorList {
+	if old.DirectLinkId == "" || dl.DirectLinkId == "" {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("network", "directLink")
+	for _, f := range []struct {
+		name       string
+		old, value any
+	}{
+		{"directLinkId", old.DirectLinkId, dl.DirectLinkId},
+		{"vlan", old.Vlan, dl.Vlan},
+		{"bgpAsn", old.BgpAsn, dl.BgpAsn},
+		{"bgpKeyFromSecret", old.BgpKeyFromSecret, dl.BgpKeyFromSecret},
+		{"bgpKeySecretKey", old.BgpKeySecretKey, dl.BgpKeySecretKey},
+		{"clientPrivateIp", old.ClientPrivateIp, dl.ClientPrivateIp},
+		{"outscalePrivateIp", old.OutscalePrivateIp, dl.OutscalePrivateIp},
+	} {
+		if f.value != f.old {
+			allErrs = append(allErrs, field.Invalid(path.Child(f.name), f.value, "field is immutable, remove the directLink to replace its interface"))
+		}
+	}
+	return allErrs
+}
+
 // ValidateDelete implements webhook.CustomValidator.
 func (OscClusterWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
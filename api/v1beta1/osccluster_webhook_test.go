@@ -45,6 +45,106 @@ func TestOscCluster_ValidateCreate(t *testing.T) {
 			},
 			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.loadBalancer.loadbalancertype: Invalid value: \"foo\": only internet-facing or internal are allowed"),
 		},
+		{
+			name: "duplicate loadBalancer listener",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+						Listeners: []infrastructurev1beta1.OscLoadBalancerListener{
+							{LoadBalancerPort: 6443},
+							{LoadBalancerPort: 6443, BackendPort: 8132},
+						},
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.loadBalancer.listeners[1].loadbalancerport: Duplicate value: 6443"),
+		},
+		{
+			name: "bad loadBalancer listener port",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+						Listeners: []infrastructurev1beta1.OscLoadBalancerListener{
+							{LoadBalancerPort: 6443},
+							{LoadBalancerPort: 70000},
+						},
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.loadBalancer.listeners[1].loadbalancerport: Invalid value: 70000: must be between 1 and 65535"),
+		},
+		{
+			name: "NAT instance without image",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					NatService: infrastructurev1beta1.OscNatService{
+						Mode: infrastructurev1beta1.NatServiceModeInstance,
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.natService.instance.imageId: Required value: imageId or imageName is required in instance mode"),
+		},
+		{
+			name: "bastion in airgapped mode",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Mode: infrastructurev1beta1.NetworkModeAirgapped,
+					Bastion: infrastructurev1beta1.OscBastion{
+						Enable: true,
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.bastion.enable: Forbidden: a bastion requires internet access and cannot be enabled in airgapped mode"),
+		},
+		{
+			name: "internet-facing loadbalancer in airgapped mode",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Mode: infrastructurev1beta1.NetworkModeAirgapped,
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+						LoadBalancerType: "internet-facing",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.loadBalancer.loadbalancertype: Invalid value: \"internet-facing\": only internal is allowed in airgapped mode"),
+		},
+		{
+			name: "bad proxy URL",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+				Proxy: infrastructurev1beta1.OscProxy{
+					HTTPProxy: "proxy:3128",
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: proxy.httpProxy: Invalid value: \"proxy:3128\": only http and https URLs are allowed"),
+		},
+		{
+			name: "bootstrap storage without bucket",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+				BootstrapStorage: infrastructurev1beta1.OscBootstrapStorage{
+					Enable: true,
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: bootstrapStorage.bucket: Required value: a bucket is required to store bootstrap data"),
+		},
 		{
 			name: "bad cidr",
 			clusterSpec: infrastructurev1beta1.OscClusterSpec{
@@ -104,6 +204,303 @@ func TestOscCluster_ValidateCreate(t *testing.T) {
 			},
 			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.subnets.ipSubnetRange: Invalid value: \"10.0.1.0/24\": subnet overlaps 10.0.1.0/24"),
 		},
+		{
+			name: "duplicate controlplane nic slots",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					ControlPlaneNics: infrastructurev1beta1.OscControlPlaneNics{
+						Enable: true,
+						Slots:  []infrastructurev1beta1.OscNicSlot{{Name: "a"}, {Name: "a"}},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.controlPlaneNics.slots[1].name: Duplicate value: \"a\""),
+		},
+		{
+			name: "bad controlplane nic private ip",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					ControlPlaneNics: infrastructurev1beta1.OscControlPlaneNics{
+						Enable: true,
+						Slots:  []infrastructurev1beta1.OscNicSlot{{Name: "a", PrivateIp: "10.0.4.300"}},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.controlPlaneNics.slots[0].privateIp: Invalid value: \"10.0.4.300\": invalid IP address"),
+		},
+		{
+			name: "subnetPlan with subnets",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Net: infrastructurev1beta1.OscNet{
+						IpRange: "10.0.0.0/16",
+					},
+					Subnets:    []infrastructurev1beta1.OscSubnet{{IpSubnetRange: "10.0.1.0/24"}},
+					SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.subnetPlan: Forbidden: subnetPlan cannot be used with subnets"),
+		},
+		{
+			name: "subnetPlan not fitting in net",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Net: infrastructurev1beta1.OscNet{
+						IpRange: "10.0.0.0/24",
+					},
+					Subregions: []string{"eu-west-2a", "eu-west-2b"},
+					SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 25},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.subnetPlan: Invalid value: \"10.0.0.0/24\": no room for a /25 [worker] subnet in eu-west-2a: subnet plan does not fit in net"),
+		},
+		{
+			name: "valid subnetPlan",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Net: infrastructurev1beta1.OscNet{
+						IpRange: "10.0.0.0/16",
+					},
+					SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 20, ReservedRanges: []string{"10.0.0.0/23"}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "vpn connections without vpn enabled",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Vpn: infrastructurev1beta1.OscVpn{
+						Connections: []infrastructurev1beta1.OscVpnConnection{{Name: "onprem", PublicIp: "198.51.100.1"}},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.vpn.connections: Forbidden: vpn must be enabled to define connections"),
+		},
+		{
+			name: "vpn connection with invalid public IP and static route",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Vpn: infrastructurev1beta1.OscVpn{
+						Enable:      true,
+						Connections: []infrastructurev1beta1.OscVpnConnection{{Name: "onprem", PublicIp: "foo", StaticRoutes: []string{"192.168.0.0"}}},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.vpn.connections[0].publicIp: Invalid value: \"foo\": invalid IP address, network.vpn.connections[0].staticRoutes[0]: Invalid value: \"192.168.0.0\": invalid CIDR address]"),
+		},
+		{
+			name: "valid vpn",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Vpn: infrastructurev1beta1.OscVpn{
+						Enable:      true,
+						Connections: []infrastructurev1beta1.OscVpnConnection{{Name: "onprem", PublicIp: "198.51.100.1", StaticRoutes: []string{"192.168.0.0/16"}}},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroupMembers with both name and role",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					SecurityGroups: []infrastructurev1beta1.OscSecurityGroup{{
+						Name: "kw",
+						SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+							Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 22, ToPortRange: 22,
+							SecurityGroupMembers: []infrastructurev1beta1.OscSecurityGroupMember{{Name: "lb", Role: infrastructurev1beta1.RoleLoadBalancer}},
+						}},
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.securityGroups.securityGroupRules.securityGroupMembers: Invalid value: v1beta1.OscSecurityGroupMember{Name:\"lb\", Role:\"loadbalancer\", SecurityGroupId:\"\"}: exactly one of name, role or securityGroupId must be set"),
+		},
+		{
+			name: "valid securityGroupMembers",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					SecurityGroups: []infrastructurev1beta1.OscSecurityGroup{{
+						Name: "kw",
+						SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+							Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 22, ToPortRange: 22,
+							SecurityGroupMembers: []infrastructurev1beta1.OscSecurityGroupMember{{Role: infrastructurev1beta1.RoleBastion}, {SecurityGroupId: "sg-foo"}},
+						}},
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroup both audit and authoritative",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					SecurityGroups: []infrastructurev1beta1.OscSecurityGroup{{
+						Name:          "kw",
+						Audit:         true,
+						Authoritative: true,
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.securityGroups.audit: Invalid value: true: audit and authoritative are mutually exclusive"),
+		},
+		{
+			name: "dhcpOptions with invalid servers",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Net: infrastructurev1beta1.OscNet{
+						DhcpOptions: infrastructurev1beta1.OscDhcpOptions{
+							DomainNameServers: []string{"OutscaleProvidedDNS", "dns.example.com"},
+							NtpServers:        []string{"10.0.0.300"},
+						},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.net.dhcpOptions.domainNameServers[1]: Invalid value: \"dns.example.com\": invalid IP address, network.net.dhcpOptions.ntpServers[0]: Invalid value: \"10.0.0.300\": invalid IP address]"),
+		},
+		{
+			name: "valid dhcpOptions",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Net: infrastructurev1beta1.OscNet{
+						DhcpOptions: infrastructurev1beta1.OscDhcpOptions{
+							DomainName:        "corp.example.com",
+							DomainNameServers: []string{"10.1.0.2", "10.1.0.3"},
+							NtpServers:        []string{"10.1.0.4"},
+						},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "additionalNetPeerings with reserved name and missing fields",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					AdditionalNetPeerings: []infrastructurev1beta1.OscAdditionalNetPeering{{
+						Name: "default",
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.additionalNetPeerings[0].name: Invalid value: \"default\": default is a reserved name, network.additionalNetPeerings[0].netId: Required value: netId is required, network.additionalNetPeerings[0].destinationIpRanges: Required value: at least one destination IP range is required]"),
+		},
+		{
+			name: "additionalNetPeerings with duplicate name",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					AdditionalNetPeerings: []infrastructurev1beta1.OscAdditionalNetPeering{{
+						Name: "shared", NetId: "vpc-foo", DestinationIpRanges: []string{"10.100.0.0/16"},
+					}, {
+						Name: "shared", NetId: "vpc-bar", DestinationIpRanges: []string{"10.101.0.0/16"},
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.additionalNetPeerings[1].name: Duplicate value: \"shared\""),
+		},
+		{
+			name: "valid additionalNetPeerings",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					AdditionalNetPeerings: []infrastructurev1beta1.OscAdditionalNetPeering{{
+						Name:                "shared",
+						NetId:               "vpc-foo",
+						AccountId:           "0123",
+						DestinationIpRanges: []string{"10.100.0.0/16"},
+						RouteTableRoles:     []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+					}},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "directLink without directLinkId",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					DirectLink: infrastructurev1beta1.OscDirectLink{
+						Vlan: 42,
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.directLink.directLinkId: Required value: directLinkId is required"),
+		},
+		{
+			name: "directLink with invalid vlan and range",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					DirectLink: infrastructurev1beta1.OscDirectLink{
+						DirectLinkId:    "dxcon-foo",
+						OnPremiseRanges: []string{"192.168.0.0"},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+			expValidateCreateErr: errors.New("OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.directLink.vlan: Invalid value: 0: vlan must be between 1 and 4094, network.directLink.onPremiseRanges[0]: Invalid value: \"192.168.0.0\": invalid CIDR address]"),
+		},
+		{
+			name: "valid directLink",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					DirectLink: infrastructurev1beta1.OscDirectLink{
+						DirectLinkId:      "dxcon-foo",
+						Vlan:              42,
+						ClientPrivateIp:   "169.254.0.1/30",
+						OutscalePrivateIp: "169.254.0.2/30",
+						OnPremiseRanges:   []string{"192.168.0.0/16"},
+					},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerName: "foo",
+					},
+				},
+			},
+		},
 	}
 	h := infrastructurev1beta1.OscClusterWebhook{}
 	for _, ctc := range clusterTestCases {
@@ -119,6 +516,187 @@ func TestOscCluster_ValidateCreate(t *testing.T) {
 	}
 }
 
+// TestOscCluster_ValidateUpdate checks updates of oscCluster specs
+func TestOscCluster_ValidateUpdate(t *testing.T) {
+	withSubnets := infrastructurev1beta1.OscClusterStatus{
+		Resources: infrastructurev1beta1.OscClusterResources{
+			Subnet: map[string]string{"10.0.2.0/24": "subnet-foo"},
+		},
+	}
+	clusterTestCases := []struct {
+		name           string
+		oldClusterSpec infrastructurev1beta1.OscClusterSpec
+		oldStatus      infrastructurev1beta1.OscClusterStatus
+		clusterSpec    infrastructurev1beta1.OscClusterSpec
+		expErr         string
+	}{
+		{
+			name: "an empty subnetPlan may be added to an existing cluster",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{}},
+			},
+			oldStatus: withSubnets,
+		},
+		{
+			name: "a custom subnetPlan cannot be added to an existing cluster",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 20}},
+			},
+			oldStatus: withSubnets,
+			expErr:    "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.subnetPlan: Forbidden: subnetPlan cannot be changed once subnets have been created",
+		},
+		{
+			name: "a subnetPlan cannot be changed once subnets exist",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 20}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 22}},
+			},
+			oldStatus: withSubnets,
+			expErr:    "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.subnetPlan: Forbidden: subnetPlan cannot be changed once subnets have been created",
+		},
+		{
+			name: "a subnetPlan may be changed before subnets are created",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 20}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{SubnetPlan: &infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 22}},
+			},
+		},
+		{
+			name: "the static routes of a VPN connection may be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{Vpn: infrastructurev1beta1.OscVpn{Enable: true, Connections: []infrastructurev1beta1.OscVpnConnection{
+					{Name: "onprem", PublicIp: "198.51.100.1", StaticRoutes: []string{"192.168.0.0/16"}},
+				}}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{Vpn: infrastructurev1beta1.OscVpn{Enable: true, Connections: []infrastructurev1beta1.OscVpnConnection{
+					{Name: "onprem", PublicIp: "198.51.100.1", StaticRoutes: []string{"192.168.0.0/16", "172.16.0.0/16"}},
+					{Name: "other", PublicIp: "198.51.100.2"},
+				}}},
+			},
+		},
+		{
+			name: "the endpoint of a VPN connection cannot be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{Vpn: infrastructurev1beta1.OscVpn{Enable: true, Connections: []infrastructurev1beta1.OscVpnConnection{
+					{Name: "onprem", PublicIp: "198.51.100.1", StaticRoutes: []string{"192.168.0.0/16"}},
+				}}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{Vpn: infrastructurev1beta1.OscVpn{Enable: true, Connections: []infrastructurev1beta1.OscVpnConnection{
+					{Name: "onprem", PublicIp: "198.51.100.2", BgpAsn: 65001},
+				}}},
+			},
+			expErr: "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.vpn.connections[0].publicIp: Invalid value: \"198.51.100.2\": field is immutable, rename the connection to replace it, " +
+				"network.vpn.connections[0].bgpAsn: Invalid value: 65001: field is immutable, rename the connection to replace it, " +
+				"network.vpn.connections[0].staticRoutes: Forbidden: cannot switch between static and BGP routing, rename the connection to replace it]",
+		},
+		{
+			name: "listeners may be added after the API listener",
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{LoadBalancer: infrastructurev1beta1.OscLoadBalancer{Listeners: []infrastructurev1beta1.OscLoadBalancerListener{
+					{LoadBalancerPort: 6443},
+					{LoadBalancerPort: 8132},
+				}}},
+			},
+		},
+		{
+			name: "the port of the API listener cannot be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{LoadBalancer: infrastructurev1beta1.OscLoadBalancer{Listeners: []infrastructurev1beta1.OscLoadBalancerListener{
+					{LoadBalancerPort: 6443},
+				}}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{LoadBalancer: infrastructurev1beta1.OscLoadBalancer{Listeners: []infrastructurev1beta1.OscLoadBalancerListener{
+					{LoadBalancerPort: 443, BackendPort: 6443},
+				}}},
+			},
+			expErr: "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: network.loadBalancer.listeners[0].loadbalancerport: Invalid value: 443: field is immutable, the first listener exposes the Kubernetes API",
+		},
+		{
+			name: "the on-premise ranges of a directLink may be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{DirectLink: infrastructurev1beta1.OscDirectLink{
+					DirectLinkId: "dxcon-foo", Vlan: 42, OnPremiseRanges: []string{"192.168.0.0/16"},
+				}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{DirectLink: infrastructurev1beta1.OscDirectLink{
+					DirectLinkId: "dxcon-foo", Vlan: 42, OnPremiseRanges: []string{"192.168.0.0/16", "172.16.0.0/16"},
+				}},
+			},
+		},
+		{
+			name: "the interface of a directLink cannot be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{DirectLink: infrastructurev1beta1.OscDirectLink{
+					DirectLinkId: "dxcon-foo", Vlan: 42,
+				}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{DirectLink: infrastructurev1beta1.OscDirectLink{
+					DirectLinkId: "dxcon-bar", Vlan: 43, BgpKeyFromSecret: "bgp",
+				}},
+			},
+			expErr: "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.directLink.directLinkId: Invalid value: \"dxcon-bar\": field is immutable, remove the directLink to replace its interface, " +
+				"network.directLink.vlan: Invalid value: 43: field is immutable, remove the directLink to replace its interface, " +
+				"network.directLink.bgpKeyFromSecret: Invalid value: \"bgp\": field is immutable, remove the directLink to replace its interface]",
+		},
+		{
+			name: "the peer net of an additional net peering cannot be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{AdditionalNetPeerings: []infrastructurev1beta1.OscAdditionalNetPeering{
+					{Name: "shared", NetId: "vpc-shared", DestinationIpRanges: []string{"10.100.0.0/16"}},
+				}},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{AdditionalNetPeerings: []infrastructurev1beta1.OscAdditionalNetPeering{
+					{Name: "other", NetId: "vpc-other", DestinationIpRanges: []string{"10.101.0.0/16"}},
+					{Name: "shared", NetId: "vpc-foo", AccountId: "0123", DestinationIpRanges: []string{"10.100.0.0/16", "10.102.0.0/16"}},
+				}},
+			},
+			expErr: "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.additionalNetPeerings[1].netId: Invalid value: \"vpc-foo\": field is immutable, rename the net peering to replace it, " +
+				"network.additionalNetPeerings[1].accountId: Invalid value: \"0123\": field is immutable, rename the net peering to replace it]",
+		},
+		{
+			name: "the NAT mode cannot be changed",
+			oldClusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					NatService:  infrastructurev1beta1.OscNatService{Mode: infrastructurev1beta1.NatServiceModeInstance},
+					NatServices: []infrastructurev1beta1.OscNatService{{Name: "nat-a"}},
+				},
+			},
+			clusterSpec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					NatService:  infrastructurev1beta1.OscNatService{Mode: infrastructurev1beta1.NatServiceModeService},
+					NatServices: []infrastructurev1beta1.OscNatService{{Name: "nat-a", Mode: infrastructurev1beta1.NatServiceModeInstance}},
+				},
+			},
+			expErr: "OscCluster.infrastructure.cluster.x-k8s.io \"webhook-test\" is invalid: [network.natService.mode: Invalid value: \"service\": field is immutable, " +
+				"network.natServices[0].mode: Invalid value: \"instance\": field is immutable]",
+		},
+	}
+	h := infrastructurev1beta1.OscClusterWebhook{}
+	for _, ctc := range clusterTestCases {
+		t.Run(ctc.name, func(t *testing.T) {
+			oldCluster := createOscInfraCluster(ctc.oldClusterSpec, "webhook-test", "default")
+			oldCluster.Status = ctc.oldStatus
+			cluster := createOscInfraCluster(ctc.clusterSpec, "webhook-test", "default")
+			_, err := h.ValidateUpdate(context.TODO(), cluster, oldCluster)
+			if ctc.expErr != "" {
+				require.EqualError(t, err, ctc.expErr, "ValidateUpdate() should return the right error")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 // createOscInfraCluster create oscInfraCluster
 func createOscInfraCluster(infraClusterSpec infrastructurev1beta1.OscClusterSpec, name string, namespace string) *infrastructurev1beta1.OscCluster {
 	oscInfraCluster := &infrastructurev1beta1.OscCluster{
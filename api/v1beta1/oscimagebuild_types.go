@@ -0,0 +1,88 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// OscImageBuildSpec defines the VM an image is built from.
+type OscImageBuildSpec struct {
+	// The OscMachine whose VM is the source of the image, in the namespace of the OscImageBuild.
+	// The credentials of its cluster are used.
+	// +optional
+	MachineRef *corev1.LocalObjectReference `json:"machineRef,omitempty"`
+	// The ID of the VM that is the source of the image, if machineRef is not set.
+	// +optional
+	VmId string `json:"vmId,omitempty"`
+	// The credentials used with vmId.
+	// +optional
+	Credentials OscCredentials `json:"credentials,omitempty"`
+	// The name of the image.
+	ImageName string `json:"imageName"`
+	// The description of the image.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Reboot the VM before creating the image, for a consistent file system.
+	// The VM is not rebooted by default.
+	// +optional
+	Reboot bool `json:"reboot,omitempty"`
+	// Tags added to the image.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// OscImageBuildStatus defines the observed state of OscImageBuild.
+type OscImageBuildStatus struct {
+	// The ID of the built image.
+	// +optional
+	ImageId string `json:"imageId,omitempty"`
+	// True once the image is available.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:subresource:status
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=oscimagebuilds,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.imageName",description="Image name"
+// +kubebuilder:printcolumn:name="ImageId",type="string",JSONPath=".status.imageId",description="Image ID"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Image is available"
+
+// OscImageBuild is the Schema for the OscImageBuild API, building an image from a VM.
+type OscImageBuild struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OscImageBuildSpec   `json:"spec,omitempty"`
+	Status OscImageBuildStatus `json:"status,omitempty"`
+}
+
+// OscImageBuildList contains a list of OscImageBuild
+// +kubebuilder:object:root=true
+type OscImageBuildList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OscImageBuild `json:"items"`
+}
+
+func (b *OscImageBuild) GetConditions() clusterv1.Conditions {
+	return b.Status.Conditions
+}
+
+func (b *OscImageBuild) SetConditions(conditions clusterv1.Conditions) {
+	b.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&OscImageBuild{}, &OscImageBuildList{})
+}
@@ -0,0 +1,30 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateOscImageBuildSpec validates a OscImageBuildSpec.
+func ValidateOscImageBuildSpec(spec OscImageBuildSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	switch {
+	case spec.MachineRef == nil && spec.VmId == "":
+		allErrs = append(allErrs, field.Required(field.NewPath("machineRef"), "machineRef or vmId is required"))
+	case spec.MachineRef != nil && spec.VmId != "":
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("vmId"), "machineRef and vmId are mutually exclusive"))
+	case spec.VmId != "" && spec.Credentials.FromSecret == "" && spec.Credentials.FromFile == "":
+		// The controller credentials would allow any namespace to build images from any VM of the controller account.
+		allErrs = append(allErrs, field.Required(field.NewPath("credentials"), "credentials are required with vmId"))
+	}
+	allErrs = AppendValidation(allErrs, ValidateRequired(field.NewPath("imageName"), spec.ImageName, "imageName is required"))
+	if err := ValidateImageName(spec.ImageName); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("imageName"), spec.ImageName, err.Error()))
+	}
+	return allErrs
+}
@@ -0,0 +1,40 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateOscImageBuildSpec(t *testing.T) {
+	creds := v1beta1.OscCredentials{FromSecret: "creds"}
+	var tcs = []struct {
+		name  string
+		spec  v1beta1.OscImageBuildSpec
+		valid bool
+	}{
+		{name: "a machine", spec: v1beta1.OscImageBuildSpec{MachineRef: &corev1.LocalObjectReference{Name: "foo"}, ImageName: "foo"}, valid: true},
+		{name: "a vm", spec: v1beta1.OscImageBuildSpec{VmId: "i-foo", Credentials: creds, ImageName: "foo"}, valid: true},
+		{name: "a vm with credentials from a file", spec: v1beta1.OscImageBuildSpec{VmId: "i-foo", Credentials: v1beta1.OscCredentials{FromFile: "/etc/osc/creds.json"}, ImageName: "foo"}, valid: true},
+		{name: "a vm without credentials", spec: v1beta1.OscImageBuildSpec{VmId: "i-foo", ImageName: "foo"}, valid: false},
+		{name: "no source", spec: v1beta1.OscImageBuildSpec{ImageName: "foo"}, valid: false},
+		{name: "a machine and a vm", spec: v1beta1.OscImageBuildSpec{MachineRef: &corev1.LocalObjectReference{Name: "foo"}, VmId: "i-foo", ImageName: "foo"}, valid: false},
+		{name: "no image name", spec: v1beta1.OscImageBuildSpec{VmId: "i-foo"}, valid: false},
+		{name: "an invalid image name", spec: v1beta1.OscImageBuildSpec{VmId: "i-foo", Credentials: creds, ImageName: "foo bar!"}, valid: false},
+	}
+	for _, tc := range tcs {
+		errs := v1beta1.ValidateOscImageBuildSpec(tc.spec)
+		if tc.valid {
+			require.Empty(t, errs, tc.name)
+		} else {
+			require.NotEmpty(t, errs, tc.name)
+		}
+	}
+}
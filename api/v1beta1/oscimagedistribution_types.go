@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// OscImageDistributionSpec defines the image to distribute.
+// The image is distributed to the tenants of the OscClusters whose OscMachineTemplates, in the namespace of the OscImageDistribution,
+// reference the image by name, name pattern or ID.
+type OscImageDistributionSpec struct {
+	// The ID of the source image.
+	ImageId string `json:"imageId"`
+	// The credentials of the account owning the source image.
+	// +optional
+	Credentials OscCredentials `json:"credentials,omitempty"`
+}
+
+// OscImageCopy is a copy of the source image in another region.
+type OscImageCopy struct {
+	// The region of the copy.
+	Region string `json:"region"`
+	// The account owning the copy.
+	AccountId string `json:"accountId"`
+	// The ID of the copy.
+	ImageId string `json:"imageId"`
+	// The OscCluster whose credentials are used to manage the copy.
+	ClusterName string `json:"clusterName"`
+	// The credentials of the OscCluster when the copy was made, used to delete the copy once the OscCluster is deleted.
+	// +optional
+	Credentials *OscCredentials `json:"credentials,omitempty"`
+	// True once the copy is available.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+}
+
+// OscImageDistributionStatus defines the observed state of OscImageDistribution.
+type OscImageDistributionStatus struct {
+	// The accounts allowed to launch VMs from the source image.
+	// +optional
+	SharedAccountIds []string `json:"sharedAccountIds,omitempty"`
+	// The copies of the source image.
+	// +optional
+	Copies []OscImageCopy `json:"copies,omitempty"`
+	// True once the image is available to all tenants.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:subresource:status
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=oscimagedistributions,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="ImageId",type="string",JSONPath=".spec.imageId",description="Source image ID"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Image is available to all tenants"
+
+// OscImageDistribution is the Schema for the OscImageDistribution API, sharing and copying an image across accounts and regions.
+type OscImageDistribution struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OscImageDistributionSpec   `json:"spec,omitempty"`
+	Status OscImageDistributionStatus `json:"status,omitempty"`
+}
+
+// OscImageDistributionList contains a list of OscImageDistribution
+// +kubebuilder:object:root=true
+type OscImageDistributionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OscImageDistribution `json:"items"`
+}
+
+func (d *OscImageDistribution) GetConditions() clusterv1.Conditions {
+	return d.Status.Conditions
+}
+
+func (d *OscImageDistribution) SetConditions(conditions clusterv1.Conditions) {
+	d.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&OscImageDistribution{}, &OscImageDistributionList{})
+}
@@ -0,0 +1,23 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateOscImageDistributionSpec validates a OscImageDistributionSpec.
+func ValidateOscImageDistributionSpec(spec OscImageDistributionSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = AppendValidation(allErrs, ValidateRequired(field.NewPath("imageId"), spec.ImageId, "imageId is required"))
+	if spec.ImageId != "" {
+		if err := ValidateImageId(spec.ImageId); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("imageId"), spec.ImageId, err.Error()))
+		}
+	}
+	return allErrs
+}
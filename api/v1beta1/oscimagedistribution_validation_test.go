@@ -0,0 +1,19 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOscImageDistributionSpec(t *testing.T) {
+	require.Empty(t, v1beta1.ValidateOscImageDistributionSpec(v1beta1.OscImageDistributionSpec{ImageId: "ami-foo"}))
+	require.Len(t, v1beta1.ValidateOscImageDistributionSpec(v1beta1.OscImageDistributionSpec{}), 1)
+	require.Len(t, v1beta1.ValidateOscImageDistributionSpec(v1beta1.OscImageDistributionSpec{ImageId: "i-foo"}), 1)
+}
@@ -32,6 +32,8 @@ type OscMachineStatus struct {
 	Resources            OscMachineResources     `json:"resources,omitempty"`
 	ReconcilerGeneration OscReconcilerGeneration `json:"reconcilerGeneration,omitempty"`
 	Conditions           clusterv1.Conditions    `json:"conditions,omitempty"`
+	// The securityGroups attached to the VM by CAPOSC. SecurityGroups attached out of band are left untouched.
+	AttachedSecurityGroups []string `json:"attachedSecurityGroups,omitempty"`
 }
 
 // +kubebuilder:object:root=true
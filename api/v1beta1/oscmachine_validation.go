@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -28,6 +29,10 @@ func ValidateOscMachineSpec(spec OscMachineSpec) field.ErrorList {
 	allErrs = AppendValidation(allErrs, ValidateRequired(field.NewPath("node", "vm", "keypairName"), spec.Node.Vm.KeypairName, "keypairName is required"))
 	allErrs = AppendValidation(allErrs, ValidateVmType(field.NewPath("node", "vm", "vmType"), spec.Node.Vm.VmType))
 	allErrs = AppendValidation(allErrs, ValidateSubregion(field.NewPath("node", "vm", "subregionName"), spec.Node.Vm.SubregionName))
+	allErrs = append(allErrs, ValidateAddressesFromPools(field.NewPath("node", "vm", "addressesFromPools"), spec.Node.Vm)...)
+	allErrs = append(allErrs, ValidateSecurityGroupRules(spec.Node.Vm.DedicatedSecurityGroup.SecurityGroupRules)...)
+	allErrs = append(allErrs, ValidateAdditionalUserData(field.NewPath("node", "vm", "additionalUserData"), spec.Node.Vm.AdditionalUserData)...)
+	allErrs = AppendValidation(allErrs, ValidateImageNamePattern(field.NewPath("node", "image"), spec.Node.Image))
 
 	for _, spec := range spec.Node.Volumes {
 		allErrs = AppendValidation(allErrs, ValidateVolume(field.NewPath("node", "vm", "subregionName"), spec)...)
@@ -48,6 +53,56 @@ func ValidateVolume(path *field.Path, spec OscVolume) field.ErrorList {
 	)
 }
 
+// ValidateAddressesFromPools checks that IPAM pool references are complete and not mixed with static private IPs.
+func ValidateAddressesFromPools(path *field.Path, vm OscVm) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(vm.AddressesFromPools) == 0 {
+		return allErrs
+	}
+	if len(vm.PrivateIps) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path, "addressesFromPools cannot be used with privateIps"))
+	}
+	for i, pool := range vm.AddressesFromPools {
+		allErrs = AppendValidation(allErrs,
+			ValidateRequired(path.Index(i).Child("apiGroup"), ptr.Deref(pool.APIGroup, ""), "apiGroup is required"),
+			ValidateRequired(path.Index(i).Child("kind"), pool.Kind, "kind is required"),
+			ValidateRequired(path.Index(i).Child("name"), pool.Name, "name is required"),
+		)
+	}
+	return allErrs
+}
+
+// ValidateAdditionalUserData checks that each user data part is either inline or from a secret.
+func ValidateAdditionalUserData(path *field.Path, parts []OscUserDataPart) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, part := range parts {
+		p := path.Index(i)
+		switch {
+		case part.Content == "" && part.FromSecret == "":
+			allErrs = append(allErrs, field.Required(p.Child("content"), "content or fromSecret is required"))
+		case part.Content != "" && part.FromSecret != "":
+			allErrs = append(allErrs, field.Forbidden(p.Child("fromSecret"), "content and fromSecret are mutually exclusive"))
+		case part.SecretKey != "" && part.FromSecret == "":
+			allErrs = append(allErrs, field.Forbidden(p.Child("secretKey"), "secretKey requires fromSecret"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateImageNamePattern checks that an image name pattern is a valid template, not mixed with an image name.
+func ValidateImageNamePattern(path *field.Path, image OscImage) *field.Error {
+	if image.NamePattern == "" {
+		return nil
+	}
+	if image.Name != "" {
+		return field.Forbidden(path.Child("namePattern"), "name and namePattern are mutually exclusive")
+	}
+	if _, err := image.ResolveNamePattern("v1.0.0"); err != nil {
+		return field.Invalid(path.Child("namePattern"), image.NamePattern, err.Error())
+	}
+	return nil
+}
+
 // ValidateImageId checks that imageId is a valid imageId
 func ValidateImageId(imageId string) error {
 	switch {
@@ -10,6 +10,9 @@ import (
 
 	"github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateSubregion(t *testing.T) {
@@ -75,3 +78,78 @@ func TestValidateVmType(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAddressesFromPools(t *testing.T) {
+	pool := corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "nodes",
+	}
+	var tcs = []struct {
+		name  string
+		vm    v1beta1.OscVm
+		valid bool
+	}{
+		{name: "no pool", vm: v1beta1.OscVm{}, valid: true},
+		{name: "a complete pool", vm: v1beta1.OscVm{AddressesFromPools: []corev1.TypedLocalObjectReference{pool}}, valid: true},
+		{name: "a pool without apiGroup", vm: v1beta1.OscVm{AddressesFromPools: []corev1.TypedLocalObjectReference{{Kind: "InClusterIPPool", Name: "nodes"}}}, valid: false},
+		{name: "a pool without name", vm: v1beta1.OscVm{AddressesFromPools: []corev1.TypedLocalObjectReference{{APIGroup: pool.APIGroup, Kind: "InClusterIPPool"}}}, valid: false},
+		{name: "a pool and private ips", vm: v1beta1.OscVm{
+			AddressesFromPools: []corev1.TypedLocalObjectReference{pool},
+			PrivateIps:         []v1beta1.OscPrivateIpElement{{PrivateIp: "10.0.3.10"}},
+		}, valid: false},
+	}
+	for _, tc := range tcs {
+		errs := v1beta1.ValidateAddressesFromPools(nil, tc.vm)
+		if tc.valid {
+			require.Empty(t, errs, tc.name)
+		} else {
+			require.NotEmpty(t, errs, tc.name)
+		}
+	}
+}
+
+func TestValidateAdditionalUserData(t *testing.T) {
+	var tcs = []struct {
+		name  string
+		parts []v1beta1.OscUserDataPart
+		valid bool
+	}{
+		{name: "no part", valid: true},
+		{name: "an inline part", parts: []v1beta1.OscUserDataPart{{Content: "#!/bin/sh\n"}}, valid: true},
+		{name: "a part from a secret", parts: []v1beta1.OscUserDataPart{{FromSecret: "foo", SecretKey: "bar"}}, valid: true},
+		{name: "an empty part", parts: []v1beta1.OscUserDataPart{{ContentType: "text/x-shellscript"}}, valid: false},
+		{name: "an inline part from a secret", parts: []v1beta1.OscUserDataPart{{Content: "#!/bin/sh\n", FromSecret: "foo"}}, valid: false},
+		{name: "a secret key without secret", parts: []v1beta1.OscUserDataPart{{Content: "#!/bin/sh\n", SecretKey: "bar"}}, valid: false},
+	}
+	for _, tc := range tcs {
+		errs := v1beta1.ValidateAdditionalUserData(field.NewPath("additionalUserData"), tc.parts)
+		if tc.valid {
+			require.Empty(t, errs, tc.name)
+		} else {
+			require.NotEmpty(t, errs, tc.name)
+		}
+	}
+}
+
+func TestValidateImageNamePattern(t *testing.T) {
+	var tcs = []struct {
+		name  string
+		image v1beta1.OscImage
+		valid bool
+	}{
+		{name: "no pattern", image: v1beta1.OscImage{Name: "foo"}, valid: true},
+		{name: "a pattern", image: v1beta1.OscImage{NamePattern: "ubuntu-kubernetes-{{.K8sVersion}}-*"}, valid: true},
+		{name: "a pattern and a name", image: v1beta1.OscImage{Name: "foo", NamePattern: "foo-*"}, valid: false},
+		{name: "an invalid template", image: v1beta1.OscImage{NamePattern: "foo-{{.K8sVersion"}, valid: false},
+		{name: "an unknown field", image: v1beta1.OscImage{NamePattern: "foo-{{.Version}}"}, valid: false},
+	}
+	for _, tc := range tcs {
+		err := v1beta1.ValidateImageNamePattern(field.NewPath("image"), tc.image)
+		if tc.valid {
+			require.Nil(t, err, tc.name)
+		} else {
+			require.NotNil(t, err, tc.name)
+		}
+	}
+}
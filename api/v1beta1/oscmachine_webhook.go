@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"maps"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -98,6 +99,20 @@ func (OscMachineWebhook) ValidateUpdate(_ context.Context, obj runtime.Object, o
 				r.Spec.Node.Vm.SubnetName, "field is immutable"),
 		)
 	}
+	if !equality.Semantic.DeepEqual(r.Spec.Node.Vm.AddressesFromPools, old.Spec.Node.Vm.AddressesFromPools) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("node", "vm", "addressesFromPools"),
+				r.Spec.Node.Vm.AddressesFromPools, "field is immutable"),
+		)
+	}
+
+	if r.Spec.Node.Vm.DedicatedSecurityGroup.Enable != old.Spec.Node.Vm.DedicatedSecurityGroup.Enable {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("node", "vm", "dedicatedSecurityGroup", "enable"),
+				r.Spec.Node.Vm.DedicatedSecurityGroup.Enable, "field is immutable"),
+		)
+	}
+
 	if r.Spec.Node.Vm.RootDisk.RootDiskSize != old.Spec.Node.Vm.RootDisk.RootDiskSize {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("node", "vm", "rootDisk", "rootDiskSize"),
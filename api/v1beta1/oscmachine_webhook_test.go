@@ -313,6 +313,78 @@ func TestOscMachine_ValidateUpdate(t *testing.T) {
 			},
 			errorCount: 1,
 		},
+		{
+			name: "update securityGroupNames",
+			oldMachineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType:             "tinav4.c2r4p2",
+						SecurityGroupNames: []infrastructurev1beta1.OscSecurityGroupElement{{Name: "worker"}},
+					},
+				},
+			},
+			machineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType:             "tinav4.c2r4p2",
+						SecurityGroupNames: []infrastructurev1beta1.OscSecurityGroupElement{{Name: "worker"}, {Name: "monitoring"}},
+					},
+				},
+			},
+			errorCount: 0,
+		},
+		{
+			name: "enable dedicatedSecurityGroup",
+			oldMachineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType: "tinav4.c2r4p2",
+					},
+				},
+			},
+			machineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType: "tinav4.c2r4p2",
+						DedicatedSecurityGroup: infrastructurev1beta1.OscDedicatedSecurityGroup{
+							Enable: true,
+						},
+					},
+				},
+			},
+			errorCount: 1,
+		},
+		{
+			name: "update dedicatedSecurityGroup rules",
+			oldMachineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType: "tinav4.c2r4p2",
+						DedicatedSecurityGroup: infrastructurev1beta1.OscDedicatedSecurityGroup{
+							Enable: true,
+						},
+					},
+				},
+			},
+			machineSpec: infrastructurev1beta1.OscMachineSpec{
+				Node: infrastructurev1beta1.OscNode{
+					Vm: infrastructurev1beta1.OscVm{
+						VmType: "tinav4.c2r4p2",
+						DedicatedSecurityGroup: infrastructurev1beta1.OscDedicatedSecurityGroup{
+							Enable: true,
+							SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+								Flow:          "Inbound",
+								IpProtocol:    "tcp",
+								IpRange:       "10.0.0.0/16",
+								FromPortRange: 9100,
+								ToPortRange:   9100,
+							}},
+						},
+					},
+				},
+			},
+			errorCount: 0,
+		},
 	}
 	h := infrastructurev1beta1.OscMachineWebhook{}
 	for _, mtc := range machineTestCases {
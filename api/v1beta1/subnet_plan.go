@@ -0,0 +1,118 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package v1beta1
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+const (
+	defaultSubnetPrefixLength = 24
+	minSubnetPrefixLength     = 16
+	maxSubnetPrefixLength     = 28
+)
+
+var ErrSubnetPlanDoesNotFit = errors.New("subnet plan does not fit in net")
+
+func defaultPrefixLength(l int32) int {
+	if l == 0 {
+		return defaultSubnetPrefixLength
+	}
+	return int(l)
+}
+
+// legacyReservedPrefixLength is the range left free at the start of the net by the default subnet layout.
+const legacyReservedPrefixLength = 23
+
+// Plan computes the subnets of a net.
+// For each subregion, a public subnet, the worker subnets and a controlplane subnet are planned, in that order.
+// Each subnet is allocated at the lowest free address, skipping reserved ranges. Adding a subregion at the end of the list
+// does not change the ranges of already planned subnets.
+// Without reserved ranges, the first /23 of the net is kept free, so that an empty plan gives the same subnets
+// as the default layout.
+func (p *OscSubnetPlan) Plan(ipRange string, subregions []string) ([]OscSubnet, error) {
+	n, err := netip.ParsePrefix(ipRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid net range: %w", err)
+	}
+	n = n.Masked()
+	if !n.Addr().Is4() {
+		return nil, errors.New("only IPv4 nets are supported")
+	}
+	used := make([]netip.Prefix, 0, len(p.ReservedRanges))
+	for _, r := range p.ReservedRanges {
+		rp, err := netip.ParsePrefix(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reserved range %q: %w", r, err)
+		}
+		used = append(used, rp.Masked())
+	}
+	if len(used) == 0 && n.Bits() < legacyReservedPrefixLength {
+		used = append(used, netip.PrefixFrom(n.Addr(), legacyReservedPrefixLength))
+	}
+	workers := int(p.WorkerSubnetsPerSubregion)
+	if workers == 0 {
+		workers = 1
+	}
+	type request struct {
+		bits  int
+		roles []OscRole
+	}
+	reqs := []request{{bits: defaultPrefixLength(p.PublicPrefixLength), roles: []OscRole{RoleLoadBalancer, RoleBastion, RoleNat}}}
+	for range workers {
+		reqs = append(reqs, request{bits: defaultPrefixLength(p.WorkerPrefixLength), roles: []OscRole{RoleWorker}})
+	}
+	reqs = append(reqs, request{bits: defaultPrefixLength(p.ControlPlanePrefixLength), roles: []OscRole{RoleControlPlane}})
+
+	subnets := make([]OscSubnet, 0, len(reqs)*len(subregions))
+	for _, subregion := range subregions {
+		for _, req := range reqs {
+			if req.bits < minSubnetPrefixLength || req.bits > maxSubnetPrefixLength {
+				return nil, fmt.Errorf("prefix length must be between %d and %d", minSubnetPrefixLength, maxSubnetPrefixLength)
+			}
+			sn, ok := allocateSubnet(n, req.bits, used)
+			if !ok {
+				return nil, fmt.Errorf("no room for a /%d %v subnet in %s: %w", req.bits, req.roles, subregion, ErrSubnetPlanDoesNotFit)
+			}
+			used = append(used, sn)
+			subnets = append(subnets, OscSubnet{
+				IpSubnetRange: sn.String(),
+				Roles:         req.roles,
+				SubregionName: subregion,
+			})
+		}
+	}
+	return subnets, nil
+}
+
+// allocateSubnet returns the first prefix of the requested length within net not overlapping any used prefix.
+func allocateSubnet(n netip.Prefix, bits int, used []netip.Prefix) (netip.Prefix, bool) {
+	if bits < n.Bits() {
+		return netip.Prefix{}, false
+	}
+	a4 := n.Addr().As4()
+	start := uint64(binary.BigEndian.Uint32(a4[:]))
+	end := start + 1<<(32-n.Bits())
+	step := uint64(1) << (32 - bits)
+	for addr := start; addr < end; addr += step {
+		binary.BigEndian.PutUint32(a4[:], uint32(addr))
+		candidate := netip.PrefixFrom(netip.AddrFrom4(a4), bits)
+		free := true
+		for _, u := range used {
+			if candidate.Overlaps(u) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate, true
+		}
+	}
+	return netip.Prefix{}, false
+}
@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package v1beta1_test
+
+import (
+	"testing"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ranges(subnets []infrastructurev1beta1.OscSubnet) []string {
+	res := make([]string, 0, len(subnets))
+	for _, sn := range subnets {
+		res = append(res, sn.SubregionName+" "+sn.IpSubnetRange)
+	}
+	return res
+}
+
+func TestOscSubnetPlan_Plan(t *testing.T) {
+	tcs := []struct {
+		name       string
+		plan       infrastructurev1beta1.OscSubnetPlan
+		ipRange    string
+		subregions []string
+		expected   []string
+		err        string
+	}{{
+		name:       "default plan",
+		ipRange:    "10.0.0.0/16",
+		subregions: []string{"eu-west-2a"},
+		expected:   []string{"eu-west-2a 10.0.2.0/24", "eu-west-2a 10.0.3.0/24", "eu-west-2a 10.0.4.0/24"},
+	}, {
+		name:       "default plan with multiple subregions",
+		ipRange:    "10.0.0.0/16",
+		subregions: []string{"eu-west-2a", "eu-west-2b"},
+		expected: []string{
+			"eu-west-2a 10.0.2.0/24", "eu-west-2a 10.0.3.0/24", "eu-west-2a 10.0.4.0/24",
+			"eu-west-2b 10.0.5.0/24", "eu-west-2b 10.0.6.0/24", "eu-west-2b 10.0.7.0/24",
+		},
+	}, {
+		name:       "small net without reserved ranges",
+		plan:       infrastructurev1beta1.OscSubnetPlan{PublicPrefixLength: 26, WorkerPrefixLength: 26, ControlPlanePrefixLength: 26},
+		ipRange:    "10.0.0.0/24",
+		subregions: []string{"eu-west-2a"},
+		expected:   []string{"eu-west-2a 10.0.0.0/26", "eu-west-2a 10.0.0.64/26", "eu-west-2a 10.0.0.128/26"},
+	}, {
+		name: "custom prefix lengths and reserved ranges",
+		plan: infrastructurev1beta1.OscSubnetPlan{
+			PublicPrefixLength:        26,
+			WorkerPrefixLength:        20,
+			WorkerSubnetsPerSubregion: 2,
+			ControlPlanePrefixLength:  27,
+			ReservedRanges:            []string{"10.0.0.0/24"},
+		},
+		ipRange:    "10.0.0.0/16",
+		subregions: []string{"eu-west-2a", "eu-west-2b"},
+		expected: []string{
+			"eu-west-2a 10.0.1.0/26", "eu-west-2a 10.0.16.0/20", "eu-west-2a 10.0.32.0/20", "eu-west-2a 10.0.1.64/27",
+			"eu-west-2b 10.0.1.128/26", "eu-west-2b 10.0.48.0/20", "eu-west-2b 10.0.64.0/20", "eu-west-2b 10.0.1.96/27",
+		},
+	}, {
+		name:       "plan does not fit",
+		plan:       infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 18, WorkerSubnetsPerSubregion: 4},
+		ipRange:    "10.0.0.0/16",
+		subregions: []string{"eu-west-2a"},
+		err:        "no room for a /18 [worker] subnet in eu-west-2a: subnet plan does not fit in net",
+	}, {
+		name:       "prefix larger than net",
+		plan:       infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 16},
+		ipRange:    "10.0.0.0/20",
+		subregions: []string{"eu-west-2a"},
+		err:        "no room for a /16 [worker] subnet in eu-west-2a: subnet plan does not fit in net",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := tc.plan.Plan(tc.ipRange, tc.subregions)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ranges(subnets))
+		})
+	}
+}
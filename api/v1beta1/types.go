@@ -6,8 +6,14 @@ SPDX-License-Identifier: BSD-3-Clause
 package v1beta1
 
 import (
+	"errors"
 	"slices"
 	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type OscRole string
@@ -44,6 +50,53 @@ type OscCredentials struct {
 	Profile string `json:"profile,omitempty"`
 }
 
+// OscProxy configures the HTTP proxy of the nodes.
+type OscProxy struct {
+	// The URL of the proxy used for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// The URL of the proxy used for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// Additional hosts, domains or CIDRs that are not proxied.
+	// The Net CIDR, the service CIDRs and the control-plane endpoint are always added.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// IsEnabled returns true if a proxy is configured.
+func (p OscProxy) IsEnabled() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != ""
+}
+
+// DefaultBootstrapURLExpiration is the default validity of the presigned URLs of bootstrap data.
+const DefaultBootstrapURLExpiration = 30 * time.Minute
+
+// OscBootstrapStorage configures the delivery of bootstrap data through OOS.
+// The bootstrap data is uploaded to a bucket, and the user data of the VM only contains a presigned URL to fetch it.
+type OscBootstrapStorage struct {
+	// Enable the delivery of bootstrap data through OOS.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+	// The name of the bucket storing bootstrap data. The bucket must already exist.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// The OOS endpoint (default: https://oos.<region>.outscale.com).
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// The validity of the presigned URLs (default: 30m). VMs must fetch their bootstrap data before expiration.
+	// +optional
+	URLExpiration *metav1.Duration `json:"urlExpiration,omitempty"`
+}
+
+// GetURLExpiration returns the validity of the presigned URLs.
+func (s OscBootstrapStorage) GetURLExpiration() time.Duration {
+	if s.URLExpiration == nil || s.URLExpiration.Duration == 0 {
+		return DefaultBootstrapURLExpiration
+	}
+	return s.URLExpiration.Duration
+}
+
 type OscNetwork struct {
 	// Reuse externally managed resources ?
 	// +optional
@@ -51,6 +104,10 @@ type OscNetwork struct {
 	// List of disabled features (internet = no internet service, no nat services)
 	// +optional
 	Disable []OscDisable `json:"disable,omitempty"`
+	// The network mode. In airgapped mode, internet is disabled, the net access points required by the cluster
+	// (api, eim, lbu, oos, kms) are created and linked to all route tables, and the load balancer is internal.
+	// +optional
+	Mode OscNetworkMode `json:"mode,omitempty"`
 	// The Load Balancer configuration
 	// +optional
 	LoadBalancer OscLoadBalancer `json:"loadBalancer,omitempty"`
@@ -60,6 +117,9 @@ type OscNetwork struct {
 	// The NetPeering configuration, required if the load balancer is internal, and management and workload clusters are on separate VPCs.
 	// +optional
 	NetPeering OscNetPeering `json:"netPeering,omitempty"`
+	// NetPeerings to other nets (shared services, ...), in addition to the management net peering.
+	// +optional
+	AdditionalNetPeerings []OscAdditionalNetPeering `json:"additionalNetPeerings,omitempty"`
 	// The NetAccessPoints configuration, required if internet is disabled.
 	// +optional
 	NetAccessPoints []OscNetAccessPointService `json:"netAccessPoints,omitempty"`
@@ -69,6 +129,9 @@ type OscNetwork struct {
 	// The Subnets configuration
 	// +optional
 	Subnets []OscSubnet `json:"subnets,omitempty"`
+	// The plan used to compute subnets in automatic mode (ignored if subnets are set).
+	// +optional
+	SubnetPlan *OscSubnetPlan `json:"subnetPlan,omitempty"`
 	// The Internet Service configuration
 	// +optional
 	InternetService OscInternetService `json:"internetService,omitempty"`
@@ -90,6 +153,16 @@ type OscNetwork struct {
 	// Additional rules to add to the automatic security groups
 	// +optional
 	AdditionalSecurityRules []OscAdditionalSecurityRules `json:"additionalSecurityRules,omitempty"`
+	// The CNI used by the cluster, selecting the rules added to the automatic security groups (if not set, rules for both Calico and Cilium are added).
+	// +optional
+	CNI OscCNI `json:"cni,omitempty"`
+	// The maximum number of rules in a security group (default 50), rules exceeding the quota are spread over additional security groups.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SecurityGroupRuleQuota int `json:"securityGroupRuleQuota,omitempty"`
+	// Audit the automatic security groups instead of enforcing their rules (drift is reported in the SecurityGroupDrift condition).
+	// +optional
+	SecurityGroupAudit bool `json:"securityGroupAudit,omitempty"`
 	// The Public Ip configuration (unused)
 	// +optional
 	PublicIps []*OscPublicIp `json:"publicIps,omitempty"`
@@ -102,6 +175,15 @@ type OscNetwork struct {
 	// The bastion configuration
 	// + optional
 	Bastion OscBastion `json:"bastion,omitempty"`
+	// Pre-created NICs used by controlplane nodes, to keep stable private IPs across rollouts.
+	// +optional
+	ControlPlaneNics OscControlPlaneNics `json:"controlPlaneNics,omitempty"`
+	// The VPN configuration, to connect the cluster to on-premise networks.
+	// +optional
+	Vpn OscVpn `json:"vpn,omitempty"`
+	// The DirectLink configuration, to connect the cluster to on-premise networks through an existing DirectLink.
+	// +optional
+	DirectLink OscDirectLink `json:"directLink,omitempty"`
 	// The default subregion name (deprecated, use subregions)
 	SubregionName string `json:"subregionName,omitempty"`
 	// The list of subregions where to deploy this cluster
@@ -116,6 +198,95 @@ type OscNetwork struct {
 	AllowToIPRanges []string `json:"allowToIPRanges,omitempty"`
 }
 
+type OscSubnetPlan struct {
+	// The prefix length of the public (loadbalancer/bastion/nat) subnets (24 if not set).
+	// +optional
+	PublicPrefixLength int32 `json:"publicPrefixLength,omitempty"`
+	// The prefix length of the worker subnets (24 if not set).
+	// +optional
+	WorkerPrefixLength int32 `json:"workerPrefixLength,omitempty"`
+	// The prefix length of the controlplane subnets (24 if not set).
+	// +optional
+	ControlPlanePrefixLength int32 `json:"controlPlanePrefixLength,omitempty"`
+	// The number of worker subnets per subregion (1 if not set).
+	// +optional
+	WorkerSubnetsPerSubregion int32 `json:"workerSubnetsPerSubregion,omitempty"`
+	// IP ranges (in CIDR notation) within the net where no subnet will be planned.
+	// +optional
+	ReservedRanges []string `json:"reservedRanges,omitempty"`
+}
+
+type OscVpn struct {
+	// If set, a virtual gateway is created, linked to the net, and propagates its routes to all cluster route tables.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+	// The site-to-site VPN connections.
+	// +optional
+	Connections []OscVpnConnection `json:"connections,omitempty"`
+}
+
+type OscVpnConnection struct {
+	// The name of the connection.
+	Name string `json:"name"`
+	// The public IP of the on-premise VPN endpoint (client gateway).
+	PublicIp string `json:"publicIp"`
+	// The BGP ASN of the client gateway (65000 if not set).
+	// +optional
+	BgpAsn int32 `json:"bgpAsn,omitempty"`
+	// The on-premise IP ranges (in CIDR notation) routed through the connection.
+	// If set, static routing is used, otherwise routes are exchanged with BGP.
+	// +optional
+	StaticRoutes []string `json:"staticRoutes,omitempty"`
+}
+
+type OscDirectLink struct {
+	// The ID of an existing DirectLink. If set, a DirectLink interface is created and bound to the cluster virtual gateway.
+	// +optional
+	DirectLinkId string `json:"directLinkId,omitempty"`
+	// The VLAN number of the interface.
+	// +optional
+	Vlan int32 `json:"vlan,omitempty"`
+	// The BGP ASN of the on-premise router (65000 if not set).
+	// +optional
+	BgpAsn int32 `json:"bgpAsn,omitempty"`
+	// The name of the secret containing the BGP authentication key, in the namespace of the cluster.
+	// +optional
+	BgpKeyFromSecret string `json:"bgpKeyFromSecret,omitempty"`
+	// The key of the BGP authentication key in the secret (bgpKey by default).
+	// +optional
+	BgpKeySecretKey string `json:"bgpKeySecretKey,omitempty"`
+	// The IP on the on-premise side of the interface, in CIDR notation.
+	// +optional
+	ClientPrivateIp string `json:"clientPrivateIp,omitempty"`
+	// The IP on the OUTSCALE side of the interface, in CIDR notation.
+	// +optional
+	OutscalePrivateIp string `json:"outscalePrivateIp,omitempty"`
+	// The on-premise IP ranges (in CIDR notation) routed through the virtual gateway in all cluster route tables.
+	// +optional
+	OnPremiseRanges []string `json:"onPremiseRanges,omitempty"`
+}
+
+type OscControlPlaneNics struct {
+	// If set, controlplane VMs are attached to pre-created NICs, and keep their private IP when replaced.
+	// Requires a KubeadmControlPlane rollout strategy with maxSurge set to 0.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+	// The NIC slots (one slot per controlplane subregion if not set).
+	// +optional
+	Slots []OscNicSlot `json:"slots,omitempty"`
+}
+
+type OscNicSlot struct {
+	// The name of the slot
+	Name string `json:"name"`
+	// The subregion of the slot (the default subregion if not set)
+	// +optional
+	SubregionName string `json:"subregionName,omitempty"`
+	// The fixed private IP of the NIC (allocated from the controlplane subnet if not set)
+	// +optional
+	PrivateIp string `json:"privateIp,omitempty"`
+}
+
 type OscReuse struct {
 	// If set, net, subnets, internet service, nat services and route tables are externally managed
 	Net bool `json:"net,omitempty"`
@@ -123,6 +294,13 @@ type OscReuse struct {
 	SecurityGroups bool `json:"securityGroups,omitempty"`
 }
 
+// +kubebuilder:validation:Enum:=airgapped
+type OscNetworkMode string
+
+const (
+	NetworkModeAirgapped OscNetworkMode = "airgapped"
+)
+
 // +kubebuilder:validation:Enum:=internet;loadbalancer
 type OscDisable string
 
@@ -144,10 +322,14 @@ type OscLoadBalancer struct {
 	// The security group name for the load-balancer (deprecated, add loadbalancer role to a security group)
 	// +optional
 	SecurityGroupName string `json:"securitygroupname,omitempty"`
-	// The Listener configuration of the loadBalancer
+	// The Listener configuration of the loadBalancer (ignored if listeners is set)
 	// +optional
 	Listener OscLoadBalancerListener `json:"listener,omitempty"`
-	// The healthCheck configuration of the Load Balancer
+	// The listeners of the loadBalancer, the first listener exposes the Kubernetes API.
+	// Listeners are created, updated and deleted to match the spec.
+	// +optional
+	Listeners []OscLoadBalancerListener `json:"listeners,omitempty"`
+	// The healthCheck configuration of the Load Balancer, shared by all listeners
 	// +optional
 	HealthCheck OscLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
 	// unused
@@ -203,12 +385,31 @@ type OscNet struct {
 	// The Id of the Net to reuse (if useExisting.net is set)
 	// +optional
 	ResourceId string `json:"resourceId,omitempty"`
+	// The DHCP options of the Net.
+	// +optional
+	DhcpOptions OscDhcpOptions `json:"dhcpOptions,omitempty"`
 }
 
 func (o *OscNet) IsZero() bool {
 	return o.IpRange == "" && o.ResourceId == ""
 }
 
+type OscDhcpOptions struct {
+	// The domain name.
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+	// The IPs of the domain name servers (OutscaleProvidedDNS for the default DNS servers).
+	// +optional
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+	// The IPs of the NTP servers.
+	// +optional
+	NtpServers []string `json:"ntpServers,omitempty"`
+}
+
+func (o *OscDhcpOptions) IsZero() bool {
+	return o.DomainName == "" && len(o.DomainNameServers) == 0 && len(o.NtpServers) == 0
+}
+
 var DefaultNet = OscNet{
 	IpRange: "10.0.0.0/16",
 }
@@ -231,6 +432,33 @@ type OscNetPeering struct {
 	ManagementSubnetID string `json:"managementSubnetId,omitempty"`
 }
 
+type OscAdditionalNetPeering struct {
+	// The name of the net peering.
+	Name string `json:"name"`
+	// The ID of the peer net.
+	NetId string `json:"netId"`
+	// The account ID of the peer net (the cluster account if not set).
+	// +optional
+	AccountId string `json:"accountId,omitempty"`
+	// The credentials used to accept the net peering (the cluster credentials if not set).
+	// +optional
+	Credentials OscCredentials `json:"credentials,omitempty"`
+	// The IP ranges (in CIDR notation) routed to the net peering.
+	DestinationIpRanges []string `json:"destinationIpRanges"`
+	// The roles of the route tables receiving routes (all route tables if not set).
+	// +optional
+	RouteTableRoles []OscRole `json:"routeTableRoles,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:=cilium;calico;flannel
+type OscCNI string
+
+const (
+	CNICilium  OscCNI = "cilium"
+	CNICalico  OscCNI = "calico"
+	CNIFlannel OscCNI = "flannel"
+)
+
 // +kubebuilder:validation:Enum:=api;directlink;eim;kms;lbu;oos
 type OscNetAccessPointService string
 
@@ -292,6 +520,39 @@ type OscNatService struct {
 	// The resource id (unused)
 	// +optional
 	ResourceId string `json:"resourceId,omitempty"`
+	// The NAT mode: service (a managed NAT service, the default) or instance (a VM acting as a NAT gateway).
+	// When set on natService and no natServices are defined, the mode applies to all NATs of the cluster.
+	// +kubebuilder:validation:Enum=service;instance
+	// +optional
+	Mode OscNatServiceMode `json:"mode,omitempty"`
+	// The configuration of the NAT VM (instance mode only).
+	// +optional
+	Instance OscNatInstance `json:"instance,omitempty"`
+}
+
+type OscNatServiceMode string
+
+const (
+	NatServiceModeService  OscNatServiceMode = "service"
+	NatServiceModeInstance OscNatServiceMode = "instance"
+)
+
+type OscNatInstance struct {
+	// The type of VM (tinav6.c1r1p2 by default)
+	// +optional
+	VmType string `json:"vmType,omitempty"`
+	// The image ID (required unless imageName is set)
+	// +optional
+	ImageId string `json:"imageId,omitempty"`
+	// The image name (required unless imageId is set)
+	// +optional
+	ImageName string `json:"imageName,omitempty"`
+	// The account ID of the owner of the image
+	// +optional
+	ImageAccountId string `json:"imageAccountId,omitempty"`
+	// The keypair name used to access the VM
+	// +optional
+	KeypairName string `json:"keypairName,omitempty"`
 }
 
 type OscRouteTable struct {
@@ -334,6 +595,10 @@ type OscSecurityGroup struct {
 	// Is the Security Group configuration authoritative ? (if yes, all rules not found in configuration will be deleted).
 	// +optional
 	Authoritative bool `json:"authoritative,omitempty"`
+	// Is the Security Group in audit mode ? (if yes, rules are compared to the configuration but never added or deleted,
+	// and drift is reported in the SecurityGroupDrift condition).
+	// +optional
+	Audit bool `json:"audit,omitempty"`
 }
 
 func (sg *OscSecurityGroup) HasRole(role OscRole) bool {
@@ -415,6 +680,9 @@ type OscSecurityGroupRule struct {
 	// The list of ip ranges of the security group rule
 	// +optional
 	IpRanges []string `json:"ipRanges,omitempty"`
+	// The list of security groups allowed by the security group rule
+	// +optional
+	SecurityGroupMembers []OscSecurityGroupMember `json:"securityGroupMembers,omitempty"`
 	// The beginning of the port range
 	// +optional
 	FromPortRange int32 `json:"fromPortRange,omitempty"`
@@ -426,6 +694,19 @@ type OscSecurityGroupRule struct {
 	ResourceId string `json:"resourceId,omitempty"`
 }
 
+// OscSecurityGroupMember references a security group, either by name or role within the cluster, or by id.
+type OscSecurityGroupMember struct {
+	// The name of a security group of the cluster
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The role of the security groups of the cluster
+	// +optional
+	Role OscRole `json:"role,omitempty"`
+	// The id of an external security group
+	// +optional
+	SecurityGroupId string `json:"securityGroupId,omitempty"`
+}
+
 func (sgr *OscSecurityGroupRule) GetIpRanges() []string {
 	if len(sgr.IpRanges) > 0 {
 		return sgr.IpRanges
@@ -485,31 +766,43 @@ type OscNetworkResource struct {
 }
 
 type OscClusterResources struct {
-	Net             map[string]string `json:"net,omitempty"`
-	NetPeering      map[string]string `json:"netPeering,omitempty"`
-	Subnet          map[string]string `json:"subnet,omitempty"`
-	InternetService map[string]string `json:"internetService,omitempty"`
-	NetAccessPoint  map[string]string `json:"netAccessPoint,omitempty"`
-	SecurityGroup   map[string]string `json:"securityGroup,omitempty"`
-	NatService      map[string]string `json:"natService,omitempty"`
-	Bastion         map[string]string `json:"bastion,omitempty"`
-	PublicIPs       map[string]string `json:"publicIps,omitempty"`
+	Net                 map[string]string `json:"net,omitempty"`
+	NetPeering          map[string]string `json:"netPeering,omitempty"`
+	Subnet              map[string]string `json:"subnet,omitempty"`
+	InternetService     map[string]string `json:"internetService,omitempty"`
+	NetAccessPoint      map[string]string `json:"netAccessPoint,omitempty"`
+	SecurityGroup       map[string]string `json:"securityGroup,omitempty"`
+	NatService          map[string]string `json:"natService,omitempty"`
+	NatInstance         map[string]string `json:"natInstance,omitempty"`
+	Bastion             map[string]string `json:"bastion,omitempty"`
+	PublicIPs           map[string]string `json:"publicIps,omitempty"`
+	Nic                 map[string]string `json:"nic,omitempty"`
+	VirtualGateway      map[string]string `json:"virtualGateway,omitempty"`
+	ClientGateway       map[string]string `json:"clientGateway,omitempty"`
+	VpnConnection       map[string]string `json:"vpnConnection,omitempty"`
+	DirectLinkInterface map[string]string `json:"directLinkInterface,omitempty"`
+	DhcpOptionsSet      map[string]string `json:"dhcpOptionsSet,omitempty"`
 }
 
 type Reconciler string
 
 const (
-	ReconcilerBastion          Reconciler = "bastion"
-	ReconcilerNet              Reconciler = "net"
-	ReconcilerNetPeering       Reconciler = "netPeering"
-	ReconcilerNetPeeringRoutes Reconciler = "netPeering/routes"
-	ReconcilerSubnet           Reconciler = "subnet"
-	ReconcilerInternetService  Reconciler = "internetService"
-	ReconcilerNetAccessPoint   Reconciler = "netAccessPoint"
-	ReconcilerNatService       Reconciler = "natService"
-	ReconcilerRouteTable       Reconciler = "routeTable"
-	ReconcilerSecurityGroup    Reconciler = "securityGroup"
-	ReconcilerLoadbalancer     Reconciler = "loadbalancer"
+	ReconcilerBastion               Reconciler = "bastion"
+	ReconcilerNet                   Reconciler = "net"
+	ReconcilerNetPeering            Reconciler = "netPeering"
+	ReconcilerNetPeeringRoutes      Reconciler = "netPeering/routes"
+	ReconcilerSubnet                Reconciler = "subnet"
+	ReconcilerInternetService       Reconciler = "internetService"
+	ReconcilerNetAccessPoint        Reconciler = "netAccessPoint"
+	ReconcilerNatService            Reconciler = "natService"
+	ReconcilerRouteTable            Reconciler = "routeTable"
+	ReconcilerSecurityGroup         Reconciler = "securityGroup"
+	ReconcilerLoadbalancer          Reconciler = "loadbalancer"
+	ReconcilerControlPlaneNic       Reconciler = "controlPlaneNic"
+	ReconcilerVpn                   Reconciler = "vpn"
+	ReconcilerDirectLink            Reconciler = "directLink"
+	ReconcilerAdditionalNetPeerings Reconciler = "additionalNetPeerings"
+	ReconcilerAirgapPreflight       Reconciler = "airgapPreflight"
 
 	ReconcilerVm Reconciler = "vm"
 )
@@ -532,23 +825,50 @@ type OscNodeResource struct {
 }
 
 type OscMachineResources struct {
-	Vm        map[string]string `json:"vm,omitempty"`
-	Image     map[string]string `json:"image,omitempty"`
-	Volumes   map[string]string `json:"volumes,omitempty"`
-	PublicIPs map[string]string `json:"publicIps,omitempty"`
+	Vm            map[string]string `json:"vm,omitempty"`
+	Image         map[string]string `json:"image,omitempty"`
+	Volumes       map[string]string `json:"volumes,omitempty"`
+	PublicIPs     map[string]string `json:"publicIps,omitempty"`
+	SecurityGroup map[string]string `json:"securityGroup,omitempty"`
+	BootstrapData map[string]string `json:"bootstrapData,omitempty"`
 }
 
 type OscImage struct {
 	// The image name.
 	Name string `json:"name,omitempty"`
+	// A pattern of image names, * matching any sequence of characters, and {{.K8sVersion}} being replaced by the
+	// Kubernetes version of the machine (e.g. ubuntu-2204-kubernetes-{{.K8sVersion}}-*).
+	// The newest matching image is used when creating a VM.
+	// +optional
+	NamePattern string `json:"namePattern,omitempty"`
 	// The image account owner ID.
 	AccountId string `json:"accountId,omitempty"`
+	// The image account owner IDs allowed by namePattern, in addition to accountId.
+	// +optional
+	AccountIds []string `json:"accountIds,omitempty"`
 	// Use an "Outscale Opensource" image
 	OutscaleOpenSource bool `json:"outscaleOpenSource,omitempty"`
 	// unused
 	ResourceId string `json:"resourceId,omitempty"`
 }
 
+// ResolveNamePattern returns the name pattern of the image, for a Kubernetes version.
+func (i *OscImage) ResolveNamePattern(k8sVersion string) (string, error) {
+	tpl, err := template.New("namePattern").Parse(i.NamePattern)
+	if err != nil {
+		return "", err
+	}
+	if k8sVersion == "" && strings.Contains(i.NamePattern, "K8sVersion") {
+		return "", errors.New("the Kubernetes version is required by the image name pattern")
+	}
+	var buf strings.Builder
+	err = tpl.Execute(&buf, struct{ K8sVersion string }{K8sVersion: k8sVersion})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 type OscVolume struct {
 	// The volume name.
 	Name string `json:"name,omitempty"`
@@ -613,7 +933,11 @@ type OscVm struct {
 	PublicIpPool  string                `json:"publicIpPool,omitempty"`
 	SubregionName string                `json:"subregionName,omitempty"`
 	PrivateIps    []OscPrivateIpElement `json:"privateIps,omitempty"`
-	// The list of security groups to use (deprecated, use controlplane and/or worker roles on security groups)
+	// The list of IPAM pools to claim private IPs from (one IPAddressClaim is created per pool).
+	// +optional
+	AddressesFromPools []corev1.TypedLocalObjectReference `json:"addressesFromPools,omitempty"`
+	// The list of security groups to use (deprecated, use controlplane and/or worker roles on security groups).
+	// Changes are applied to running VMs.
 	SecurityGroupNames []OscSecurityGroupElement `json:"securityGroupNames,omitempty"`
 	// The resource id of the vm (not set anymore)
 	ResourceId string `json:"resourceId,omitempty"`
@@ -627,6 +951,40 @@ type OscVm struct {
 	// Tags to add to the VM.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+	// A security group dedicated to the VM, in addition to the security groups of its role.
+	// +optional
+	DedicatedSecurityGroup OscDedicatedSecurityGroup `json:"dedicatedSecurityGroup,omitempty"`
+	// Additional cloud-init parts, merged with the bootstrap data in a MIME multipart user data.
+	// +optional
+	AdditionalUserData []OscUserDataPart `json:"additionalUserData,omitempty"`
+}
+
+// OscUserDataPart is a cloud-init part of the user data, either inline or from a secret.
+type OscUserDataPart struct {
+	// The content of the part.
+	// +optional
+	Content string `json:"content,omitempty"`
+	// The name of the secret containing the part, in the namespace of the machine.
+	// +optional
+	FromSecret string `json:"fromSecret,omitempty"`
+	// The key of the part in the secret (value by default).
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// The MIME type of the part (detected from the content by default).
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+	// The cloud-init merge type of a cloud-config part (e.g. list(append)+dict(no_replace,recurse_list)+str()).
+	// +optional
+	MergeType string `json:"mergeType,omitempty"`
+}
+
+type OscDedicatedSecurityGroup struct {
+	// Create a security group dedicated to the VM.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+	// The list of rules for the dedicated security group (the configuration is authoritative).
+	// +optional
+	SecurityGroupRules []OscSecurityGroupRule `json:"securityGroupRules,omitempty"`
 }
 
 func (vm *OscVm) GetRole() OscRole {
@@ -696,6 +1054,7 @@ const (
 	DefaultRootDiskIops int32  = 1500
 
 	DefaultVmBastionType       string = "tinav6.c1r1p2"
+	DefaultVmNatInstanceType   string = "tinav6.c1r1p2"
 	DefaultRootDiskBastionType string = "gp2"
 	DefaultRootDiskBastionSize int32  = 15
 
@@ -740,6 +1099,13 @@ func (bastion *OscBastion) SetDefaultValue() {
 	}
 }
 
+// SetDefaultValue set the NAT instance default values
+func (nat *OscNatInstance) SetDefaultValue() {
+	if nat.VmType == "" {
+		nat.VmType = DefaultVmNatInstanceType
+	}
+}
+
 // SetDefaultValue set the LoadBalancer Service default values
 func (lb *OscLoadBalancer) SetDefaultValue() {
 	if lb.LoadBalancerType == "" {
@@ -757,6 +1123,11 @@ func (lb *OscLoadBalancer) SetDefaultValue() {
 	if lb.Listener.LoadBalancerProtocol == "" {
 		lb.Listener.LoadBalancerProtocol = DefaultLoadBalancerProtocol
 	}
+	// Listeners are cloned, not to set defaults in the spec.
+	lb.Listeners = slices.Clone(lb.Listeners)
+	for i := range lb.Listeners {
+		lb.Listeners[i].SetDefaultValue()
+	}
 	if lb.HealthCheck.CheckInterval == 0 {
 		lb.HealthCheck.CheckInterval = DefaultCheckInterval
 	}
@@ -772,7 +1143,31 @@ func (lb *OscLoadBalancer) SetDefaultValue() {
 	if lb.HealthCheck.Protocol == "" {
 		lb.HealthCheck.Protocol = DefaultLoadBalancerProtocol
 	}
+	if lb.HealthCheck.Port == 0 && len(lb.Listeners) > 0 {
+		lb.HealthCheck.Port = lb.Listeners[0].BackendPort
+	}
 	if lb.HealthCheck.Port == 0 {
 		lb.HealthCheck.Port = APIPort
 	}
 }
+
+// SetDefaultValue set the LoadBalancer listener default values
+func (l *OscLoadBalancerListener) SetDefaultValue() {
+	if l.BackendPort == 0 {
+		l.BackendPort = l.LoadBalancerPort
+	}
+	if l.BackendProtocol == "" {
+		l.BackendProtocol = DefaultLoadBalancerProtocol
+	}
+	if l.LoadBalancerProtocol == "" {
+		l.LoadBalancerProtocol = DefaultLoadBalancerProtocol
+	}
+}
+
+// GetListeners returns the listeners of the LoadBalancer, the first listener exposes the Kubernetes API.
+func (lb *OscLoadBalancer) GetListeners() []OscLoadBalancerListener {
+	if len(lb.Listeners) > 0 {
+		return lb.Listeners
+	}
+	return []OscLoadBalancerListener{lb.Listener}
+}
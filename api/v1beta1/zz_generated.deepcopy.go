@@ -22,11 +22,38 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscAdditionalNetPeering) DeepCopyInto(out *OscAdditionalNetPeering) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.DestinationIpRanges != nil {
+		in, out := &in.DestinationIpRanges, &out.DestinationIpRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteTableRoles != nil {
+		in, out := &in.RouteTableRoles, &out.RouteTableRoles
+		*out = make([]OscRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscAdditionalNetPeering.
+func (in *OscAdditionalNetPeering) DeepCopy() *OscAdditionalNetPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(OscAdditionalNetPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscAdditionalSecurityRules) DeepCopyInto(out *OscAdditionalSecurityRules) {
 	*out = *in
@@ -80,6 +107,26 @@ func (in *OscBastion) DeepCopy() *OscBastion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscBootstrapStorage) DeepCopyInto(out *OscBootstrapStorage) {
+	*out = *in
+	if in.URLExpiration != nil {
+		in, out := &in.URLExpiration, &out.URLExpiration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscBootstrapStorage.
+func (in *OscBootstrapStorage) DeepCopy() *OscBootstrapStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(OscBootstrapStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscCluster) DeepCopyInto(out *OscCluster) {
 	*out = *in
@@ -191,6 +238,13 @@ func (in *OscClusterResources) DeepCopyInto(out *OscClusterResources) {
 			(*out)[key] = val
 		}
 	}
+	if in.NatInstance != nil {
+		in, out := &in.NatInstance, &out.NatInstance
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Bastion != nil {
 		in, out := &in.Bastion, &out.Bastion
 		*out = make(map[string]string, len(*in))
@@ -205,6 +259,48 @@ func (in *OscClusterResources) DeepCopyInto(out *OscClusterResources) {
 			(*out)[key] = val
 		}
 	}
+	if in.Nic != nil {
+		in, out := &in.Nic, &out.Nic
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VirtualGateway != nil {
+		in, out := &in.VirtualGateway, &out.VirtualGateway
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientGateway != nil {
+		in, out := &in.ClientGateway, &out.ClientGateway
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VpnConnection != nil {
+		in, out := &in.VpnConnection, &out.VpnConnection
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DirectLinkInterface != nil {
+		in, out := &in.DirectLinkInterface, &out.DirectLinkInterface
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DhcpOptionsSet != nil {
+		in, out := &in.DhcpOptionsSet, &out.DhcpOptionsSet
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscClusterResources.
@@ -223,6 +319,8 @@ func (in *OscClusterSpec) DeepCopyInto(out *OscClusterSpec) {
 	out.Credentials = in.Credentials
 	in.Network.DeepCopyInto(&out.Network)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	in.BootstrapStorage.DeepCopyInto(&out.BootstrapStorage)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscClusterSpec.
@@ -266,6 +364,26 @@ func (in *OscClusterStatus) DeepCopyInto(out *OscClusterStatus) {
 		*out = new(VmState)
 		**out = **in
 	}
+	if in.SubnetCapacity != nil {
+		in, out := &in.SubnetCapacity, &out.SubnetCapacity
+		*out = make([]OscSubnetCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SubnetCapacityUpdatedAt != nil {
+		in, out := &in.SubnetCapacityUpdatedAt, &out.SubnetCapacityUpdatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SecurityGroupAuditedAt != nil {
+		in, out := &in.SecurityGroupAuditedAt, &out.SecurityGroupAuditedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.AcceptedNetPeerings != nil {
+		in, out := &in.AcceptedNetPeerings, &out.AcceptedNetPeerings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscClusterStatus.
@@ -399,6 +517,26 @@ func (in *OscClusterWebhook) DeepCopy() *OscClusterWebhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscControlPlaneNics) DeepCopyInto(out *OscControlPlaneNics) {
+	*out = *in
+	if in.Slots != nil {
+		in, out := &in.Slots, &out.Slots
+		*out = make([]OscNicSlot, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscControlPlaneNics.
+func (in *OscControlPlaneNics) DeepCopy() *OscControlPlaneNics {
+	if in == nil {
+		return nil
+	}
+	out := new(OscControlPlaneNics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscCredentials) DeepCopyInto(out *OscCredentials) {
 	*out = *in
@@ -414,9 +552,81 @@ func (in *OscCredentials) DeepCopy() *OscCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscDedicatedSecurityGroup) DeepCopyInto(out *OscDedicatedSecurityGroup) {
+	*out = *in
+	if in.SecurityGroupRules != nil {
+		in, out := &in.SecurityGroupRules, &out.SecurityGroupRules
+		*out = make([]OscSecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscDedicatedSecurityGroup.
+func (in *OscDedicatedSecurityGroup) DeepCopy() *OscDedicatedSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(OscDedicatedSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscDhcpOptions) DeepCopyInto(out *OscDhcpOptions) {
+	*out = *in
+	if in.DomainNameServers != nil {
+		in, out := &in.DomainNameServers, &out.DomainNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NtpServers != nil {
+		in, out := &in.NtpServers, &out.NtpServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscDhcpOptions.
+func (in *OscDhcpOptions) DeepCopy() *OscDhcpOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OscDhcpOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscDirectLink) DeepCopyInto(out *OscDirectLink) {
+	*out = *in
+	if in.OnPremiseRanges != nil {
+		in, out := &in.OnPremiseRanges, &out.OnPremiseRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscDirectLink.
+func (in *OscDirectLink) DeepCopy() *OscDirectLink {
+	if in == nil {
+		return nil
+	}
+	out := new(OscDirectLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscImage) DeepCopyInto(out *OscImage) {
 	*out = *in
+	if in.AccountIds != nil {
+		in, out := &in.AccountIds, &out.AccountIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImage.
@@ -429,6 +639,244 @@ func (in *OscImage) DeepCopy() *OscImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageBuild) DeepCopyInto(out *OscImageBuild) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageBuild.
+func (in *OscImageBuild) DeepCopy() *OscImageBuild {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageBuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OscImageBuild) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageBuildList) DeepCopyInto(out *OscImageBuildList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OscImageBuild, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageBuildList.
+func (in *OscImageBuildList) DeepCopy() *OscImageBuildList {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageBuildList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OscImageBuildList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageBuildSpec) DeepCopyInto(out *OscImageBuildSpec) {
+	*out = *in
+	if in.MachineRef != nil {
+		in, out := &in.MachineRef, &out.MachineRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	out.Credentials = in.Credentials
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageBuildSpec.
+func (in *OscImageBuildSpec) DeepCopy() *OscImageBuildSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageBuildSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageBuildStatus) DeepCopyInto(out *OscImageBuildStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageBuildStatus.
+func (in *OscImageBuildStatus) DeepCopy() *OscImageBuildStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageBuildStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageCopy) DeepCopyInto(out *OscImageCopy) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(OscCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageCopy.
+func (in *OscImageCopy) DeepCopy() *OscImageCopy {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageCopy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageDistribution) DeepCopyInto(out *OscImageDistribution) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageDistribution.
+func (in *OscImageDistribution) DeepCopy() *OscImageDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OscImageDistribution) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageDistributionList) DeepCopyInto(out *OscImageDistributionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OscImageDistribution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageDistributionList.
+func (in *OscImageDistributionList) DeepCopy() *OscImageDistributionList {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageDistributionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OscImageDistributionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageDistributionSpec) DeepCopyInto(out *OscImageDistributionSpec) {
+	*out = *in
+	out.Credentials = in.Credentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageDistributionSpec.
+func (in *OscImageDistributionSpec) DeepCopy() *OscImageDistributionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageDistributionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscImageDistributionStatus) DeepCopyInto(out *OscImageDistributionStatus) {
+	*out = *in
+	if in.SharedAccountIds != nil {
+		in, out := &in.SharedAccountIds, &out.SharedAccountIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Copies != nil {
+		in, out := &in.Copies, &out.Copies
+		*out = make([]OscImageCopy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscImageDistributionStatus.
+func (in *OscImageDistributionStatus) DeepCopy() *OscImageDistributionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OscImageDistributionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscInternetService) DeepCopyInto(out *OscInternetService) {
 	*out = *in
@@ -463,6 +911,11 @@ func (in *OscKeypair) DeepCopy() *OscKeypair {
 func (in *OscLoadBalancer) DeepCopyInto(out *OscLoadBalancer) {
 	*out = *in
 	out.Listener = in.Listener
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]OscLoadBalancerListener, len(*in))
+		copy(*out, *in)
+	}
 	out.HealthCheck = in.HealthCheck
 }
 
@@ -596,6 +1049,20 @@ func (in *OscMachineResources) DeepCopyInto(out *OscMachineResources) {
 			(*out)[key] = val
 		}
 	}
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BootstrapData != nil {
+		in, out := &in.BootstrapData, &out.BootstrapData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscMachineResources.
@@ -673,6 +1140,11 @@ func (in *OscMachineStatus) DeepCopyInto(out *OscMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AttachedSecurityGroups != nil {
+		in, out := &in.AttachedSecurityGroups, &out.AttachedSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscMachineStatus.
@@ -836,9 +1308,25 @@ func (in *OscMachineWebhook) DeepCopy() *OscMachineWebhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscNatInstance) DeepCopyInto(out *OscNatInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscNatInstance.
+func (in *OscNatInstance) DeepCopy() *OscNatInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(OscNatInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscNatService) DeepCopyInto(out *OscNatService) {
 	*out = *in
+	out.Instance = in.Instance
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscNatService.
@@ -854,6 +1342,7 @@ func (in *OscNatService) DeepCopy() *OscNatService {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscNet) DeepCopyInto(out *OscNet) {
 	*out = *in
+	in.DhcpOptions.DeepCopyInto(&out.DhcpOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscNet.
@@ -891,9 +1380,16 @@ func (in *OscNetwork) DeepCopyInto(out *OscNetwork) {
 		*out = make([]OscDisable, len(*in))
 		copy(*out, *in)
 	}
-	out.LoadBalancer = in.LoadBalancer
-	out.Net = in.Net
+	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
+	in.Net.DeepCopyInto(&out.Net)
 	out.NetPeering = in.NetPeering
+	if in.AdditionalNetPeerings != nil {
+		in, out := &in.AdditionalNetPeerings, &out.AdditionalNetPeerings
+		*out = make([]OscAdditionalNetPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NetAccessPoints != nil {
 		in, out := &in.NetAccessPoints, &out.NetAccessPoints
 		*out = make([]OscNetAccessPointService, len(*in))
@@ -911,6 +1407,11 @@ func (in *OscNetwork) DeepCopyInto(out *OscNetwork) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SubnetPlan != nil {
+		in, out := &in.SubnetPlan, &out.SubnetPlan
+		*out = new(OscSubnetPlan)
+		(*in).DeepCopyInto(*out)
+	}
 	out.InternetService = in.InternetService
 	out.NatService = in.NatService
 	if in.NatServices != nil {
@@ -950,8 +1451,11 @@ func (in *OscNetwork) DeepCopyInto(out *OscNetwork) {
 			}
 		}
 	}
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.Bastion.DeepCopyInto(&out.Bastion)
+	in.ControlPlaneNics.DeepCopyInto(&out.ControlPlaneNics)
+	in.Vpn.DeepCopyInto(&out.Vpn)
+	in.DirectLink.DeepCopyInto(&out.DirectLink)
 	if in.Subregions != nil {
 		in, out := &in.Subregions, &out.Subregions
 		*out = make([]string, len(*in))
@@ -1022,11 +1526,26 @@ func (in *OscNetworkResource) DeepCopy() *OscNetworkResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscNicSlot) DeepCopyInto(out *OscNicSlot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscNicSlot.
+func (in *OscNicSlot) DeepCopy() *OscNicSlot {
+	if in == nil {
+		return nil
+	}
+	out := new(OscNicSlot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscNode) DeepCopyInto(out *OscNode) {
 	*out = *in
 	in.Vm.DeepCopyInto(&out.Vm)
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]OscVolume, len(*in))
@@ -1081,6 +1600,26 @@ func (in *OscPrivateIpElement) DeepCopy() *OscPrivateIpElement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscProxy) DeepCopyInto(out *OscProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscProxy.
+func (in *OscProxy) DeepCopy() *OscProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(OscProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscPublicIp) DeepCopyInto(out *OscPublicIp) {
 	*out = *in
@@ -1251,6 +1790,21 @@ func (in *OscSecurityGroupElement) DeepCopy() *OscSecurityGroupElement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscSecurityGroupMember) DeepCopyInto(out *OscSecurityGroupMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscSecurityGroupMember.
+func (in *OscSecurityGroupMember) DeepCopy() *OscSecurityGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(OscSecurityGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscSecurityGroupRule) DeepCopyInto(out *OscSecurityGroupRule) {
 	*out = *in
@@ -1259,6 +1813,11 @@ func (in *OscSecurityGroupRule) DeepCopyInto(out *OscSecurityGroupRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecurityGroupMembers != nil {
+		in, out := &in.SecurityGroupMembers, &out.SecurityGroupMembers
+		*out = make([]OscSecurityGroupMember, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscSecurityGroupRule.
@@ -1291,6 +1850,61 @@ func (in *OscSubnet) DeepCopy() *OscSubnet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscSubnetCapacity) DeepCopyInto(out *OscSubnetCapacity) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]OscRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscSubnetCapacity.
+func (in *OscSubnetCapacity) DeepCopy() *OscSubnetCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(OscSubnetCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscSubnetPlan) DeepCopyInto(out *OscSubnetPlan) {
+	*out = *in
+	if in.ReservedRanges != nil {
+		in, out := &in.ReservedRanges, &out.ReservedRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscSubnetPlan.
+func (in *OscSubnetPlan) DeepCopy() *OscSubnetPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(OscSubnetPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscUserDataPart) DeepCopyInto(out *OscUserDataPart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscUserDataPart.
+func (in *OscUserDataPart) DeepCopy() *OscUserDataPart {
+	if in == nil {
+		return nil
+	}
+	out := new(OscUserDataPart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OscVm) DeepCopyInto(out *OscVm) {
 	*out = *in
@@ -1300,6 +1914,13 @@ func (in *OscVm) DeepCopyInto(out *OscVm) {
 		*out = make([]OscPrivateIpElement, len(*in))
 		copy(*out, *in)
 	}
+	if in.AddressesFromPools != nil {
+		in, out := &in.AddressesFromPools, &out.AddressesFromPools
+		*out = make([]v1.TypedLocalObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SecurityGroupNames != nil {
 		in, out := &in.SecurityGroupNames, &out.SecurityGroupNames
 		*out = make([]OscSecurityGroupElement, len(*in))
@@ -1312,6 +1933,12 @@ func (in *OscVm) DeepCopyInto(out *OscVm) {
 			(*out)[key] = val
 		}
 	}
+	in.DedicatedSecurityGroup.DeepCopyInto(&out.DedicatedSecurityGroup)
+	if in.AdditionalUserData != nil {
+		in, out := &in.AdditionalUserData, &out.AdditionalUserData
+		*out = make([]OscUserDataPart, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscVm.
@@ -1338,3 +1965,45 @@ func (in *OscVolume) DeepCopy() *OscVolume {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscVpn) DeepCopyInto(out *OscVpn) {
+	*out = *in
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = make([]OscVpnConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscVpn.
+func (in *OscVpn) DeepCopy() *OscVpn {
+	if in == nil {
+		return nil
+	}
+	out := new(OscVpn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscVpnConnection) DeepCopyInto(out *OscVpnConnection) {
+	*out = *in
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscVpnConnection.
+func (in *OscVpnConnection) DeepCopy() *OscVpnConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(OscVpnConnection)
+	in.DeepCopyInto(out)
+	return out
+}
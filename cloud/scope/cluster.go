@@ -16,6 +16,8 @@ import (
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -111,11 +113,47 @@ func (s *ClusterScope) GetNetwork() *infrastructurev1beta1.OscNetwork {
 // GetNet return the net of the cluster
 func (s *ClusterScope) GetNet() infrastructurev1beta1.OscNet {
 	if s.OscCluster.Spec.Network.Net.IsZero() {
-		return infrastructurev1beta1.DefaultNet
+		net := infrastructurev1beta1.DefaultNet
+		net.DhcpOptions = s.OscCluster.Spec.Network.Net.DhcpOptions
+		return net
 	}
 	return s.OscCluster.Spec.Network.Net
 }
 
+// GetNoProxy returns the hosts, domains and CIDRs that nodes reach without the proxy.
+func (s *ClusterScope) GetNoProxy() []string {
+	noProxy := []string{"localhost", "127.0.0.1", "169.254.169.254"}
+	switch {
+	case !s.GetNetwork().UseExisting.Net:
+		noProxy = append(noProxy, s.GetNet().IpRange)
+	case s.OscCluster.Spec.Network.Net.IpRange != "":
+		noProxy = append(noProxy, s.OscCluster.Spec.Network.Net.IpRange)
+	default:
+		for _, subnet := range s.GetSubnets() {
+			if subnet.IpSubnetRange != "" {
+				noProxy = append(noProxy, subnet.IpSubnetRange)
+			}
+		}
+	}
+	if cn := s.Cluster.Spec.ClusterNetwork; cn != nil {
+		if cn.Services != nil {
+			noProxy = append(noProxy, cn.Services.CIDRBlocks...)
+		}
+		if cn.ServiceDomain != "" {
+			noProxy = append(noProxy, ".svc", "."+cn.ServiceDomain)
+		}
+	}
+	if host := s.OscCluster.Spec.ControlPlaneEndpoint.Host; host != "" {
+		noProxy = append(noProxy, host)
+	}
+	for _, np := range s.OscCluster.Spec.Proxy.NoProxy {
+		if !slices.Contains(noProxy, np) {
+			noProxy = append(noProxy, np)
+		}
+	}
+	return noProxy
+}
+
 // GetNetName return the name of the net
 func (s *ClusterScope) GetNetName() string {
 	if s.OscCluster.Spec.Network.Net.Name != "" {
@@ -140,14 +178,27 @@ func (s *ClusterScope) GetSubregions() []string {
 	return []string{s.GetNetwork().SubregionName}
 }
 
-// GetSubnets returns the subnets of the cluster.
+// GetSubnets returns the subnets of the cluster, or nil if they cannot be computed.
 func (s *ClusterScope) GetSubnets() []infrastructurev1beta1.OscSubnet {
+	subnets, _ := s.ListSubnets()
+	return subnets
+}
+
+// ListSubnets returns the subnets of the cluster, or an error if the subnet plan cannot be applied to the net.
+func (s *ClusterScope) ListSubnets() ([]infrastructurev1beta1.OscSubnet, error) {
 	if len(s.OscCluster.Spec.Network.Subnets) > 0 {
-		return s.OscCluster.Spec.Network.Subnets
+		return s.OscCluster.Spec.Network.Subnets, nil
+	}
+	if plan := s.OscCluster.Spec.Network.SubnetPlan; plan != nil {
+		subnets, err := plan.Plan(s.GetNet().IpRange, s.GetSubregions())
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply subnet plan: %w", err)
+		}
+		return subnets, nil
 	}
 	_, net, err := net.ParseCIDR(s.GetNet().IpRange)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("cannot parse net ipRange: %w", err)
 	}
 	fds := s.GetSubregions()
 	subnets := make([]infrastructurev1beta1.OscSubnet, 0, 3*len(fds))
@@ -166,7 +217,7 @@ func (s *ClusterScope) GetSubnets() []infrastructurev1beta1.OscSubnet {
 			subnets = append(subnets, subnet)
 		}
 	}
-	return subnets
+	return subnets, nil
 }
 
 var ErrNoSubnetFound = errors.New("subnet not found")
@@ -187,6 +238,24 @@ func (s *ClusterScope) GetSubnet(name string, role infrastructurev1beta1.OscRole
 	return infrastructurev1beta1.OscSubnet{}, ErrNoSubnetFound
 }
 
+// GetSubnetCandidates returns the subnet having a name, or all subnets having a role in a subregion.
+func (s *ClusterScope) GetSubnetCandidates(name string, role infrastructurev1beta1.OscRole, subregion string) []infrastructurev1beta1.OscSubnet {
+	if subregion == "" {
+		subregion = s.GetDefaultSubregion()
+	}
+	var candidates []infrastructurev1beta1.OscSubnet
+	for _, spec := range s.GetSubnets() {
+		switch {
+		case name != "" && spec.Name == name:
+			return []infrastructurev1beta1.OscSubnet{spec}
+		case !s.SubnetHasRole(spec, role):
+		case s.GetSubnetSubregion(spec) == subregion:
+			candidates = append(candidates, spec)
+		}
+	}
+	return candidates
+}
+
 func (s *ClusterScope) SubnetHasRole(spec infrastructurev1beta1.OscSubnet, role infrastructurev1beta1.OscRole) bool {
 	if len(spec.Roles) > 0 {
 		return slices.Contains(spec.Roles, role)
@@ -228,9 +297,35 @@ func (s *ClusterScope) GetSubnetName(spec infrastructurev1beta1.OscSubnet) strin
 	}
 }
 
+// IsAirgapped checks if the network is in airgapped mode.
+func (s *ClusterScope) IsAirgapped() bool {
+	return s.GetNetwork().Mode == infrastructurev1beta1.NetworkModeAirgapped
+}
+
 // IsInternetDisabled checks if internet is disabled.
 func (s *ClusterScope) IsInternetDisabled() bool {
-	return slices.Contains(s.GetNetwork().Disable, infrastructurev1beta1.DisableInternet)
+	return s.IsAirgapped() || slices.Contains(s.GetNetwork().Disable, infrastructurev1beta1.DisableInternet)
+}
+
+// airgappedNetAccessPoints are the services required by an airgapped cluster.
+var airgappedNetAccessPoints = []infrastructurev1beta1.OscNetAccessPointService{
+	infrastructurev1beta1.ServiceAPI, infrastructurev1beta1.ServiceEIM, infrastructurev1beta1.ServiceLBU,
+	infrastructurev1beta1.ServiceOOS, infrastructurev1beta1.ServiceKMS,
+}
+
+// GetNetAccessPoints returns the services having a net access point, including the ones required in airgapped mode.
+func (s *ClusterScope) GetNetAccessPoints() []infrastructurev1beta1.OscNetAccessPointService {
+	naps := s.GetNetwork().NetAccessPoints
+	if !s.IsAirgapped() {
+		return naps
+	}
+	all := slices.Clone(airgappedNetAccessPoints)
+	for _, nap := range naps {
+		if !slices.Contains(all, nap) {
+			all = append(all, nap)
+		}
+	}
+	return all
 }
 
 // IsLBDisabled checks if loadbalancer is disabled.
@@ -258,7 +353,7 @@ func (s *ClusterScope) GetNatServices() []infrastructurev1beta1.OscNatService {
 		return nil
 	case len(s.OscCluster.Spec.Network.NatServices) > 0:
 		return s.OscCluster.Spec.Network.NatServices
-	case s.OscCluster.Spec.Network.NatService != infrastructurev1beta1.OscNatService{}:
+	case s.hasSingleNatService():
 		return []infrastructurev1beta1.OscNatService{s.OscCluster.Spec.Network.NatService}
 	default:
 		var nss []infrastructurev1beta1.OscNatService
@@ -269,12 +364,57 @@ func (s *ClusterScope) GetNatServices() []infrastructurev1beta1.OscNatService {
 			nss = append(nss, infrastructurev1beta1.OscNatService{
 				SubregionName: s.GetSubnetSubregion(subnet),
 				SubnetName:    subnet.Name,
+				Mode:          s.OscCluster.Spec.Network.NatService.Mode,
+				Instance:      s.OscCluster.Spec.Network.NatService.Instance,
 			})
 		}
 		return nss
 	}
 }
 
+// hasSingleNatService returns true if natService defines a single NAT (v0.4 configs), and not only the NAT mode.
+func (s *ClusterScope) hasSingleNatService() bool {
+	nat := s.OscCluster.Spec.Network.NatService
+	nat.Mode = ""
+	nat.Instance = infrastructurev1beta1.OscNatInstance{}
+	return nat != infrastructurev1beta1.OscNatService{}
+}
+
+// IsNatInstance returns true if the NAT is a VM.
+func (s *ClusterScope) IsNatInstance(nat infrastructurev1beta1.OscNatService) bool {
+	return nat.Mode == infrastructurev1beta1.NatServiceModeInstance
+}
+
+// HasNatInstances returns true if at least one NAT is a VM.
+func (s *ClusterScope) HasNatInstances() bool {
+	return slices.ContainsFunc(s.GetNatServices(), s.IsNatInstance)
+}
+
+// GetNatInstanceClientToken returns the client token of a NAT instance.
+// When a NAT instance replaces another one, the ID of the replaced VM is part of the client token.
+func (s *ClusterScope) GetNatInstanceClientToken(nat infrastructurev1beta1.OscNatService, replacedVmId string) string {
+	ct := "nat-" + s.GetNatServiceClientToken(nat)
+	if replacedVmId != "" {
+		ct += "-" + replacedVmId
+	}
+	if len(ct) > 64 {
+		ct = ct[len(ct)-64:]
+	}
+	return ct
+}
+
+// GetNatInstanceName returns the name of a NAT instance.
+func (s *ClusterScope) GetNatInstanceName(nat infrastructurev1beta1.OscNatService) string {
+	if nat.Name != "" {
+		return nat.Name
+	}
+	name := "NAT instance for " + s.OscCluster.Name
+	if nat.SubregionName != "" {
+		name += "/" + nat.SubregionName
+	}
+	return name
+}
+
 // GetNatService return the natService of the cluster
 func (s *ClusterScope) GetNatService(name string, subregion string) (infrastructurev1beta1.OscNatService, error) {
 	nats := s.GetNatServices()
@@ -366,9 +506,45 @@ func (s *ClusterScope) getAdditionalRules(roles ...infrastructurev1beta1.OscRole
 // GetSecurityGroups returns the list of all security groups for the cluster.
 func (s *ClusterScope) GetSecurityGroups() []infrastructurev1beta1.OscSecurityGroup {
 	if len(s.OscCluster.Spec.Network.SecurityGroups) > 0 {
-		return s.getManualSecurityGroups()
+		return s.compactSecurityGroups(s.getManualSecurityGroups())
+	}
+	sgs := s.getAutomaticSecurityGroups()
+	if s.HasNatInstances() {
+		sgs = append(sgs, s.getNatInstanceSecurityGroup())
+	}
+	if s.OscCluster.Spec.Network.SecurityGroupAudit {
+		for i := range sgs {
+			sgs[i].Authoritative = false
+			sgs[i].Audit = true
+		}
+	}
+	return s.compactSecurityGroups(sgs)
+}
+
+// getNatInstanceSecurityGroup returns the security group of NAT instances, forwarding all traffic from the net.
+func (s *ClusterScope) getNatInstanceSecurityGroup() infrastructurev1beta1.OscSecurityGroup {
+	allowedOut := s.OscCluster.Spec.Network.AllowToIPRanges
+	switch {
+	case len(allowedOut) == 0:
+		allowedOut = []string{"0.0.0.0/0"}
+	case allowedOut[0] == "":
+		allowedOut = nil
+	}
+	nat := infrastructurev1beta1.OscSecurityGroup{
+		Name:        s.GetName() + "-nat",
+		Description: "NAT instance securityGroup for " + s.GetName(),
+		Roles:       []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleNat},
+		SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{
+			{Flow: "Inbound", IpProtocol: "-1", FromPortRange: -1, ToPortRange: -1, IpRange: s.GetNet().IpRange},
+		},
+		Authoritative: true,
+	}
+	if len(allowedOut) > 0 {
+		nat.SecurityGroupRules = append(nat.SecurityGroupRules,
+			infrastructurev1beta1.OscSecurityGroupRule{Flow: "Outbound", IpProtocol: "-1", FromPortRange: -1, ToPortRange: -1, IpRanges: allowedOut},
+		)
 	}
-	return s.getAutomaticSecurityGroups()
+	return nat
 }
 
 func (s *ClusterScope) getManualSecurityGroups() []infrastructurev1beta1.OscSecurityGroup {
@@ -478,28 +654,13 @@ func (s *ClusterScope) getAutomaticSecurityGroups() []infrastructurev1beta1.OscS
 		Description: "Node securityGroup for " + s.GetName(),
 		Roles:       []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleControlPlane, infrastructurev1beta1.RoleWorker},
 		SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{
-
-			// Calico - see https://docs.tigera.io/calico/latest/getting-started/kubernetes/requirements#network-requirements
-			{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 179, ToPortRange: 179, IpRange: s.GetNet().IpRange},     // BGP
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 4789, ToPortRange: 4789, IpRange: s.GetNet().IpRange},   // VXLAN/flannel
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 5473, ToPortRange: 5473, IpRange: s.GetNet().IpRange},   // Typha
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8285, ToPortRange: 8285, IpRange: s.GetNet().IpRange},   // Flannel
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51820, ToPortRange: 51821, IpRange: s.GetNet().IpRange}, // Wiregard
-			{Flow: "Inbound", IpProtocol: "4", FromPortRange: -1, ToPortRange: -1, IpRange: s.GetNet().IpRange},         // IP-in-IP
-
-			// Cillium - see https://docs.cilium.io/en/stable/operations/system_requirements/#firewall-rules
-			{Flow: "Inbound", IpProtocol: "icmp", FromPortRange: 8, ToPortRange: 8, IpRange: s.GetNet().IpRange},        // ICMP
-			{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4240, ToPortRange: 4240, IpRange: s.GetNet().IpRange},   // Health
-			{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4244, ToPortRange: 4244, IpRange: s.GetNet().IpRange},   // Hubble
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8472, ToPortRange: 8472, IpRange: s.GetNet().IpRange},   // VXLAN
-			{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51871, ToPortRange: 51871, IpRange: s.GetNet().IpRange}, // Wiregard
-
 			{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 30000, ToPortRange: 32767, IpRange: s.GetNet().IpRange}, // NodePort
 			{Flow: "Outbound", IpProtocol: "-1", FromPortRange: -1, ToPortRange: -1, IpRange: s.GetNet().IpRange},       // internal trafic
 		},
 		Tag:           "OscK8sMainSG",
 		Authoritative: true,
 	}
+	node.SecurityGroupRules = append(node.SecurityGroupRules, s.getCNIRules()...)
 	// Outbound traffic
 	if len(allowedOut) > 0 {
 		node.SecurityGroupRules = append(node.SecurityGroupRules,
@@ -540,15 +701,21 @@ var ErrNoSecurityGroupFound = errors.New("no security group found")
 // getSecurityGroupsForNames returns the security group having names.
 func (s *ClusterScope) getSecurityGroupsForNames(names []infrastructurev1beta1.OscSecurityGroupElement) ([]infrastructurev1beta1.OscSecurityGroup, error) {
 	var sgs []infrastructurev1beta1.OscSecurityGroup
-LOOPNAMES:
+	all := s.GetSecurityGroups()
 	for _, name := range names {
-		for _, spec := range s.GetSecurityGroups() {
-			if spec.Name == name.Name {
-				sgs = append(sgs, spec)
-				continue LOOPNAMES
+		found := false
+		for _, spec := range all {
+			switch {
+			case spec.Name == name.Name:
+				found = true
+			case !isOverflowSecurityGroupOf(spec, name.Name):
+				continue
 			}
+			sgs = append(sgs, spec)
+		}
+		if !found {
+			return nil, ErrNoSecurityGroupFound
 		}
-		return nil, ErrNoSecurityGroupFound
 	}
 	return sgs, nil
 }
@@ -579,6 +746,11 @@ func (s *ClusterScope) GetSecurityGroupName(sg infrastructurev1beta1.OscSecurity
 	return name + s.GetUID()
 }
 
+// SetFailureDomains replaces all infrastructure provider failure domains.
+func (s *ClusterScope) SetFailureDomains(fds clusterv1.FailureDomains) {
+	s.OscCluster.Status.FailureDomains = fds
+}
+
 // SetFailureDomain sets the infrastructure provider failure domain key to the spec given as input.
 func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainSpec) {
 	if s.OscCluster.Status.FailureDomains == nil {
@@ -593,6 +765,9 @@ func (s *ClusterScope) GetLoadBalancer() infrastructurev1beta1.OscLoadBalancer {
 	if lb.LoadBalancerName == "" {
 		lb.LoadBalancerName = s.GetName() + "-k8s"
 	}
+	if s.IsAirgapped() {
+		lb.LoadBalancerType = "internal"
+	}
 	lb.SetDefaultValue()
 	return lb
 }
@@ -659,6 +834,86 @@ func (s *ClusterScope) SetReady() {
 	s.OscCluster.Status.Ready = true
 }
 
+// GetControlPlaneNicSlots returns the slots of pre-created controlplane NICs, if enabled.
+func (s *ClusterScope) GetControlPlaneNicSlots() []infrastructurev1beta1.OscNicSlot {
+	spec := s.GetNetwork().ControlPlaneNics
+	if !spec.Enable {
+		return nil
+	}
+	if len(spec.Slots) > 0 {
+		slots := slices.Clone(spec.Slots)
+		for i := range slots {
+			if slots[i].SubregionName == "" {
+				slots[i].SubregionName = s.GetDefaultSubregion()
+			}
+		}
+		return slots
+	}
+	var slots []infrastructurev1beta1.OscNicSlot
+	for _, subnet := range s.GetSubnets() {
+		if !s.SubnetHasRole(subnet, infrastructurev1beta1.RoleControlPlane) {
+			continue
+		}
+		subregion := s.GetSubnetSubregion(subnet)
+		if slices.ContainsFunc(slots, func(slot infrastructurev1beta1.OscNicSlot) bool { return slot.SubregionName == subregion }) {
+			continue
+		}
+		slots = append(slots, infrastructurev1beta1.OscNicSlot{
+			Name:          subregion,
+			SubregionName: subregion,
+		})
+	}
+	return slots
+}
+
+// GetControlPlaneNicName returns the name of the NIC of a slot.
+func (s *ClusterScope) GetControlPlaneNicName(slot infrastructurev1beta1.OscNicSlot) string {
+	return "controlplane-" + slot.Name + "-" + s.GetUID()
+}
+
+// GetVirtualGatewayName returns the name of the virtual gateway.
+func (s *ClusterScope) GetVirtualGatewayName() string {
+	return "Virtual gateway for " + s.OscCluster.Name
+}
+
+// GetDhcpOptionsName returns the name of the DHCP options set.
+func (s *ClusterScope) GetDhcpOptionsName() string {
+	return "DHCP options for " + s.OscCluster.Name
+}
+
+// GetVpnConnectionName returns the name of the client gateway and VPN connection.
+func (s *ClusterScope) GetVpnConnectionName(conn infrastructurev1beta1.OscVpnConnection) string {
+	return "vpn-" + conn.Name + "-" + s.GetUID()
+}
+
+// GetAdditionalNetPeeringName returns the name of an additional net peering.
+func (s *ClusterScope) GetAdditionalNetPeeringName(np infrastructurev1beta1.OscAdditionalNetPeering) string {
+	return "netpeering-" + np.Name + "-" + s.GetUID()
+}
+
+// GetDirectLinkInterfaceName returns the name of the DirectLink interface of the cluster.
+func (s *ClusterScope) GetDirectLinkInterfaceName() string {
+	return "directlink-" + s.GetUID()
+}
+
+// GetVpnSecretName returns the name of the secret storing the VPN tunnel configurations.
+func (s *ClusterScope) GetVpnSecretName() string {
+	return s.OscCluster.Name + "-vpn"
+}
+
+// GetSecretValue returns the value of a key of a secret in the namespace of the cluster.
+func (s *ClusterScope) GetSecretValue(ctx context.Context, name, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.GetNamespace(), Name: name}, secret); err != nil {
+		return "", fmt.Errorf("failed to retrieve secret %s: %w", name, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s is missing in secret %s", key, name)
+	}
+	return string(value), nil
+}
+
 // GetBastion return the vm bastion
 func (s *ClusterScope) GetBastion() infrastructurev1beta1.OscBastion {
 	if !s.OscCluster.Spec.Network.Bastion.Enable {
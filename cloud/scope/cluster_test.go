@@ -222,3 +222,190 @@ func TestClusterScope_GetSecurityGroups(t *testing.T) {
 		}
 	}
 }
+
+func TestClusterScope_GetSecurityGroups_CNI(t *testing.T) {
+	clusterScope := &scope.ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+				UID:  "abcd",
+			},
+		},
+		OscCluster: &infrastructurev1beta1.OscCluster{
+			Spec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					SubregionName: "eu-west2a",
+					CNI:           infrastructurev1beta1.CNICilium,
+				},
+			},
+		},
+	}
+	sgs := clusterScope.GetSecurityGroups()
+	for _, sg := range sgs {
+		if !sg.HasRole(infrastructurev1beta1.RoleWorker) || !sg.HasRole(infrastructurev1beta1.RoleControlPlane) {
+			continue
+		}
+		assert.Contains(t, sg.SecurityGroupRules, infrastructurev1beta1.OscSecurityGroupRule{
+			Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8472, ToPortRange: 8472, IpRange: clusterScope.GetNet().IpRange,
+		})
+		assert.NotContains(t, sg.SecurityGroupRules, infrastructurev1beta1.OscSecurityGroupRule{
+			Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 179, ToPortRange: 179, IpRange: clusterScope.GetNet().IpRange,
+		})
+	}
+	assert.False(t, clusterScope.NeedCNIPresetUpdate(), "clusters without a preset version use the first version")
+	clusterScope.OscCluster.Status.CNIPresetVersion = "0"
+	assert.True(t, clusterScope.NeedCNIPresetUpdate())
+	clusterScope.SetCNIPresetVersion()
+	assert.False(t, clusterScope.NeedCNIPresetUpdate())
+}
+
+func TestClusterScope_GetSecurityGroups_NatInstance(t *testing.T) {
+	clusterScope := &scope.ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+				UID:  "abcd",
+			},
+		},
+		OscCluster: &infrastructurev1beta1.OscCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+			Spec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Subregions: []string{"eu-west-2a", "eu-west-2b"},
+					NatService: infrastructurev1beta1.OscNatService{
+						Mode:     infrastructurev1beta1.NatServiceModeInstance,
+						Instance: infrastructurev1beta1.OscNatInstance{ImageId: "ami-foo"},
+					},
+				},
+			},
+		},
+	}
+	nats := clusterScope.GetNatServices()
+	require.Len(t, nats, 2)
+	for _, nat := range nats {
+		assert.True(t, clusterScope.IsNatInstance(nat), "the mode is propagated to each subregion")
+		assert.Equal(t, "ami-foo", nat.Instance.ImageId)
+	}
+	assert.Equal(t, "nat-eu-west-2a-abcd", clusterScope.GetNatInstanceClientToken(nats[0], ""))
+	assert.Equal(t, "nat-eu-west-2a-abcd-i-foo", clusterScope.GetNatInstanceClientToken(nats[0], "i-foo"))
+
+	sgs, err := clusterScope.GetSecurityGroupsFor(nil, infrastructurev1beta1.RoleNat)
+	require.NoError(t, err)
+	require.Len(t, sgs, 1)
+	assert.Contains(t, sgs[0].SecurityGroupRules, infrastructurev1beta1.OscSecurityGroupRule{
+		Flow: "Inbound", IpProtocol: "-1", FromPortRange: -1, ToPortRange: -1, IpRange: clusterScope.GetNet().IpRange,
+	})
+	assert.Contains(t, sgs[0].SecurityGroupRules, infrastructurev1beta1.OscSecurityGroupRule{
+		Flow: "Outbound", IpProtocol: "-1", FromPortRange: -1, ToPortRange: -1, IpRanges: []string{"0.0.0.0/0"},
+	})
+
+	clusterScope.OscCluster.Spec.Network.NatService.Mode = infrastructurev1beta1.NatServiceModeService
+	sgs, err = clusterScope.GetSecurityGroupsFor(nil, infrastructurev1beta1.RoleNat)
+	require.NoError(t, err)
+	assert.Empty(t, sgs, "no nat securityGroup is required with NAT services")
+}
+
+func TestClusterScope_GetSecurityGroups_Quota(t *testing.T) {
+	clusterScope := &scope.ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+				UID:  "abcd",
+			},
+		},
+		OscCluster: &infrastructurev1beta1.OscCluster{
+			Spec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					SecurityGroupRuleQuota: 2,
+					SecurityGroups: []infrastructurev1beta1.OscSecurityGroup{{
+						Name: "kw",
+						SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+							Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 22, ToPortRange: 22, IpRanges: []string{"10.0.0.0/24", "10.0.2.0/24", "10.0.4.0/24"},
+						}},
+					}, {
+						Name: "kcp",
+					}},
+				},
+			},
+		},
+	}
+	sgs, err := clusterScope.GetSecurityGroupsFor([]infrastructurev1beta1.OscSecurityGroupElement{{Name: "kw"}}, "")
+	require.NoError(t, err)
+	require.Len(t, sgs, 2)
+	assert.Equal(t, []string{"10.0.0.0/24", "10.0.2.0/24"}, sgs[0].SecurityGroupRules[0].IpRanges)
+	assert.Equal(t, "kw-overflow-1", sgs[1].Name)
+	assert.Equal(t, []string{"10.0.4.0/24"}, sgs[1].SecurityGroupRules[0].IpRanges)
+
+	clusterScope.OscCluster.Status.Resources.SecurityGroup = map[string]string{"kw-overflow-1-abcd": "sg-foo"}
+	clusterScope.OscCluster.Spec.Network.SecurityGroupRuleQuota = 0
+	sgs, err = clusterScope.GetSecurityGroupsFor([]infrastructurev1beta1.OscSecurityGroupElement{{Name: "kw"}}, "")
+	require.NoError(t, err)
+	require.Len(t, sgs, 2, "overflow securityGroups are kept")
+	assert.Len(t, sgs[0].SecurityGroupRules, 1)
+	assert.Empty(t, sgs[1].SecurityGroupRules)
+}
+
+func TestClusterScope_Airgapped(t *testing.T) {
+	clusterScope := &scope.ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+				UID:  "abcd",
+			},
+		},
+		OscCluster: &infrastructurev1beta1.OscCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+			Spec: infrastructurev1beta1.OscClusterSpec{
+				Network: infrastructurev1beta1.OscNetwork{
+					Mode:            infrastructurev1beta1.NetworkModeAirgapped,
+					NetAccessPoints: []infrastructurev1beta1.OscNetAccessPointService{infrastructurev1beta1.ServiceKMS, infrastructurev1beta1.ServiceDirectLink},
+					LoadBalancer: infrastructurev1beta1.OscLoadBalancer{
+						LoadBalancerType: "internet-facing",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, clusterScope.IsInternetDisabled())
+	assert.Equal(t, []infrastructurev1beta1.OscNetAccessPointService{
+		infrastructurev1beta1.ServiceAPI, infrastructurev1beta1.ServiceEIM, infrastructurev1beta1.ServiceLBU,
+		infrastructurev1beta1.ServiceOOS, infrastructurev1beta1.ServiceKMS, infrastructurev1beta1.ServiceDirectLink,
+	}, clusterScope.GetNetAccessPoints())
+	assert.Equal(t, "internal", clusterScope.GetLoadBalancer().LoadBalancerType)
+}
+
+func TestClusterScope_GetNoProxy(t *testing.T) {
+	clusterScope := &scope.ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+				UID:  "abcd",
+			},
+			Spec: clusterv1.ClusterSpec{
+				ClusterNetwork: &clusterv1.ClusterNetwork{
+					Services:      &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+					ServiceDomain: "cluster.local",
+				},
+			},
+		},
+		OscCluster: &infrastructurev1beta1.OscCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+			Spec: infrastructurev1beta1.OscClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "foo.lbu.outscale.com"},
+				Proxy: infrastructurev1beta1.OscProxy{
+					HTTPProxy: "http://proxy:3128",
+					NoProxy:   []string{".example.com", "localhost"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{
+		"localhost", "127.0.0.1", "169.254.169.254", "10.0.0.0/16", "10.96.0.0/12", ".svc", ".cluster.local", "foo.lbu.outscale.com", ".example.com",
+	}, clusterScope.GetNoProxy())
+}
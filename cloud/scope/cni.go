@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package scope
+
+import (
+	"cmp"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+)
+
+// CNIPresetVersion is the version of the CNI presets, bumped each time presets are changed,
+// so that the rules of existing clusters are updated after a provider upgrade.
+const CNIPresetVersion = "1"
+
+// firstCNIPresetVersion is the version of the presets used by clusters created before presets were versioned.
+const firstCNIPresetVersion = "1"
+
+var cniPresets = map[infrastructurev1beta1.OscCNI][]infrastructurev1beta1.OscSecurityGroupRule{
+	// see https://docs.tigera.io/calico/latest/getting-started/kubernetes/requirements#network-requirements
+	infrastructurev1beta1.CNICalico: {
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 179, ToPortRange: 179},     // BGP
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 4789, ToPortRange: 4789},   // VXLAN
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 5473, ToPortRange: 5473},   // Typha
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51820, ToPortRange: 51821}, // Wireguard
+		{Flow: "Inbound", IpProtocol: "4", FromPortRange: -1, ToPortRange: -1},         // IP-in-IP
+	},
+	// see https://docs.cilium.io/en/stable/operations/system_requirements/#firewall-rules
+	infrastructurev1beta1.CNICilium: {
+		{Flow: "Inbound", IpProtocol: "icmp", FromPortRange: 8, ToPortRange: 8},        // Health (ICMP)
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4240, ToPortRange: 4240},   // Health
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4244, ToPortRange: 4244},   // Hubble
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8472, ToPortRange: 8472},   // VXLAN
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51871, ToPortRange: 51871}, // Wireguard
+	},
+	// see https://github.com/flannel-io/flannel/blob/master/Documentation/backends.md
+	infrastructurev1beta1.CNIFlannel: {
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8472, ToPortRange: 8472},   // VXLAN
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8285, ToPortRange: 8285},   // UDP backend
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51820, ToPortRange: 51820}, // Wireguard
+	},
+	// Rules used when no CNI is set, allowing both Calico and Cilium.
+	"": {
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 179, ToPortRange: 179},     // BGP
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 4789, ToPortRange: 4789},   // VXLAN/flannel
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 5473, ToPortRange: 5473},   // Typha
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8285, ToPortRange: 8285},   // Flannel
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51820, ToPortRange: 51821}, // Wiregard
+		{Flow: "Inbound", IpProtocol: "4", FromPortRange: -1, ToPortRange: -1},         // IP-in-IP
+		{Flow: "Inbound", IpProtocol: "icmp", FromPortRange: 8, ToPortRange: 8},        // ICMP
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4240, ToPortRange: 4240},   // Health
+		{Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 4244, ToPortRange: 4244},   // Hubble
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 8472, ToPortRange: 8472},   // VXLAN
+		{Flow: "Inbound", IpProtocol: "udp", FromPortRange: 51871, ToPortRange: 51871}, // Wiregard
+	},
+}
+
+// getCNIRules returns the node rules required by the CNI of the cluster, scoped to the net CIDR.
+func (s *ClusterScope) getCNIRules() []infrastructurev1beta1.OscSecurityGroupRule {
+	preset := cniPresets[s.GetNetwork().CNI]
+	rules := make([]infrastructurev1beta1.OscSecurityGroupRule, 0, len(preset))
+	for _, rule := range preset {
+		rule.IpRange = s.GetNet().IpRange
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// NeedCNIPresetUpdate returns true if the CNI rules of the cluster have been computed from an older version of the presets.
+// Clusters created before presets were versioned use rules matching the first version.
+func (s *ClusterScope) NeedCNIPresetUpdate() bool {
+	version := cmp.Or(s.OscCluster.Status.CNIPresetVersion, firstCNIPresetVersion)
+	return len(s.OscCluster.Spec.Network.SecurityGroups) == 0 && version != CNIPresetVersion
+}
+
+// SetCNIPresetVersion records the version of the CNI presets applied to the cluster.
+func (s *ClusterScope) SetCNIPresetVersion() {
+	if len(s.OscCluster.Spec.Network.SecurityGroups) == 0 {
+		s.OscCluster.Status.CNIPresetVersion = CNIPresetVersion
+	}
+}
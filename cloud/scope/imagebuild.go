@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageBuildScopeParams is a collection of input parameters to create a new scope
+type ImageBuildScopeParams struct {
+	Client        client.Client
+	OscImageBuild *infrastructurev1beta1.OscImageBuild
+}
+
+// NewImageBuildScope creates a new ImageBuildScope from parameters which is called at each reconciliation iteration
+func NewImageBuildScope(params ImageBuildScopeParams) (*ImageBuildScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("Client is required when creating a ImageBuildScope")
+	}
+
+	helper, err := patch.NewHelper(params.OscImageBuild, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+	return &ImageBuildScope{
+		client:        params.Client,
+		OscImageBuild: params.OscImageBuild,
+		patchHelper:   helper,
+	}, nil
+}
+
+// ImageBuildScope is the basic context of the actuator that will be used
+type ImageBuildScope struct {
+	client        client.Client
+	patchHelper   *patch.Helper
+	OscImageBuild *infrastructurev1beta1.OscImageBuild
+}
+
+// Close closes the scope of the image build configuration and status
+func (s *ImageBuildScope) Close(ctx context.Context) error {
+	return s.patchHelper.Patch(ctx, s.OscImageBuild)
+}
+
+// GetName return the name of the image build
+func (s *ImageBuildScope) GetName() string {
+	return s.OscImageBuild.Name
+}
+
+// GetNamespace return the namespace of the image build
+func (s *ImageBuildScope) GetNamespace() string {
+	return s.OscImageBuild.Namespace
+}
+
+// GetImageId returns the ID of the built image.
+func (s *ImageBuildScope) GetImageId() string {
+	return s.OscImageBuild.Status.ImageId
+}
+
+// SetImageId sets the ID of the built image.
+func (s *ImageBuildScope) SetImageId(imageId string) {
+	s.OscImageBuild.Status.ImageId = imageId
+}
+
+// SetReady marks the image as available.
+func (s *ImageBuildScope) SetReady() {
+	s.OscImageBuild.Status.Ready = true
+}
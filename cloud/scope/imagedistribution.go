@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageDistributionScopeParams is a collection of input parameters to create a new scope
+type ImageDistributionScopeParams struct {
+	Client               client.Client
+	OscImageDistribution *infrastructurev1beta1.OscImageDistribution
+}
+
+// NewImageDistributionScope creates a new ImageDistributionScope from parameters which is called at each reconciliation iteration
+func NewImageDistributionScope(params ImageDistributionScopeParams) (*ImageDistributionScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("Client is required when creating a ImageDistributionScope")
+	}
+
+	helper, err := patch.NewHelper(params.OscImageDistribution, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+	return &ImageDistributionScope{
+		client:               params.Client,
+		OscImageDistribution: params.OscImageDistribution,
+		patchHelper:          helper,
+	}, nil
+}
+
+// ImageDistributionScope is the basic context of the actuator that will be used
+type ImageDistributionScope struct {
+	client               client.Client
+	patchHelper          *patch.Helper
+	OscImageDistribution *infrastructurev1beta1.OscImageDistribution
+}
+
+// Close closes the scope of the image distribution configuration and status
+func (s *ImageDistributionScope) Close(ctx context.Context) error {
+	return s.patchHelper.Patch(ctx, s.OscImageDistribution)
+}
+
+// GetNamespace return the namespace of the image distribution
+func (s *ImageDistributionScope) GetNamespace() string {
+	return s.OscImageDistribution.Namespace
+}
+
+// GetSharedAccountIds returns the accounts the source image is shared with.
+func (s *ImageDistributionScope) GetSharedAccountIds() []string {
+	return s.OscImageDistribution.Status.SharedAccountIds
+}
+
+// SetSharedAccountIds sets the accounts the source image is shared with.
+func (s *ImageDistributionScope) SetSharedAccountIds(accountIds []string) {
+	s.OscImageDistribution.Status.SharedAccountIds = accountIds
+}
+
+// GetCopies returns the copies of the source image.
+func (s *ImageDistributionScope) GetCopies() []infrastructurev1beta1.OscImageCopy {
+	return s.OscImageDistribution.Status.Copies
+}
+
+// GetCopy returns the copy of the source image in a region/account, or nil if none exists.
+func (s *ImageDistributionScope) GetCopy(region, accountId string) *infrastructurev1beta1.OscImageCopy {
+	for i := range s.OscImageDistribution.Status.Copies {
+		c := &s.OscImageDistribution.Status.Copies[i]
+		if c.Region == region && c.AccountId == accountId {
+			return c
+		}
+	}
+	return nil
+}
+
+// AddCopy records a copy of the source image.
+func (s *ImageDistributionScope) AddCopy(c infrastructurev1beta1.OscImageCopy) {
+	s.OscImageDistribution.Status.Copies = append(s.OscImageDistribution.Status.Copies, c)
+}
+
+// RemoveCopy forgets a copy of the source image.
+func (s *ImageDistributionScope) RemoveCopy(imageId string) {
+	s.OscImageDistribution.Status.Copies = slices.DeleteFunc(s.OscImageDistribution.Status.Copies, func(c infrastructurev1beta1.OscImageCopy) bool {
+		return c.ImageId == imageId
+	})
+}
+
+// SetReady sets whether the image is available to all tenants.
+func (s *ImageDistributionScope) SetReady(ready bool) {
+	s.OscImageDistribution.Status.Ready = ready
+}
@@ -91,6 +91,11 @@ func (m *MachineScope) GetClientToken(clusterScope *ClusterScope) string {
 	return ct
 }
 
+// GetBootstrapDataKey returns the key of the bootstrap data object, when bootstrap data is delivered through OOS.
+func (m *MachineScope) GetBootstrapDataKey(clusterScope *ClusterScope) string {
+	return m.OscMachine.Namespace + "/" + m.OscMachine.Name + "-" + clusterScope.GetUID()
+}
+
 // GetNamespace return the namespace of the machine
 func (m *MachineScope) GetNamespace() string {
 	return m.OscMachine.Namespace
@@ -116,6 +121,14 @@ func (m *MachineScope) GetImage() *infrastructurev1beta1.OscImage {
 	return &m.OscMachine.Spec.Node.Image
 }
 
+// GetKubernetesVersion returns the Kubernetes version of the machine.
+func (m *MachineScope) GetKubernetesVersion() string {
+	if m.Machine.Spec.Version == nil {
+		return ""
+	}
+	return *m.Machine.Spec.Version
+}
+
 // SetImageId set ImageId
 func (m *MachineScope) SetImageId(imageId string) {
 	m.OscMachine.Spec.Node.Vm.ImageId = imageId
@@ -131,6 +144,11 @@ func (m *MachineScope) GetVmPrivateIps() []infrastructurev1beta1.OscPrivateIpEle
 	return m.GetVm().PrivateIps
 }
 
+// GetVmAddressesFromPools return the IPAM pools used to claim vm privateIps
+func (m *MachineScope) GetVmAddressesFromPools() []corev1.TypedLocalObjectReference {
+	return m.GetVm().AddressesFromPools
+}
+
 // GetVmSecurityGroups return the vm securityGroups
 func (m *MachineScope) GetVmSecurityGroups() []infrastructurev1beta1.OscSecurityGroupElement {
 	return m.GetVm().SecurityGroupNames
@@ -221,6 +239,16 @@ func (m *MachineScope) SetVmState(v infrastructurev1beta1.VmState) {
 	m.OscMachine.Status.VmState = &v
 }
 
+// GetAttachedSecurityGroups returns the securityGroups attached to the VM by CAPOSC.
+func (m *MachineScope) GetAttachedSecurityGroups() []string {
+	return m.OscMachine.Status.AttachedSecurityGroups
+}
+
+// SetAttachedSecurityGroups records the securityGroups attached to the VM by CAPOSC.
+func (m *MachineScope) SetAttachedSecurityGroups(securityGroupIds []string) {
+	m.OscMachine.Status.AttachedSecurityGroups = securityGroupIds
+}
+
 // SetReady set machine status ready
 func (m *MachineScope) SetReady() {
 	m.OscMachine.Status.Ready = true
@@ -291,15 +319,29 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 		}})
 }
 
-// GetBootstrapData return bootstrapData
-func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
+// Bootstrap data formats, as set in the format key of the bootstrap secret.
+const (
+	BootstrapFormatCloudConfig = "cloud-config"
+	BootstrapFormatIgnition    = "ignition"
+)
+
+func (m *MachineScope) getBootstrapSecret(ctx context.Context) (*corev1.Secret, error) {
 	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
-		return "", errors.New("error retrieving bootstrap data: DataSecretName is not set")
+		return nil, errors.New("error retrieving bootstrap data: DataSecretName is not set")
 	}
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: m.GetNamespace(), Name: *m.Machine.Spec.Bootstrap.DataSecretName}
 	if err := m.client.Get(ctx, key, secret); err != nil {
-		return "", fmt.Errorf("failed to retrieve bootstrap data secret: %w", err)
+		return nil, fmt.Errorf("failed to retrieve bootstrap data secret: %w", err)
+	}
+	return secret, nil
+}
+
+// GetBootstrapData return bootstrapData
+func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
+	secret, err := m.getBootstrapSecret(ctx)
+	if err != nil {
+		return "", err
 	}
 	value, ok := secret.Data["value"]
 	if !ok {
@@ -307,3 +349,28 @@ func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
 	}
 	return string(value), nil
 }
+
+// GetBootstrapFormat returns the format of the bootstrap data (cloud-config by default).
+func (m *MachineScope) GetBootstrapFormat(ctx context.Context) (string, error) {
+	secret, err := m.getBootstrapSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+	if format := string(secret.Data["format"]); format != "" {
+		return format, nil
+	}
+	return BootstrapFormatCloudConfig, nil
+}
+
+// GetSecretValue returns the value of a key of a secret, in the namespace of the machine.
+func (m *MachineScope) GetSecretValue(ctx context.Context, name, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := m.client.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: name}, secret); err != nil {
+		return "", fmt.Errorf("failed to retrieve secret %s: %w", name, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s is missing in secret %s", key, name)
+	}
+	return string(value), nil
+}
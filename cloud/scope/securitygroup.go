@@ -0,0 +1,160 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package scope
+
+import (
+	"strconv"
+	"strings"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+)
+
+// DefaultSecurityGroupRuleQuota is the default maximum number of rules in a security group.
+const DefaultSecurityGroupRuleQuota = 50
+
+const overflowSuffix = "-overflow-"
+
+// GetSecurityGroupRuleQuota returns the maximum number of rules in a security group.
+func (s *ClusterScope) GetSecurityGroupRuleQuota() int {
+	if s.GetNetwork().SecurityGroupRuleQuota > 0 {
+		return s.GetNetwork().SecurityGroupRuleQuota
+	}
+	return DefaultSecurityGroupRuleQuota
+}
+
+// compactSecurityGroupRules merges the IP ranges of each rule.
+func compactSecurityGroupRules(rules []infrastructurev1beta1.OscSecurityGroupRule) []infrastructurev1beta1.OscSecurityGroupRule {
+	var out []infrastructurev1beta1.OscSecurityGroupRule
+	for i, rule := range rules {
+		ranges := rule.GetIpRanges()
+		compacted := utils.CompactCIDRs(ranges)
+		if len(compacted) == len(ranges) {
+			if out != nil {
+				out = append(out, rule)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]infrastructurev1beta1.OscSecurityGroupRule, 0, len(rules)), rules[:i]...)
+		}
+		rule.IpRange = ""
+		rule.IpRanges = compacted
+		out = append(out, rule)
+	}
+	if out == nil {
+		return rules
+	}
+	return out
+}
+
+// countSecurityGroupRules returns the number of IaaS rules required by a list of rules, each IP range and member being a rule.
+func countSecurityGroupRules(rules []infrastructurev1beta1.OscSecurityGroupRule) int {
+	n := 0
+	for _, rule := range rules {
+		n += len(rule.GetIpRanges()) + len(rule.SecurityGroupMembers)
+	}
+	return n
+}
+
+// getOverflowSecurityGroup returns the n-th security group receiving the rules exceeding the quota of a security group.
+func (s *ClusterScope) getOverflowSecurityGroup(sg infrastructurev1beta1.OscSecurityGroup, n int) infrastructurev1beta1.OscSecurityGroup {
+	name := sg.Name
+	if name == "" {
+		roles := make([]string, 0, len(sg.Roles))
+		for _, role := range sg.Roles {
+			roles = append(roles, string(role))
+		}
+		name = s.GetName() + "-" + strings.Join(roles, "-")
+	}
+	return infrastructurev1beta1.OscSecurityGroup{
+		Name:          name + overflowSuffix + strconv.Itoa(n),
+		Description:   sg.Description + " (overflow " + strconv.Itoa(n) + ")",
+		Roles:         sg.Roles,
+		Authoritative: sg.Authoritative,
+		Audit:         sg.Audit,
+	}
+}
+
+// isOverflowSecurityGroupOf returns true if sg is an overflow security group of the security group named name.
+func isOverflowSecurityGroupOf(sg infrastructurev1beta1.OscSecurityGroup, name string) bool {
+	suffix, found := strings.CutPrefix(sg.Name, name+overflowSuffix)
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// IsOverflowSecurityGroup returns true if sg receives the rules exceeding the quota of another security group.
+// Overflow security groups follow their base security group in GetSecurityGroups.
+func (s *ClusterScope) IsOverflowSecurityGroup(sg infrastructurev1beta1.OscSecurityGroup) bool {
+	idx := strings.LastIndex(sg.Name, overflowSuffix)
+	if idx < 0 {
+		return false
+	}
+	_, err := strconv.Atoi(sg.Name[idx+len(overflowSuffix):])
+	return err == nil
+}
+
+// splitSecurityGroup spreads the rules of a security group over additional security groups when the rule quota would be exceeded.
+// Overflow security groups that are no longer required are kept, without any rule, as they are attached to existing VMs.
+func (s *ClusterScope) splitSecurityGroup(sg infrastructurev1beta1.OscSecurityGroup, quota int) []infrastructurev1beta1.OscSecurityGroup {
+	groups := [][]infrastructurev1beta1.OscSecurityGroupRule{sg.SecurityGroupRules}
+	if countSecurityGroupRules(sg.SecurityGroupRules) > quota {
+		groups = [][]infrastructurev1beta1.OscSecurityGroupRule{nil}
+		free := quota
+		for _, rule := range sg.SecurityGroupRules {
+			ranges, members := rule.GetIpRanges(), rule.SecurityGroupMembers
+			for len(ranges)+len(members) > 0 {
+				if free == 0 {
+					groups = append(groups, nil)
+					free = quota
+				}
+				part := rule
+				part.IpRange = ""
+				nr := min(free, len(ranges))
+				part.IpRanges, ranges = ranges[:nr:nr], ranges[nr:]
+				free -= nr
+				nm := min(free, len(members))
+				part.SecurityGroupMembers, members = members[:nm:nm], members[nm:]
+				free -= nm
+				groups[len(groups)-1] = append(groups[len(groups)-1], part)
+			}
+		}
+	}
+	base := sg
+	base.SecurityGroupRules = groups[0]
+	sgs := []infrastructurev1beta1.OscSecurityGroup{base}
+	tracked := s.GetResources().SecurityGroup
+	for n := 1; ; n++ {
+		if n >= len(groups) && len(tracked) == 0 {
+			break
+		}
+		overflow := s.getOverflowSecurityGroup(sg, n)
+		if n < len(groups) {
+			overflow.SecurityGroupRules = groups[n]
+		} else if _, found := tracked[s.GetSecurityGroupName(overflow)]; !found {
+			break
+		}
+		sgs = append(sgs, overflow)
+	}
+	return sgs
+}
+
+// compactSecurityGroups compacts the rules of security groups, and splits the ones exceeding the rule quota.
+func (s *ClusterScope) compactSecurityGroups(sgs []infrastructurev1beta1.OscSecurityGroup) []infrastructurev1beta1.OscSecurityGroup {
+	if s.GetNetwork().UseExisting.SecurityGroups {
+		return sgs
+	}
+	quota := s.GetSecurityGroupRuleQuota()
+	out := make([]infrastructurev1beta1.OscSecurityGroup, 0, len(sgs))
+	for _, sg := range sgs {
+		sg.SecurityGroupRules = compactSecurityGroupRules(sg.SecurityGroupRules)
+		out = append(out, s.splitSecurityGroup(sg, quota)...)
+	}
+	return out
+}
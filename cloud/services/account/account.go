@@ -0,0 +1,33 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_account/account_mock.go -package mock_account -source ./account.go
+type OscAccountInterface interface {
+	GetAccountId(ctx context.Context) (string, error)
+}
+
+// GetAccountId returns the ID of the account of the tenant.
+func (s *Service) GetAccountId(ctx context.Context) (string, error) {
+	readAccountsRequest := osc.ReadAccountsRequest{}
+	readAccountsResponse, httpRes, err := s.tenant.Client().AccountApi.ReadAccounts(s.tenant.ContextWithAuth(ctx)).ReadAccountsRequest(readAccountsRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadAccounts", readAccountsRequest, httpRes, err)
+	if err != nil {
+		return "", err
+	}
+	if len(readAccountsResponse.GetAccounts()) == 0 {
+		return "", errors.New("no account found")
+	}
+	return readAccountsResponse.GetAccounts()[0].GetAccountId(), nil
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./account.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_account/account_mock.go -package mock_account -source ./account.go
+//
+
+// Package mock_account is a generated GoMock package.
+package mock_account
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscAccountInterface is a mock of OscAccountInterface interface.
+type MockOscAccountInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscAccountInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscAccountInterfaceMockRecorder is the mock recorder for MockOscAccountInterface.
+type MockOscAccountInterfaceMockRecorder struct {
+	mock *MockOscAccountInterface
+}
+
+// NewMockOscAccountInterface creates a new mock instance.
+func NewMockOscAccountInterface(ctrl *gomock.Controller) *MockOscAccountInterface {
+	mock := &MockOscAccountInterface{ctrl: ctrl}
+	mock.recorder = &MockOscAccountInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscAccountInterface) EXPECT() *MockOscAccountInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAccountId mocks base method.
+func (m *MockOscAccountInterface) GetAccountId(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountId", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountId indicates an expected call of GetAccountId.
+func (mr *MockOscAccountInterfaceMockRecorder) GetAccountId(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountId", reflect.TypeOf((*MockOscAccountInterface)(nil).GetAccountId), ctx)
+}
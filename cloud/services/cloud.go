@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/account"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/loadbalancer"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/net"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/security"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/storage"
 	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
 )
@@ -24,6 +26,11 @@ type Servicer interface {
 	NetPeering(t tenant.Tenant) net.OscNetPeeringInterface
 	NetAccessPoint(t tenant.Tenant) net.OscNetAccessPointInterface
 	Subnet(t tenant.Tenant) net.OscSubnetInterface
+	Nic(t tenant.Tenant) net.OscNicInterface
+	VirtualGateway(t tenant.Tenant) net.OscVirtualGatewayInterface
+	Vpn(t tenant.Tenant) net.OscVpnInterface
+	DirectLink(t tenant.Tenant) net.OscDirectLinkInterface
+	DhcpOptions(t tenant.Tenant) net.OscDhcpOptionsInterface
 	SecurityGroup(t tenant.Tenant) security.OscSecurityGroupInterface
 
 	InternetService(t tenant.Tenant) net.OscInternetServiceInterface
@@ -36,6 +43,10 @@ type Servicer interface {
 	Image(t tenant.Tenant) compute.OscImageInterface
 
 	Tag(t tenant.Tenant) tag.OscTagInterface
+
+	ObjectStorage(t tenant.Tenant, endpoint string) storage.OscObjectStorageInterface
+
+	Account(t tenant.Tenant) account.OscAccountInterface
 }
 
 type Services struct {
@@ -78,6 +89,31 @@ func (s *Services) Subnet(t tenant.Tenant) net.OscSubnetInterface {
 	return net.NewService(t)
 }
 
+// Nic returns the Nic interface
+func (s *Services) Nic(t tenant.Tenant) net.OscNicInterface {
+	return net.NewService(t)
+}
+
+// VirtualGateway returns the VirtualGateway interface
+func (s *Services) VirtualGateway(t tenant.Tenant) net.OscVirtualGatewayInterface {
+	return net.NewService(t)
+}
+
+// Vpn returns the Vpn interface
+func (s *Services) Vpn(t tenant.Tenant) net.OscVpnInterface {
+	return net.NewService(t)
+}
+
+// DirectLink returns the DirectLink interface
+func (s *Services) DirectLink(t tenant.Tenant) net.OscDirectLinkInterface {
+	return net.NewService(t)
+}
+
+// DhcpOptions returns the DhcpOptions interface
+func (s *Services) DhcpOptions(t tenant.Tenant) net.OscDhcpOptionsInterface {
+	return net.NewService(t)
+}
+
 // getInternetServiceSvc returns internetServiceSvc
 func (s *Services) InternetService(t tenant.Tenant) net.OscInternetServiceInterface {
 	return net.NewService(t)
@@ -123,4 +159,14 @@ func (s *Services) Tag(t tenant.Tenant) tag.OscTagInterface {
 	return tag.NewService(t)
 }
 
+// ObjectStorage returns the OOS service
+func (s *Services) ObjectStorage(t tenant.Tenant, endpoint string) storage.OscObjectStorageInterface {
+	return storage.NewService(t, endpoint)
+}
+
+// Account returns the Account service
+func (s *Services) Account(t tenant.Tenant) account.OscAccountInterface {
+	return account.NewService(t)
+}
+
 var _ Servicer = (*Services)(nil)
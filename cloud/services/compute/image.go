@@ -7,15 +7,28 @@ package compute
 
 import (
 	"context"
+	"errors"
 
 	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
 	osc "github.com/outscale/osc-sdk-go/v2"
 )
 
+// TagKeyImageBuild tags the images built by an OscImageBuild, with the UID of the OscImageBuild.
+const TagKeyImageBuild = "OscK8sImageBuild"
+
+// TagKeyImageDistribution tags the image copies made by an OscImageDistribution, with the UID of the OscImageDistribution.
+const TagKeyImageDistribution = "OscK8sImageDistribution"
+
 //go:generate ../../../bin/mockgen -destination mock_compute/image_mock.go -package mock_compute -source ./image.go
 type OscImageInterface interface {
 	GetImage(ctx context.Context, imageId string) (*osc.Image, error)
 	GetImageByName(ctx context.Context, imageName, accountId string) (*osc.Image, error)
+	ListImagesByName(ctx context.Context, imageName string, accountIds []string) ([]osc.Image, error)
+	CreateImageFromVm(ctx context.Context, vmId, imageName, description string, noReboot bool) (*osc.Image, error)
+	AddImageTags(ctx context.Context, imageId string, tags map[string]string) error
+	CopyImage(ctx context.Context, sourceImageId, sourceRegion, imageName, description string) (*osc.Image, error)
+	UpdateLaunchPermissions(ctx context.Context, imageId string, additions, removals []string) error
+	DeleteImage(ctx context.Context, imageId string) error
 }
 
 // GetImage retrieve image from imageId
@@ -60,3 +73,100 @@ func (s *Service) GetImageByName(ctx context.Context, imageName, accountId strin
 
 	return &image, nil
 }
+
+// ListImagesByName lists the images by name, including * wildcards, owned by one of accountIds if set.
+func (s *Service) ListImagesByName(ctx context.Context, imageName string, accountIds []string) ([]osc.Image, error) {
+	readImageRequest := osc.ReadImagesRequest{
+		Filters: &osc.FiltersImage{
+			ImageNames: &[]string{imageName},
+		},
+	}
+	if len(accountIds) > 0 {
+		readImageRequest.Filters.AccountIds = &accountIds
+	}
+
+	readImagesResponse, httpRes, err := s.tenant.Client().ImageApi.ReadImages(s.tenant.ContextWithAuth(ctx)).ReadImagesRequest(readImageRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadImages", readImageRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return readImagesResponse.GetImages(), nil
+}
+
+// CreateImageFromVm creates an image from a VM. The VM is rebooted during the snapshot unless noReboot is set.
+func (s *Service) CreateImageFromVm(ctx context.Context, vmId, imageName, description string, noReboot bool) (*osc.Image, error) {
+	createImageRequest := osc.CreateImageRequest{
+		VmId:      &vmId,
+		ImageName: &imageName,
+		NoReboot:  &noReboot,
+	}
+	if description != "" {
+		createImageRequest.Description = &description
+	}
+
+	createImageResponse, httpRes, err := s.tenant.Client().ImageApi.CreateImage(s.tenant.ContextWithAuth(ctx)).CreateImageRequest(createImageRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateImage", createImageRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	image, ok := createImageResponse.GetImageOk()
+	if !ok {
+		return nil, errors.New("cannot create image")
+	}
+	return image, nil
+}
+
+// AddImageTags adds tags to an image.
+func (s *Service) AddImageTags(ctx context.Context, imageId string, tags map[string]string) error {
+	return s.addTags(ctx, imageId, tags)
+}
+
+// CopyImage copies an image from another region into the region of the tenant.
+// The source image must be owned by, or shared with, the account of the tenant.
+func (s *Service) CopyImage(ctx context.Context, sourceImageId, sourceRegion, imageName, description string) (*osc.Image, error) {
+	createImageRequest := osc.CreateImageRequest{
+		SourceImageId:    &sourceImageId,
+		SourceRegionName: &sourceRegion,
+		ImageName:        &imageName,
+	}
+	if description != "" {
+		createImageRequest.Description = &description
+	}
+
+	createImageResponse, httpRes, err := s.tenant.Client().ImageApi.CreateImage(s.tenant.ContextWithAuth(ctx)).CreateImageRequest(createImageRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateImage", createImageRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	image, ok := createImageResponse.GetImageOk()
+	if !ok {
+		return nil, errors.New("cannot copy image")
+	}
+	return image, nil
+}
+
+// UpdateLaunchPermissions grants and revokes the permission to launch VMs from an image.
+func (s *Service) UpdateLaunchPermissions(ctx context.Context, imageId string, additions, removals []string) error {
+	permissions := osc.PermissionsOnResourceCreation{}
+	if len(additions) > 0 {
+		permissions.Additions = &osc.PermissionsOnResource{AccountIds: &additions}
+	}
+	if len(removals) > 0 {
+		permissions.Removals = &osc.PermissionsOnResource{AccountIds: &removals}
+	}
+	updateImageRequest := osc.UpdateImageRequest{
+		ImageId:             imageId,
+		PermissionsToLaunch: &permissions,
+	}
+
+	_, httpRes, err := s.tenant.Client().ImageApi.UpdateImage(s.tenant.ContextWithAuth(ctx)).UpdateImageRequest(updateImageRequest).Execute()
+	return utils.LogAndExtractError(ctx, "UpdateImage", updateImageRequest, httpRes, err)
+}
+
+// DeleteImage deletes an image.
+func (s *Service) DeleteImage(ctx context.Context, imageId string) error {
+	deleteImageRequest := osc.DeleteImageRequest{ImageId: imageId}
+
+	_, httpRes, err := s.tenant.Client().ImageApi.DeleteImage(s.tenant.ContextWithAuth(ctx)).DeleteImageRequest(deleteImageRequest).Execute()
+	return utils.LogAndExtractError(ctx, "DeleteImage", deleteImageRequest, httpRes, err)
+}
@@ -41,6 +41,64 @@ func (m *MockOscImageInterface) EXPECT() *MockOscImageInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddImageTags mocks base method.
+func (m *MockOscImageInterface) AddImageTags(ctx context.Context, imageId string, tags map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddImageTags", ctx, imageId, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddImageTags indicates an expected call of AddImageTags.
+func (mr *MockOscImageInterfaceMockRecorder) AddImageTags(ctx, imageId, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImageTags", reflect.TypeOf((*MockOscImageInterface)(nil).AddImageTags), ctx, imageId, tags)
+}
+
+// CopyImage mocks base method.
+func (m *MockOscImageInterface) CopyImage(ctx context.Context, sourceImageId, sourceRegion, imageName, description string) (*osc.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyImage", ctx, sourceImageId, sourceRegion, imageName, description)
+	ret0, _ := ret[0].(*osc.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyImage indicates an expected call of CopyImage.
+func (mr *MockOscImageInterfaceMockRecorder) CopyImage(ctx, sourceImageId, sourceRegion, imageName, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyImage", reflect.TypeOf((*MockOscImageInterface)(nil).CopyImage), ctx, sourceImageId, sourceRegion, imageName, description)
+}
+
+// CreateImageFromVm mocks base method.
+func (m *MockOscImageInterface) CreateImageFromVm(ctx context.Context, vmId, imageName, description string, noReboot bool) (*osc.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImageFromVm", ctx, vmId, imageName, description, noReboot)
+	ret0, _ := ret[0].(*osc.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImageFromVm indicates an expected call of CreateImageFromVm.
+func (mr *MockOscImageInterfaceMockRecorder) CreateImageFromVm(ctx, vmId, imageName, description, noReboot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImageFromVm", reflect.TypeOf((*MockOscImageInterface)(nil).CreateImageFromVm), ctx, vmId, imageName, description, noReboot)
+}
+
+// DeleteImage mocks base method.
+func (m *MockOscImageInterface) DeleteImage(ctx context.Context, imageId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, imageId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockOscImageInterfaceMockRecorder) DeleteImage(ctx, imageId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockOscImageInterface)(nil).DeleteImage), ctx, imageId)
+}
+
 // GetImage mocks base method.
 func (m *MockOscImageInterface) GetImage(ctx context.Context, imageId string) (*osc.Image, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +128,32 @@ func (mr *MockOscImageInterfaceMockRecorder) GetImageByName(ctx, imageName, acco
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageByName", reflect.TypeOf((*MockOscImageInterface)(nil).GetImageByName), ctx, imageName, accountId)
 }
+
+// ListImagesByName mocks base method.
+func (m *MockOscImageInterface) ListImagesByName(ctx context.Context, imageName string, accountIds []string) ([]osc.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesByName", ctx, imageName, accountIds)
+	ret0, _ := ret[0].([]osc.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesByName indicates an expected call of ListImagesByName.
+func (mr *MockOscImageInterfaceMockRecorder) ListImagesByName(ctx, imageName, accountIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesByName", reflect.TypeOf((*MockOscImageInterface)(nil).ListImagesByName), ctx, imageName, accountIds)
+}
+
+// UpdateLaunchPermissions mocks base method.
+func (m *MockOscImageInterface) UpdateLaunchPermissions(ctx context.Context, imageId string, additions, removals []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLaunchPermissions", ctx, imageId, additions, removals)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLaunchPermissions indicates an expected call of UpdateLaunchPermissions.
+func (mr *MockOscImageInterfaceMockRecorder) UpdateLaunchPermissions(ctx, imageId, additions, removals any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLaunchPermissions", reflect.TypeOf((*MockOscImageInterface)(nil).UpdateLaunchPermissions), ctx, imageId, additions, removals)
+}
@@ -14,7 +14,6 @@ import (
 	reflect "reflect"
 
 	v1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
-	scope "github.com/outscale/cluster-api-provider-outscale/cloud/scope"
 	osc "github.com/outscale/osc-sdk-go/v2"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -57,19 +56,33 @@ func (mr *MockOscVmInterfaceMockRecorder) AddCCMTags(ctx, clusterName, hostname,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCCMTags", reflect.TypeOf((*MockOscVmInterface)(nil).AddCCMTags), ctx, clusterName, hostname, vmId)
 }
 
+// AddTags mocks base method.
+func (m *MockOscVmInterface) AddTags(ctx context.Context, vmId string, tags map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, vmId, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockOscVmInterfaceMockRecorder) AddTags(ctx, vmId, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockOscVmInterface)(nil).AddTags), ctx, vmId, tags)
+}
+
 // CreateVm mocks base method.
-func (m *MockOscVmInterface) CreateVm(ctx context.Context, machineScope *scope.MachineScope, spec *v1beta1.OscVm, imageId, subnetId string, securityGroupIds, privateIps []string, vmName, vmClientToken string, tags map[string]string, volumes []v1beta1.OscVolume) (*osc.Vm, error) {
+func (m *MockOscVmInterface) CreateVm(ctx context.Context, userData string, spec *v1beta1.OscVm, imageId, subnetId string, securityGroupIds, privateIps []string, nicId, vmName, vmClientToken string, tags map[string]string, volumes []v1beta1.OscVolume) (*osc.Vm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVm", ctx, machineScope, spec, imageId, subnetId, securityGroupIds, privateIps, vmName, vmClientToken, tags, volumes)
+	ret := m.ctrl.Call(m, "CreateVm", ctx, userData, spec, imageId, subnetId, securityGroupIds, privateIps, nicId, vmName, vmClientToken, tags, volumes)
 	ret0, _ := ret[0].(*osc.Vm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateVm indicates an expected call of CreateVm.
-func (mr *MockOscVmInterfaceMockRecorder) CreateVm(ctx, machineScope, spec, imageId, subnetId, securityGroupIds, privateIps, vmName, vmClientToken, tags, volumes any) *gomock.Call {
+func (mr *MockOscVmInterfaceMockRecorder) CreateVm(ctx, userData, spec, imageId, subnetId, securityGroupIds, privateIps, nicId, vmName, vmClientToken, tags, volumes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVm", reflect.TypeOf((*MockOscVmInterface)(nil).CreateVm), ctx, machineScope, spec, imageId, subnetId, securityGroupIds, privateIps, vmName, vmClientToken, tags, volumes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVm", reflect.TypeOf((*MockOscVmInterface)(nil).CreateVm), ctx, userData, spec, imageId, subnetId, securityGroupIds, privateIps, nicId, vmName, vmClientToken, tags, volumes)
 }
 
 // CreateVmBastion mocks base method.
@@ -87,6 +100,21 @@ func (mr *MockOscVmInterfaceMockRecorder) CreateVmBastion(ctx, spec, subnetId, s
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVmBastion", reflect.TypeOf((*MockOscVmInterface)(nil).CreateVmBastion), ctx, spec, subnetId, securityGroupIds, privateIps, vmName, vmClientToken, imageId, tags)
 }
 
+// CreateVmNat mocks base method.
+func (m *MockOscVmInterface) CreateVmNat(ctx context.Context, spec *v1beta1.OscNatInstance, subnetId string, securityGroupIds []string, vmName, vmClientToken, imageId, ipRange string, tags map[string]string) (*osc.Vm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVmNat", ctx, spec, subnetId, securityGroupIds, vmName, vmClientToken, imageId, ipRange, tags)
+	ret0, _ := ret[0].(*osc.Vm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVmNat indicates an expected call of CreateVmNat.
+func (mr *MockOscVmInterfaceMockRecorder) CreateVmNat(ctx, spec, subnetId, securityGroupIds, vmName, vmClientToken, imageId, ipRange, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVmNat", reflect.TypeOf((*MockOscVmInterface)(nil).CreateVmNat), ctx, spec, subnetId, securityGroupIds, vmName, vmClientToken, imageId, ipRange, tags)
+}
+
 // DeleteVm mocks base method.
 func (m *MockOscVmInterface) DeleteVm(ctx context.Context, vmId string) error {
 	m.ctrl.T.Helper()
@@ -130,3 +158,61 @@ func (mr *MockOscVmInterfaceMockRecorder) GetVmFromClientToken(ctx, clientToken
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVmFromClientToken", reflect.TypeOf((*MockOscVmInterface)(nil).GetVmFromClientToken), ctx, clientToken)
 }
+
+// ListVmsFromSecurityGroup mocks base method.
+func (m *MockOscVmInterface) ListVmsFromSecurityGroup(ctx context.Context, securityGroupId string) ([]osc.Vm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVmsFromSecurityGroup", ctx, securityGroupId)
+	ret0, _ := ret[0].([]osc.Vm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVmsFromSecurityGroup indicates an expected call of ListVmsFromSecurityGroup.
+func (mr *MockOscVmInterfaceMockRecorder) ListVmsFromSecurityGroup(ctx, securityGroupId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVmsFromSecurityGroup", reflect.TypeOf((*MockOscVmInterface)(nil).ListVmsFromSecurityGroup), ctx, securityGroupId)
+}
+
+// ListVmsFromSubnet mocks base method.
+func (m *MockOscVmInterface) ListVmsFromSubnet(ctx context.Context, subnetId string) ([]osc.Vm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVmsFromSubnet", ctx, subnetId)
+	ret0, _ := ret[0].([]osc.Vm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVmsFromSubnet indicates an expected call of ListVmsFromSubnet.
+func (mr *MockOscVmInterfaceMockRecorder) ListVmsFromSubnet(ctx, subnetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVmsFromSubnet", reflect.TypeOf((*MockOscVmInterface)(nil).ListVmsFromSubnet), ctx, subnetId)
+}
+
+// UpdateVmSecurityGroups mocks base method.
+func (m *MockOscVmInterface) UpdateVmSecurityGroups(ctx context.Context, vmId string, securityGroupIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVmSecurityGroups", ctx, vmId, securityGroupIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVmSecurityGroups indicates an expected call of UpdateVmSecurityGroups.
+func (mr *MockOscVmInterfaceMockRecorder) UpdateVmSecurityGroups(ctx, vmId, securityGroupIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVmSecurityGroups", reflect.TypeOf((*MockOscVmInterface)(nil).UpdateVmSecurityGroups), ctx, vmId, securityGroupIds)
+}
+
+// UpdateVmSourceDestCheck mocks base method.
+func (m *MockOscVmInterface) UpdateVmSourceDestCheck(ctx context.Context, vmId string, checked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVmSourceDestCheck", ctx, vmId, checked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVmSourceDestCheck indicates an expected call of UpdateVmSourceDestCheck.
+func (mr *MockOscVmInterfaceMockRecorder) UpdateVmSourceDestCheck(ctx, vmId, checked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVmSourceDestCheck", reflect.TypeOf((*MockOscVmInterface)(nil).UpdateVmSourceDestCheck), ctx, vmId, checked)
+}
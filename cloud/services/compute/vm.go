@@ -10,17 +10,23 @@ import (
 	b64 "encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
-	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
 	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
 	osc "github.com/outscale/osc-sdk-go/v2"
 	"k8s.io/utils/ptr"
 )
 
+// MaxUserDataSize is the maximum size of the base64 encoded user data of a VM.
+const MaxUserDataSize = 500 * 1024
+
+// ErrUserDataTooLarge is returned when the user data of a VM exceeds MaxUserDataSize.
+var ErrUserDataTooLarge = errors.New("user data is too large")
+
 const (
 	AutoAttachExternapIPTag = "osc.fcu.eip.auto-attach"
 
@@ -30,28 +36,39 @@ const (
 
 //go:generate ../../../bin/mockgen -destination mock_compute/vm_mock.go -package mock_compute -source ./vm.go
 type OscVmInterface interface {
-	CreateVm(ctx context.Context, machineScope *scope.MachineScope, spec *infrastructurev1beta1.OscVm, imageId, subnetId string, securityGroupIds []string, privateIps []string, vmName, vmClientToken string, tags map[string]string, volumes []infrastructurev1beta1.OscVolume) (*osc.Vm, error)
+	CreateVm(ctx context.Context, userData string, spec *infrastructurev1beta1.OscVm, imageId, subnetId string, securityGroupIds []string, privateIps []string, nicId, vmName, vmClientToken string, tags map[string]string, volumes []infrastructurev1beta1.OscVolume) (*osc.Vm, error)
 	CreateVmBastion(ctx context.Context, spec *infrastructurev1beta1.OscBastion, subnetId string, securityGroupIds []string, privateIps []string, vmName, vmClientToken, imageId string, tags map[string]string) (*osc.Vm, error)
+	CreateVmNat(ctx context.Context, spec *infrastructurev1beta1.OscNatInstance, subnetId string, securityGroupIds []string, vmName, vmClientToken, imageId, ipRange string, tags map[string]string) (*osc.Vm, error)
 	DeleteVm(ctx context.Context, vmId string) error
+	UpdateVmSecurityGroups(ctx context.Context, vmId string, securityGroupIds []string) error
+	UpdateVmSourceDestCheck(ctx context.Context, vmId string, checked bool) error
 	GetVm(ctx context.Context, vmId string) (*osc.Vm, error)
 	GetVmFromClientToken(ctx context.Context, clientToken string) (*osc.Vm, error)
+	ListVmsFromSubnet(ctx context.Context, subnetId string) ([]osc.Vm, error)
+	ListVmsFromSecurityGroup(ctx context.Context, securityGroupId string) ([]osc.Vm, error)
 	AddCCMTags(ctx context.Context, clusterName string, hostname string, vmId string) error
+	AddTags(ctx context.Context, vmId string, tags map[string]string) error
+}
+
+// ValidateUserDataSize checks that the user data of a VM, with its Outscale section, does not exceed MaxUserDataSize.
+func ValidateUserDataSize(userData string, tags map[string]string) error {
+	size := b64.StdEncoding.EncodedLen(len(utils.ConvertsTagsToUserDataOutscaleSection(tags)) + len(userData))
+	if size > MaxUserDataSize {
+		return fmt.Errorf("%w: %d bytes encoded, the limit is %d", ErrUserDataTooLarge, size, MaxUserDataSize)
+	}
+	return nil
 }
 
 // CreateVm creates a VM.
 func (s *Service) CreateVm(ctx context.Context,
-	machineScope *scope.MachineScope, spec *infrastructurev1beta1.OscVm, imageId, subnetId string, securityGroupIds []string, privateIps []string, vmName, vmClientToken string, tags map[string]string,
+	userData string, spec *infrastructurev1beta1.OscVm, imageId, subnetId string, securityGroupIds []string, privateIps []string, nicId, vmName, vmClientToken string, tags map[string]string,
 	volumes []infrastructurev1beta1.OscVolume) (*osc.Vm, error) {
 	keypairName := spec.KeypairName
 	vmType := spec.VmType
 	rootDiskIops := spec.RootDisk.RootDiskIops
 	rootDiskSize := spec.RootDisk.RootDiskSize
 	rootDiskType := spec.RootDisk.RootDiskType
-	bootstrapData, err := machineScope.GetBootstrapData(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode bootstrap data: %w", err)
-	}
-	mergedUserData := utils.ConvertsTagsToUserDataOutscaleSection(tags) + bootstrapData
+	mergedUserData := utils.ConvertsTagsToUserDataOutscaleSection(tags) + userData
 	mergedUserDataEnc := b64.StdEncoding.EncodeToString([]byte(mergedUserData))
 	rootDisk := osc.BlockDeviceMappingVmCreation{
 		Bsu: &osc.BsuToCreate{
@@ -89,15 +106,24 @@ func (s *Service) CreateVm(ctx context.Context,
 		ImageId:             imageId,
 		KeypairName:         &keypairName,
 		VmType:              &vmType,
-		SubnetId:            &subnetId,
-		SecurityGroupIds:    &securityGroupIds,
 		UserData:            &mergedUserDataEnc,
 		BlockDeviceMappings: &volMappings,
 		ClientToken:         &vmClientToken,
 	}
 
-	if len(privateIps) > 0 {
-		vmOpt.SetPrivateIps(privateIps)
+	// When a pre-created NIC is used, subnet, security groups and private IPs are those of the NIC.
+	switch {
+	case nicId != "":
+		vmOpt.SetNics([]osc.NicForVmCreation{{
+			NicId:        &nicId,
+			DeviceNumber: ptr.To[int32](0),
+		}})
+	default:
+		vmOpt.SetSubnetId(subnetId)
+		vmOpt.SetSecurityGroupIds(securityGroupIds)
+		if len(privateIps) > 0 {
+			vmOpt.SetPrivateIps(privateIps)
+		}
 	}
 
 	vmResponse, httpRes, err := s.tenant.Client().VmApi.CreateVms(s.tenant.ContextWithAuth(ctx)).CreateVmsRequest(vmOpt).Execute()
@@ -200,6 +226,85 @@ func (s *Service) CreateVmBastion(ctx context.Context, spec *infrastructurev1bet
 	}
 }
 
+// natInstanceUserData configures a VM to forward and masquerade the traffic of the net.
+// Forwarding is persisted and masquerading is installed as a boot service, as userdata only runs on the first boot.
+const natInstanceUserData = `#!/bin/sh
+echo net.ipv4.ip_forward=1 > /etc/sysctl.d/99-nat-instance.conf
+sysctl -w net.ipv4.ip_forward=1
+cat > /etc/systemd/system/nat-instance.service <<EOF
+[Unit]
+Description=Masquerade the traffic of the net
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh -c 'iptables -t nat -C POSTROUTING -s %[1]s ! -d %[1]s -j MASQUERADE || iptables -t nat -A POSTROUTING -s %[1]s ! -d %[1]s -j MASQUERADE'
+
+[Install]
+WantedBy=multi-user.target
+EOF
+systemctl daemon-reload
+systemctl enable --now nat-instance.service
+`
+
+// CreateVmNat creates a NAT instance vm
+func (s *Service) CreateVmNat(ctx context.Context, spec *infrastructurev1beta1.OscNatInstance, subnetId string, securityGroupIds []string, vmName, vmClientToken, imageId, ipRange string, tags map[string]string) (*osc.Vm, error) {
+	keypairName := spec.KeypairName
+	vmType := spec.VmType
+
+	userData := utils.ConvertsTagsToUserDataOutscaleSection(tags) + fmt.Sprintf(natInstanceUserData, ipRange)
+	userDataEnc := b64.StdEncoding.EncodeToString([]byte(userData))
+	vmOpt := osc.CreateVmsRequest{
+		ImageId:          imageId,
+		VmType:           &vmType,
+		SubnetId:         &subnetId,
+		SecurityGroupIds: &securityGroupIds,
+		UserData:         &userDataEnc,
+		ClientToken:      &vmClientToken,
+	}
+	if keypairName != "" {
+		vmOpt.KeypairName = &keypairName
+	}
+
+	vmResponse, httpRes, err := s.tenant.Client().VmApi.CreateVms(s.tenant.ContextWithAuth(ctx)).CreateVmsRequest(vmOpt).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateVms", vmOpt, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	vms, ok := vmResponse.GetVmsOk()
+	if !ok || len(*vms) == 0 {
+		return nil, errors.New("cannot get vm")
+	}
+	vm := (*vms)[0]
+	resourceIds := []string{vm.GetVmId()}
+	vmTagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.NameKey,
+			Value: vmName,
+		}},
+	}
+	err = tag.AddTag(ctx, vmTagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &vm, nil
+}
+
+// UpdateVmSourceDestCheck enables or disables the source/destination check of a vm
+func (s *Service) UpdateVmSourceDestCheck(ctx context.Context, vmId string, checked bool) error {
+	updateVmRequest := osc.UpdateVmRequest{
+		VmId:                vmId,
+		IsSourceDestChecked: &checked,
+	}
+
+	_, httpRes, err := s.tenant.Client().VmApi.UpdateVm(s.tenant.ContextWithAuth(ctx)).UpdateVmRequest(updateVmRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateVm", updateVmRequest, httpRes, err)
+	return err
+}
+
 // DeleteVm delete machine vm
 func (s *Service) DeleteVm(ctx context.Context, vmId string) error {
 	deleteVmsRequest := osc.DeleteVmsRequest{VmIds: []string{vmId}}
@@ -209,6 +314,18 @@ func (s *Service) DeleteVm(ctx context.Context, vmId string) error {
 	return err
 }
 
+// UpdateVmSecurityGroups replaces the security groups of a vm
+func (s *Service) UpdateVmSecurityGroups(ctx context.Context, vmId string, securityGroupIds []string) error {
+	updateVmRequest := osc.UpdateVmRequest{
+		VmId:             vmId,
+		SecurityGroupIds: &securityGroupIds,
+	}
+
+	_, httpRes, err := s.tenant.Client().VmApi.UpdateVm(s.tenant.ContextWithAuth(ctx)).UpdateVmRequest(updateVmRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateVm", updateVmRequest, httpRes, err)
+	return err
+}
+
 // GetVm retrieve vm from vmId
 func (s *Service) GetVm(ctx context.Context, vmId string) (*osc.Vm, error) {
 	readVmsRequest := osc.ReadVmsRequest{
@@ -235,6 +352,40 @@ func (s *Service) GetVm(ctx context.Context, vmId string) (*osc.Vm, error) {
 	}
 }
 
+// ListVmsFromSubnet lists the non terminated vms of a subnet
+func (s *Service) ListVmsFromSubnet(ctx context.Context, subnetId string) ([]osc.Vm, error) {
+	readVmsRequest := osc.ReadVmsRequest{
+		Filters: &osc.FiltersVm{
+			SubnetIds:    &[]string{subnetId},
+			VmStateNames: &[]string{"pending", "running", "stopping", "stopped", "shutting-down"},
+		},
+	}
+
+	readVmsResponse, httpRes, err := s.tenant.Client().VmApi.ReadVms(s.tenant.ContextWithAuth(ctx)).ReadVmsRequest(readVmsRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadVmsRequest", readVmsRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return readVmsResponse.GetVms(), nil
+}
+
+// ListVmsFromSecurityGroup lists the non terminated vms having a securityGroup
+func (s *Service) ListVmsFromSecurityGroup(ctx context.Context, securityGroupId string) ([]osc.Vm, error) {
+	readVmsRequest := osc.ReadVmsRequest{
+		Filters: &osc.FiltersVm{
+			VmSecurityGroupIds: &[]string{securityGroupId},
+			VmStateNames:       &[]string{"pending", "running", "stopping", "stopped", "shutting-down"},
+		},
+	}
+
+	readVmsResponse, httpRes, err := s.tenant.Client().VmApi.ReadVms(s.tenant.ContextWithAuth(ctx)).ReadVmsRequest(readVmsRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadVmsRequest", readVmsRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return readVmsResponse.GetVms(), nil
+}
+
 // GetVmFromClientToken retrieve vm from vmId
 func (s *Service) GetVmFromClientToken(ctx context.Context, clientToken string) (*osc.Vm, error) {
 	readVmsRequest := osc.ReadVmsRequest{
@@ -288,3 +439,22 @@ func (s *Service) AddCCMTags(ctx context.Context, clusterName string, hostname s
 	}
 	return tag.AddTag(ctx, nodeTagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
 }
+
+// AddTags adds tags to a VM.
+func (s *Service) AddTags(ctx context.Context, vmId string, tags map[string]string) error {
+	return s.addTags(ctx, vmId, tags)
+}
+
+// addTags adds tags to a resource.
+func (s *Service) addTags(ctx context.Context, resourceId string, tags map[string]string) error {
+	resourceIds := []string{resourceId}
+	resourceTags := make([]osc.ResourceTag, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		resourceTags = append(resourceTags, osc.ResourceTag{Key: key, Value: tags[key]})
+	}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags:        resourceTags,
+	}
+	return tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+}
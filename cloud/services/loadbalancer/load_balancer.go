@@ -21,6 +21,7 @@ import (
 type OscLoadBalancerInterface interface {
 	ConfigureHealthCheck(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer) (*osc.LoadBalancer, error)
 	GetLoadBalancer(ctx context.Context, loadBalancerName string) (*osc.LoadBalancer, error)
+	ListLoadBalancers(ctx context.Context) ([]osc.LoadBalancer, error)
 	CreateLoadBalancer(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer, subnetId string, securityGroupId string) (*osc.LoadBalancer, error)
 	DeleteLoadBalancer(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer) error
 	LinkLoadBalancerBackendMachines(ctx context.Context, vmIds []string, loadBalancerName string) error
@@ -28,6 +29,8 @@ type OscLoadBalancerInterface interface {
 	GetLoadBalancerTag(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer) (*osc.LoadBalancerTag, error)
 	CreateLoadBalancerTag(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer, loadBalancerTag *osc.ResourceTag) error
 	DeleteLoadBalancerTag(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer, loadBalancerTag osc.ResourceLoadBalancerTag) error
+	CreateLoadBalancerListeners(ctx context.Context, loadBalancerName string, listeners []infrastructurev1beta1.OscLoadBalancerListener) error
+	DeleteLoadBalancerListeners(ctx context.Context, loadBalancerName string, loadBalancerPorts []int32) error
 }
 
 // ConfigureHealthCheck update loadBalancer to configure healthCheck
@@ -144,6 +147,17 @@ func (s *Service) GetLoadBalancer(ctx context.Context, loadBalancerName string)
 	}
 }
 
+// ListLoadBalancers lists all loadBalancers of the account
+func (s *Service) ListLoadBalancers(ctx context.Context) ([]osc.LoadBalancer, error) {
+	readLoadBalancerRequest := osc.ReadLoadBalancersRequest{}
+	readLoadBalancersResponse, httpRes, err := s.tenant.Client().LoadBalancerApi.ReadLoadBalancers(s.tenant.ContextWithAuth(ctx)).ReadLoadBalancersRequest(readLoadBalancerRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadLoadBalancers", readLoadBalancerRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return readLoadBalancersResponse.GetLoadBalancers(), nil
+}
+
 // GetLoadBalancerTag retrieve loadBalancer object from spec
 func (s *Service) GetLoadBalancerTag(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer) (*osc.LoadBalancerTag, error) {
 	readLoadBalancerTagRequest := osc.ReadLoadBalancerTagsRequest{
@@ -198,22 +212,11 @@ func (s *Service) CreateLoadBalancerTag(ctx context.Context, spec *infrastructur
 // CreateLoadBalancer create the load balancer
 func (s *Service) CreateLoadBalancer(ctx context.Context, spec *infrastructurev1beta1.OscLoadBalancer, subnetId string, securityGroupId string) (*osc.LoadBalancer, error) {
 	loadBalancerType := spec.LoadBalancerType
-	backendPort := spec.Listener.BackendPort
-	loadBalancerPort := spec.Listener.LoadBalancerPort
-	backendProtocol := spec.Listener.BackendProtocol
-	loadBalancerProtocol := spec.Listener.LoadBalancerProtocol
-
-	first_listener := osc.ListenerForCreation{
-		BackendPort:          backendPort,
-		BackendProtocol:      &backendProtocol,
-		LoadBalancerPort:     loadBalancerPort,
-		LoadBalancerProtocol: loadBalancerProtocol,
-	}
 
 	loadBalancerRequest := osc.CreateLoadBalancerRequest{
 		LoadBalancerName: spec.LoadBalancerName,
 		LoadBalancerType: &loadBalancerType,
-		Listeners:        []osc.ListenerForCreation{first_listener},
+		Listeners:        toListenersForCreation(spec.GetListeners()),
 		SecurityGroups:   &[]string{securityGroupId},
 		Subnets:          &[]string{subnetId},
 	}
@@ -252,3 +255,39 @@ func (s *Service) DeleteLoadBalancerTag(ctx context.Context, spec *infrastructur
 	err = utils.LogAndExtractError(ctx, "DeleteLoadBalancerTags", deleteLoadBalancerTagRequest, httpRes, err)
 	return err
 }
+
+// toListenersForCreation converts listener specs to OAPI listeners.
+func toListenersForCreation(specs []infrastructurev1beta1.OscLoadBalancerListener) []osc.ListenerForCreation {
+	listeners := make([]osc.ListenerForCreation, 0, len(specs))
+	for _, spec := range specs {
+		listeners = append(listeners, osc.ListenerForCreation{
+			BackendPort:          spec.BackendPort,
+			BackendProtocol:      &spec.BackendProtocol,
+			LoadBalancerPort:     spec.LoadBalancerPort,
+			LoadBalancerProtocol: spec.LoadBalancerProtocol,
+		})
+	}
+	return listeners
+}
+
+// CreateLoadBalancerListeners adds listeners to a loadbalancer
+func (s *Service) CreateLoadBalancerListeners(ctx context.Context, loadBalancerName string, listeners []infrastructurev1beta1.OscLoadBalancerListener) error {
+	createLoadBalancerListenersRequest := osc.CreateLoadBalancerListenersRequest{
+		LoadBalancerName: loadBalancerName,
+		Listeners:        toListenersForCreation(listeners),
+	}
+
+	_, httpRes, err := s.tenant.Client().ListenerApi.CreateLoadBalancerListeners(s.tenant.ContextWithAuth(ctx)).CreateLoadBalancerListenersRequest(createLoadBalancerListenersRequest).Execute()
+	return utils.LogAndExtractError(ctx, "CreateLoadBalancerListeners", createLoadBalancerListenersRequest, httpRes, err)
+}
+
+// DeleteLoadBalancerListeners deletes the listeners of a loadbalancer on some ports
+func (s *Service) DeleteLoadBalancerListeners(ctx context.Context, loadBalancerName string, loadBalancerPorts []int32) error {
+	deleteLoadBalancerListenersRequest := osc.DeleteLoadBalancerListenersRequest{
+		LoadBalancerName:  loadBalancerName,
+		LoadBalancerPorts: loadBalancerPorts,
+	}
+
+	_, httpRes, err := s.tenant.Client().ListenerApi.DeleteLoadBalancerListeners(s.tenant.ContextWithAuth(ctx)).DeleteLoadBalancerListenersRequest(deleteLoadBalancerListenersRequest).Execute()
+	return utils.LogAndExtractError(ctx, "DeleteLoadBalancerListeners", deleteLoadBalancerListenersRequest, httpRes, err)
+}
@@ -72,6 +72,20 @@ func (mr *MockOscLoadBalancerInterfaceMockRecorder) CreateLoadBalancer(ctx, spec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).CreateLoadBalancer), ctx, spec, subnetId, securityGroupId)
 }
 
+// CreateLoadBalancerListeners mocks base method.
+func (m *MockOscLoadBalancerInterface) CreateLoadBalancerListeners(ctx context.Context, loadBalancerName string, listeners []v1beta1.OscLoadBalancerListener) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoadBalancerListeners", ctx, loadBalancerName, listeners)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoadBalancerListeners indicates an expected call of CreateLoadBalancerListeners.
+func (mr *MockOscLoadBalancerInterfaceMockRecorder) CreateLoadBalancerListeners(ctx, loadBalancerName, listeners any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancerListeners", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).CreateLoadBalancerListeners), ctx, loadBalancerName, listeners)
+}
+
 // CreateLoadBalancerTag mocks base method.
 func (m *MockOscLoadBalancerInterface) CreateLoadBalancerTag(ctx context.Context, spec *v1beta1.OscLoadBalancer, loadBalancerTag *osc.ResourceTag) error {
 	m.ctrl.T.Helper()
@@ -100,6 +114,20 @@ func (mr *MockOscLoadBalancerInterfaceMockRecorder) DeleteLoadBalancer(ctx, spec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).DeleteLoadBalancer), ctx, spec)
 }
 
+// DeleteLoadBalancerListeners mocks base method.
+func (m *MockOscLoadBalancerInterface) DeleteLoadBalancerListeners(ctx context.Context, loadBalancerName string, loadBalancerPorts []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoadBalancerListeners", ctx, loadBalancerName, loadBalancerPorts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadBalancerListeners indicates an expected call of DeleteLoadBalancerListeners.
+func (mr *MockOscLoadBalancerInterfaceMockRecorder) DeleteLoadBalancerListeners(ctx, loadBalancerName, loadBalancerPorts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancerListeners", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).DeleteLoadBalancerListeners), ctx, loadBalancerName, loadBalancerPorts)
+}
+
 // DeleteLoadBalancerTag mocks base method.
 func (m *MockOscLoadBalancerInterface) DeleteLoadBalancerTag(ctx context.Context, spec *v1beta1.OscLoadBalancer, loadBalancerTag osc.ResourceLoadBalancerTag) error {
 	m.ctrl.T.Helper()
@@ -158,6 +186,21 @@ func (mr *MockOscLoadBalancerInterfaceMockRecorder) LinkLoadBalancerBackendMachi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkLoadBalancerBackendMachines", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).LinkLoadBalancerBackendMachines), ctx, vmIds, loadBalancerName)
 }
 
+// ListLoadBalancers mocks base method.
+func (m *MockOscLoadBalancerInterface) ListLoadBalancers(ctx context.Context) ([]osc.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoadBalancers", ctx)
+	ret0, _ := ret[0].([]osc.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoadBalancers indicates an expected call of ListLoadBalancers.
+func (mr *MockOscLoadBalancerInterfaceMockRecorder) ListLoadBalancers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockOscLoadBalancerInterface)(nil).ListLoadBalancers), ctx)
+}
+
 // UnlinkLoadBalancerBackendMachines mocks base method.
 func (m *MockOscLoadBalancerInterface) UnlinkLoadBalancerBackendMachines(ctx context.Context, vmIds []string, loadBalancerName string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,100 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package net
+
+import (
+	"context"
+	"errors"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_net/dhcpoptions_mock.go -package mock_net -source ./dhcpoptions.go
+type OscDhcpOptionsInterface interface {
+	CreateDhcpOptions(ctx context.Context, spec infrastructurev1beta1.OscDhcpOptions, clusterID, name string) (*osc.DhcpOptionsSet, error)
+	DeleteDhcpOptions(ctx context.Context, dhcpOptionsSetId string) error
+	GetDhcpOptions(ctx context.Context, dhcpOptionsSetId string) (*osc.DhcpOptionsSet, error)
+	LinkDhcpOptions(ctx context.Context, dhcpOptionsSetId, netId string) error
+}
+
+// CreateDhcpOptions creates a DHCP options set.
+func (s *Service) CreateDhcpOptions(ctx context.Context, spec infrastructurev1beta1.OscDhcpOptions, clusterID, name string) (*osc.DhcpOptionsSet, error) {
+	req := osc.CreateDhcpOptionsRequest{}
+	if spec.DomainName != "" {
+		req.DomainName = &spec.DomainName
+	}
+	if len(spec.DomainNameServers) > 0 {
+		req.DomainNameServers = &spec.DomainNameServers
+	}
+	if len(spec.NtpServers) > 0 {
+		req.NtpServers = &spec.NtpServers
+	}
+	resp, httpRes, err := s.tenant.Client().DhcpOptionApi.CreateDhcpOptions(s.tenant.ContextWithAuth(ctx)).CreateDhcpOptionsRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateDhcpOptions", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	dhcp, ok := resp.GetDhcpOptionsSetOk()
+	if !ok {
+		return nil, errors.New("cannot create DHCP options")
+	}
+	resourceIds := []string{dhcp.GetDhcpOptionsSetId()}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.NameKey,
+			Value: name,
+		}, {
+			Key:   tag.ClusterKeyPrefix + clusterID,
+			Value: tag.OwnedValue,
+		}},
+	}
+	err = tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return dhcp, nil
+}
+
+// DeleteDhcpOptions deletes a DHCP options set.
+func (s *Service) DeleteDhcpOptions(ctx context.Context, dhcpOptionsSetId string) error {
+	req := osc.DeleteDhcpOptionsRequest{DhcpOptionsSetId: dhcpOptionsSetId}
+	_, httpRes, err := s.tenant.Client().DhcpOptionApi.DeleteDhcpOptions(s.tenant.ContextWithAuth(ctx)).DeleteDhcpOptionsRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteDhcpOptions", req, httpRes, err)
+	return err
+}
+
+// GetDhcpOptions fetches a DHCP options set by id.
+func (s *Service) GetDhcpOptions(ctx context.Context, dhcpOptionsSetId string) (*osc.DhcpOptionsSet, error) {
+	req := osc.ReadDhcpOptionsRequest{
+		Filters: &osc.FiltersDhcpOptions{
+			DhcpOptionsSetIds: &[]string{dhcpOptionsSetId},
+		},
+	}
+	resp, httpRes, err := s.tenant.Client().DhcpOptionApi.ReadDhcpOptions(s.tenant.ContextWithAuth(ctx)).ReadDhcpOptionsRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadDhcpOptions", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetDhcpOptionsSets()) == 0 {
+		return nil, nil
+	}
+	return &resp.GetDhcpOptionsSets()[0], nil
+}
+
+// LinkDhcpOptions links a DHCP options set to a net ("default" links the default DHCP options set).
+func (s *Service) LinkDhcpOptions(ctx context.Context, dhcpOptionsSetId, netId string) error {
+	req := osc.UpdateNetRequest{
+		DhcpOptionsSetId: dhcpOptionsSetId,
+		NetId:            netId,
+	}
+	_, httpRes, err := s.tenant.Client().NetApi.UpdateNet(s.tenant.ContextWithAuth(ctx)).UpdateNetRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateNet", req, httpRes, err)
+	return err
+}
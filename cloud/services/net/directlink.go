@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package net
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_net/directlink_mock.go -package mock_net -source ./directlink.go
+type OscDirectLinkInterface interface {
+	CreateDirectLinkInterface(ctx context.Context, directLinkId string, dli osc.DirectLinkInterface) (*osc.DirectLinkInterfaces, error)
+	DeleteDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) error
+	GetDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) (*osc.DirectLinkInterfaces, error)
+	ListDirectLinkInterfaces(ctx context.Context, directLinkId string) ([]osc.DirectLinkInterfaces, error)
+}
+
+// CreateDirectLinkInterface creates an interface on a DirectLink.
+func (s *Service) CreateDirectLinkInterface(ctx context.Context, directLinkId string, dli osc.DirectLinkInterface) (*osc.DirectLinkInterfaces, error) {
+	req := osc.CreateDirectLinkInterfaceRequest{
+		DirectLinkId:        directLinkId,
+		DirectLinkInterface: dli,
+	}
+	resp, httpRes, err := s.tenant.Client().DirectLinkInterfaceApi.CreateDirectLinkInterface(s.tenant.ContextWithAuth(ctx)).CreateDirectLinkInterfaceRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateDirectLinkInterface", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	created, ok := resp.GetDirectLinkInterfaceOk()
+	if !ok {
+		return nil, errors.New("cannot create directLink interface")
+	}
+	return created, nil
+}
+
+// DeleteDirectLinkInterface deletes a DirectLink interface.
+func (s *Service) DeleteDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) error {
+	req := osc.DeleteDirectLinkInterfaceRequest{DirectLinkInterfaceId: directLinkInterfaceId}
+	_, httpRes, err := s.tenant.Client().DirectLinkInterfaceApi.DeleteDirectLinkInterface(s.tenant.ContextWithAuth(ctx)).DeleteDirectLinkInterfaceRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteDirectLinkInterface", req, httpRes, err)
+	return err
+}
+
+// GetDirectLinkInterface fetches a DirectLink interface by id.
+func (s *Service) GetDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) (*osc.DirectLinkInterfaces, error) {
+	dlis, err := s.readDirectLinkInterfaces(ctx, osc.FiltersDirectLinkInterface{
+		DirectLinkInterfaceIds: &[]string{directLinkInterfaceId},
+	})
+	if err != nil || len(dlis) == 0 {
+		return nil, err
+	}
+	return &dlis[0], nil
+}
+
+// ListDirectLinkInterfaces lists the interfaces of a DirectLink.
+func (s *Service) ListDirectLinkInterfaces(ctx context.Context, directLinkId string) ([]osc.DirectLinkInterfaces, error) {
+	return s.readDirectLinkInterfaces(ctx, osc.FiltersDirectLinkInterface{
+		DirectLinkIds: &[]string{directLinkId},
+	})
+}
+
+func (s *Service) readDirectLinkInterfaces(ctx context.Context, filters osc.FiltersDirectLinkInterface) ([]osc.DirectLinkInterfaces, error) {
+	req := osc.ReadDirectLinkInterfacesRequest{Filters: &filters}
+	resp, httpRes, err := s.tenant.Client().DirectLinkInterfaceApi.ReadDirectLinkInterfaces(s.tenant.ContextWithAuth(ctx)).ReadDirectLinkInterfacesRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadDirectLinkInterfaces", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetDirectLinkInterfaces(), nil
+}
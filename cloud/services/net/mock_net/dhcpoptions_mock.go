@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./dhcpoptions.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_net/dhcpoptions_mock.go -package mock_net -source ./dhcpoptions.go
+//
+
+// Package mock_net is a generated GoMock package.
+package mock_net
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscDhcpOptionsInterface is a mock of OscDhcpOptionsInterface interface.
+type MockOscDhcpOptionsInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscDhcpOptionsInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscDhcpOptionsInterfaceMockRecorder is the mock recorder for MockOscDhcpOptionsInterface.
+type MockOscDhcpOptionsInterfaceMockRecorder struct {
+	mock *MockOscDhcpOptionsInterface
+}
+
+// NewMockOscDhcpOptionsInterface creates a new mock instance.
+func NewMockOscDhcpOptionsInterface(ctrl *gomock.Controller) *MockOscDhcpOptionsInterface {
+	mock := &MockOscDhcpOptionsInterface{ctrl: ctrl}
+	mock.recorder = &MockOscDhcpOptionsInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscDhcpOptionsInterface) EXPECT() *MockOscDhcpOptionsInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDhcpOptions mocks base method.
+func (m *MockOscDhcpOptionsInterface) CreateDhcpOptions(ctx context.Context, spec v1beta1.OscDhcpOptions, clusterID, name string) (*osc.DhcpOptionsSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDhcpOptions", ctx, spec, clusterID, name)
+	ret0, _ := ret[0].(*osc.DhcpOptionsSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDhcpOptions indicates an expected call of CreateDhcpOptions.
+func (mr *MockOscDhcpOptionsInterfaceMockRecorder) CreateDhcpOptions(ctx, spec, clusterID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDhcpOptions", reflect.TypeOf((*MockOscDhcpOptionsInterface)(nil).CreateDhcpOptions), ctx, spec, clusterID, name)
+}
+
+// DeleteDhcpOptions mocks base method.
+func (m *MockOscDhcpOptionsInterface) DeleteDhcpOptions(ctx context.Context, dhcpOptionsSetId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDhcpOptions", ctx, dhcpOptionsSetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDhcpOptions indicates an expected call of DeleteDhcpOptions.
+func (mr *MockOscDhcpOptionsInterfaceMockRecorder) DeleteDhcpOptions(ctx, dhcpOptionsSetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDhcpOptions", reflect.TypeOf((*MockOscDhcpOptionsInterface)(nil).DeleteDhcpOptions), ctx, dhcpOptionsSetId)
+}
+
+// GetDhcpOptions mocks base method.
+func (m *MockOscDhcpOptionsInterface) GetDhcpOptions(ctx context.Context, dhcpOptionsSetId string) (*osc.DhcpOptionsSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDhcpOptions", ctx, dhcpOptionsSetId)
+	ret0, _ := ret[0].(*osc.DhcpOptionsSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDhcpOptions indicates an expected call of GetDhcpOptions.
+func (mr *MockOscDhcpOptionsInterfaceMockRecorder) GetDhcpOptions(ctx, dhcpOptionsSetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDhcpOptions", reflect.TypeOf((*MockOscDhcpOptionsInterface)(nil).GetDhcpOptions), ctx, dhcpOptionsSetId)
+}
+
+// LinkDhcpOptions mocks base method.
+func (m *MockOscDhcpOptionsInterface) LinkDhcpOptions(ctx context.Context, dhcpOptionsSetId, netId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkDhcpOptions", ctx, dhcpOptionsSetId, netId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkDhcpOptions indicates an expected call of LinkDhcpOptions.
+func (mr *MockOscDhcpOptionsInterfaceMockRecorder) LinkDhcpOptions(ctx, dhcpOptionsSetId, netId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkDhcpOptions", reflect.TypeOf((*MockOscDhcpOptionsInterface)(nil).LinkDhcpOptions), ctx, dhcpOptionsSetId, netId)
+}
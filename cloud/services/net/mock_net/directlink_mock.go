@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./directlink.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_net/directlink_mock.go -package mock_net -source ./directlink.go
+//
+
+// Package mock_net is a generated GoMock package.
+package mock_net
+
+import (
+	context "context"
+	reflect "reflect"
+
+	osc "github.com/outscale/osc-sdk-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscDirectLinkInterface is a mock of OscDirectLinkInterface interface.
+type MockOscDirectLinkInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscDirectLinkInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscDirectLinkInterfaceMockRecorder is the mock recorder for MockOscDirectLinkInterface.
+type MockOscDirectLinkInterfaceMockRecorder struct {
+	mock *MockOscDirectLinkInterface
+}
+
+// NewMockOscDirectLinkInterface creates a new mock instance.
+func NewMockOscDirectLinkInterface(ctrl *gomock.Controller) *MockOscDirectLinkInterface {
+	mock := &MockOscDirectLinkInterface{ctrl: ctrl}
+	mock.recorder = &MockOscDirectLinkInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscDirectLinkInterface) EXPECT() *MockOscDirectLinkInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDirectLinkInterface mocks base method.
+func (m *MockOscDirectLinkInterface) CreateDirectLinkInterface(ctx context.Context, directLinkId string, dli osc.DirectLinkInterface) (*osc.DirectLinkInterfaces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDirectLinkInterface", ctx, directLinkId, dli)
+	ret0, _ := ret[0].(*osc.DirectLinkInterfaces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirectLinkInterface indicates an expected call of CreateDirectLinkInterface.
+func (mr *MockOscDirectLinkInterfaceMockRecorder) CreateDirectLinkInterface(ctx, directLinkId, dli any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectLinkInterface", reflect.TypeOf((*MockOscDirectLinkInterface)(nil).CreateDirectLinkInterface), ctx, directLinkId, dli)
+}
+
+// DeleteDirectLinkInterface mocks base method.
+func (m *MockOscDirectLinkInterface) DeleteDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDirectLinkInterface", ctx, directLinkInterfaceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDirectLinkInterface indicates an expected call of DeleteDirectLinkInterface.
+func (mr *MockOscDirectLinkInterfaceMockRecorder) DeleteDirectLinkInterface(ctx, directLinkInterfaceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDirectLinkInterface", reflect.TypeOf((*MockOscDirectLinkInterface)(nil).DeleteDirectLinkInterface), ctx, directLinkInterfaceId)
+}
+
+// GetDirectLinkInterface mocks base method.
+func (m *MockOscDirectLinkInterface) GetDirectLinkInterface(ctx context.Context, directLinkInterfaceId string) (*osc.DirectLinkInterfaces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectLinkInterface", ctx, directLinkInterfaceId)
+	ret0, _ := ret[0].(*osc.DirectLinkInterfaces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectLinkInterface indicates an expected call of GetDirectLinkInterface.
+func (mr *MockOscDirectLinkInterfaceMockRecorder) GetDirectLinkInterface(ctx, directLinkInterfaceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectLinkInterface", reflect.TypeOf((*MockOscDirectLinkInterface)(nil).GetDirectLinkInterface), ctx, directLinkInterfaceId)
+}
+
+// ListDirectLinkInterfaces mocks base method.
+func (m *MockOscDirectLinkInterface) ListDirectLinkInterfaces(ctx context.Context, directLinkId string) ([]osc.DirectLinkInterfaces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectLinkInterfaces", ctx, directLinkId)
+	ret0, _ := ret[0].([]osc.DirectLinkInterfaces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectLinkInterfaces indicates an expected call of ListDirectLinkInterfaces.
+func (mr *MockOscDirectLinkInterfaceMockRecorder) ListDirectLinkInterfaces(ctx, directLinkId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectLinkInterfaces", reflect.TypeOf((*MockOscDirectLinkInterface)(nil).ListDirectLinkInterfaces), ctx, directLinkId)
+}
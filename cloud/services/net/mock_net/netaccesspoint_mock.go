@@ -100,6 +100,20 @@ func (mr *MockOscNetAccessPointInterfaceMockRecorder) GetNetAccessPointFor(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetAccessPointFor", reflect.TypeOf((*MockOscNetAccessPointInterface)(nil).GetNetAccessPointFor), ctx, netId, region, service)
 }
 
+// LinkNetAccessPointRouteTables mocks base method.
+func (m *MockOscNetAccessPointInterface) LinkNetAccessPointRouteTables(ctx context.Context, netAccessPointId string, rtblIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkNetAccessPointRouteTables", ctx, netAccessPointId, rtblIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkNetAccessPointRouteTables indicates an expected call of LinkNetAccessPointRouteTables.
+func (mr *MockOscNetAccessPointInterfaceMockRecorder) LinkNetAccessPointRouteTables(ctx, netAccessPointId, rtblIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkNetAccessPointRouteTables", reflect.TypeOf((*MockOscNetAccessPointInterface)(nil).LinkNetAccessPointRouteTables), ctx, netAccessPointId, rtblIds)
+}
+
 // ListNetAccessPoints mocks base method.
 func (m *MockOscNetAccessPointInterface) ListNetAccessPoints(ctx context.Context, netId string) ([]osc.NetAccessPoint, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +55,21 @@ func (mr *MockOscNetPeeringInterfaceMockRecorder) AcceptNetPeering(ctx, netPeeri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptNetPeering", reflect.TypeOf((*MockOscNetPeeringInterface)(nil).AcceptNetPeering), ctx, netPeeringID)
 }
 
+// CreateNamedNetPeering mocks base method.
+func (m *MockOscNetPeeringInterface) CreateNamedNetPeering(ctx context.Context, netID, peerNetID, peerAccountID, name string) (*osc.NetPeering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamedNetPeering", ctx, netID, peerNetID, peerAccountID, name)
+	ret0, _ := ret[0].(*osc.NetPeering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNamedNetPeering indicates an expected call of CreateNamedNetPeering.
+func (mr *MockOscNetPeeringInterfaceMockRecorder) CreateNamedNetPeering(ctx, netID, peerNetID, peerAccountID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamedNetPeering", reflect.TypeOf((*MockOscNetPeeringInterface)(nil).CreateNamedNetPeering), ctx, netID, peerNetID, peerAccountID, name)
+}
+
 // CreateNetPeering mocks base method.
 func (m *MockOscNetPeeringInterface) CreateNetPeering(ctx context.Context, netID, mgmtNetID, mgmtAccountID, clusterID string) (*osc.NetPeering, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./nic.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_net/nic_mock.go -package mock_net -source ./nic.go
+//
+
+// Package mock_net is a generated GoMock package.
+package mock_net
+
+import (
+	context "context"
+	reflect "reflect"
+
+	osc "github.com/outscale/osc-sdk-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscNicInterface is a mock of OscNicInterface interface.
+type MockOscNicInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscNicInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscNicInterfaceMockRecorder is the mock recorder for MockOscNicInterface.
+type MockOscNicInterfaceMockRecorder struct {
+	mock *MockOscNicInterface
+}
+
+// NewMockOscNicInterface creates a new mock instance.
+func NewMockOscNicInterface(ctrl *gomock.Controller) *MockOscNicInterface {
+	mock := &MockOscNicInterface{ctrl: ctrl}
+	mock.recorder = &MockOscNicInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscNicInterface) EXPECT() *MockOscNicInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateNic mocks base method.
+func (m *MockOscNicInterface) CreateNic(ctx context.Context, subnetId string, securityGroupIds []string, privateIp, clusterID, nicName string) (*osc.Nic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNic", ctx, subnetId, securityGroupIds, privateIp, clusterID, nicName)
+	ret0, _ := ret[0].(*osc.Nic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNic indicates an expected call of CreateNic.
+func (mr *MockOscNicInterfaceMockRecorder) CreateNic(ctx, subnetId, securityGroupIds, privateIp, clusterID, nicName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNic", reflect.TypeOf((*MockOscNicInterface)(nil).CreateNic), ctx, subnetId, securityGroupIds, privateIp, clusterID, nicName)
+}
+
+// DeleteNic mocks base method.
+func (m *MockOscNicInterface) DeleteNic(ctx context.Context, nicId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNic", ctx, nicId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNic indicates an expected call of DeleteNic.
+func (mr *MockOscNicInterfaceMockRecorder) DeleteNic(ctx, nicId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNic", reflect.TypeOf((*MockOscNicInterface)(nil).DeleteNic), ctx, nicId)
+}
+
+// GetNic mocks base method.
+func (m *MockOscNicInterface) GetNic(ctx context.Context, nicId string) (*osc.Nic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNic", ctx, nicId)
+	ret0, _ := ret[0].(*osc.Nic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNic indicates an expected call of GetNic.
+func (mr *MockOscNicInterfaceMockRecorder) GetNic(ctx, nicId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNic", reflect.TypeOf((*MockOscNicInterface)(nil).GetNic), ctx, nicId)
+}
@@ -100,3 +100,18 @@ func (mr *MockOscSubnetInterfaceMockRecorder) GetSubnetFromNet(ctx, netId, ipRan
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetFromNet", reflect.TypeOf((*MockOscSubnetInterface)(nil).GetSubnetFromNet), ctx, netId, ipRange)
 }
+
+// ListSubnets mocks base method.
+func (m *MockOscSubnetInterface) ListSubnets(ctx context.Context, subnetIds []string) ([]osc.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnets", ctx, subnetIds)
+	ret0, _ := ret[0].([]osc.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnets indicates an expected call of ListSubnets.
+func (mr *MockOscSubnetInterfaceMockRecorder) ListSubnets(ctx, subnetIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnets", reflect.TypeOf((*MockOscSubnetInterface)(nil).ListSubnets), ctx, subnetIds)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./virtualgateway.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_net/virtualgateway_mock.go -package mock_net -source ./virtualgateway.go
+//
+
+// Package mock_net is a generated GoMock package.
+package mock_net
+
+import (
+	context "context"
+	reflect "reflect"
+
+	osc "github.com/outscale/osc-sdk-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscVirtualGatewayInterface is a mock of OscVirtualGatewayInterface interface.
+type MockOscVirtualGatewayInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscVirtualGatewayInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscVirtualGatewayInterfaceMockRecorder is the mock recorder for MockOscVirtualGatewayInterface.
+type MockOscVirtualGatewayInterfaceMockRecorder struct {
+	mock *MockOscVirtualGatewayInterface
+}
+
+// NewMockOscVirtualGatewayInterface creates a new mock instance.
+func NewMockOscVirtualGatewayInterface(ctrl *gomock.Controller) *MockOscVirtualGatewayInterface {
+	mock := &MockOscVirtualGatewayInterface{ctrl: ctrl}
+	mock.recorder = &MockOscVirtualGatewayInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscVirtualGatewayInterface) EXPECT() *MockOscVirtualGatewayInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateVirtualGateway mocks base method.
+func (m *MockOscVirtualGatewayInterface) CreateVirtualGateway(ctx context.Context, clusterID, name string) (*osc.VirtualGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualGateway", ctx, clusterID, name)
+	ret0, _ := ret[0].(*osc.VirtualGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVirtualGateway indicates an expected call of CreateVirtualGateway.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) CreateVirtualGateway(ctx, clusterID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualGateway", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).CreateVirtualGateway), ctx, clusterID, name)
+}
+
+// DeleteVirtualGateway mocks base method.
+func (m *MockOscVirtualGatewayInterface) DeleteVirtualGateway(ctx context.Context, virtualGatewayId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualGateway", ctx, virtualGatewayId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualGateway indicates an expected call of DeleteVirtualGateway.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) DeleteVirtualGateway(ctx, virtualGatewayId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualGateway", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).DeleteVirtualGateway), ctx, virtualGatewayId)
+}
+
+// GetVirtualGateway mocks base method.
+func (m *MockOscVirtualGatewayInterface) GetVirtualGateway(ctx context.Context, virtualGatewayId string) (*osc.VirtualGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualGateway", ctx, virtualGatewayId)
+	ret0, _ := ret[0].(*osc.VirtualGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualGateway indicates an expected call of GetVirtualGateway.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) GetVirtualGateway(ctx, virtualGatewayId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualGateway", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).GetVirtualGateway), ctx, virtualGatewayId)
+}
+
+// LinkVirtualGateway mocks base method.
+func (m *MockOscVirtualGatewayInterface) LinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkVirtualGateway", ctx, virtualGatewayId, netId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkVirtualGateway indicates an expected call of LinkVirtualGateway.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) LinkVirtualGateway(ctx, virtualGatewayId, netId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkVirtualGateway", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).LinkVirtualGateway), ctx, virtualGatewayId, netId)
+}
+
+// UnlinkVirtualGateway mocks base method.
+func (m *MockOscVirtualGatewayInterface) UnlinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkVirtualGateway", ctx, virtualGatewayId, netId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkVirtualGateway indicates an expected call of UnlinkVirtualGateway.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) UnlinkVirtualGateway(ctx, virtualGatewayId, netId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkVirtualGateway", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).UnlinkVirtualGateway), ctx, virtualGatewayId, netId)
+}
+
+// UpdateRoutePropagation mocks base method.
+func (m *MockOscVirtualGatewayInterface) UpdateRoutePropagation(ctx context.Context, virtualGatewayId, routeTableId string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoutePropagation", ctx, virtualGatewayId, routeTableId, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoutePropagation indicates an expected call of UpdateRoutePropagation.
+func (mr *MockOscVirtualGatewayInterfaceMockRecorder) UpdateRoutePropagation(ctx, virtualGatewayId, routeTableId, enable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoutePropagation", reflect.TypeOf((*MockOscVirtualGatewayInterface)(nil).UpdateRoutePropagation), ctx, virtualGatewayId, routeTableId, enable)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./vpn.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_net/vpn_mock.go -package mock_net -source ./vpn.go
+//
+
+// Package mock_net is a generated GoMock package.
+package mock_net
+
+import (
+	context "context"
+	reflect "reflect"
+
+	osc "github.com/outscale/osc-sdk-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscVpnInterface is a mock of OscVpnInterface interface.
+type MockOscVpnInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscVpnInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscVpnInterfaceMockRecorder is the mock recorder for MockOscVpnInterface.
+type MockOscVpnInterfaceMockRecorder struct {
+	mock *MockOscVpnInterface
+}
+
+// NewMockOscVpnInterface creates a new mock instance.
+func NewMockOscVpnInterface(ctrl *gomock.Controller) *MockOscVpnInterface {
+	mock := &MockOscVpnInterface{ctrl: ctrl}
+	mock.recorder = &MockOscVpnInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscVpnInterface) EXPECT() *MockOscVpnInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateClientGateway mocks base method.
+func (m *MockOscVpnInterface) CreateClientGateway(ctx context.Context, publicIp string, bgpAsn int32, clusterID, name string) (*osc.ClientGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClientGateway", ctx, publicIp, bgpAsn, clusterID, name)
+	ret0, _ := ret[0].(*osc.ClientGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClientGateway indicates an expected call of CreateClientGateway.
+func (mr *MockOscVpnInterfaceMockRecorder) CreateClientGateway(ctx, publicIp, bgpAsn, clusterID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClientGateway", reflect.TypeOf((*MockOscVpnInterface)(nil).CreateClientGateway), ctx, publicIp, bgpAsn, clusterID, name)
+}
+
+// CreateVpnConnection mocks base method.
+func (m *MockOscVpnInterface) CreateVpnConnection(ctx context.Context, clientGatewayId, virtualGatewayId string, staticRoutesOnly bool, clusterID, name string) (*osc.VpnConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpnConnection", ctx, clientGatewayId, virtualGatewayId, staticRoutesOnly, clusterID, name)
+	ret0, _ := ret[0].(*osc.VpnConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVpnConnection indicates an expected call of CreateVpnConnection.
+func (mr *MockOscVpnInterfaceMockRecorder) CreateVpnConnection(ctx, clientGatewayId, virtualGatewayId, staticRoutesOnly, clusterID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpnConnection", reflect.TypeOf((*MockOscVpnInterface)(nil).CreateVpnConnection), ctx, clientGatewayId, virtualGatewayId, staticRoutesOnly, clusterID, name)
+}
+
+// CreateVpnConnectionRoute mocks base method.
+func (m *MockOscVpnInterface) CreateVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpnConnectionRoute", ctx, vpnConnectionId, destinationIpRange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVpnConnectionRoute indicates an expected call of CreateVpnConnectionRoute.
+func (mr *MockOscVpnInterfaceMockRecorder) CreateVpnConnectionRoute(ctx, vpnConnectionId, destinationIpRange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpnConnectionRoute", reflect.TypeOf((*MockOscVpnInterface)(nil).CreateVpnConnectionRoute), ctx, vpnConnectionId, destinationIpRange)
+}
+
+// DeleteClientGateway mocks base method.
+func (m *MockOscVpnInterface) DeleteClientGateway(ctx context.Context, clientGatewayId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClientGateway", ctx, clientGatewayId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClientGateway indicates an expected call of DeleteClientGateway.
+func (mr *MockOscVpnInterfaceMockRecorder) DeleteClientGateway(ctx, clientGatewayId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientGateway", reflect.TypeOf((*MockOscVpnInterface)(nil).DeleteClientGateway), ctx, clientGatewayId)
+}
+
+// DeleteVpnConnection mocks base method.
+func (m *MockOscVpnInterface) DeleteVpnConnection(ctx context.Context, vpnConnectionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpnConnection", ctx, vpnConnectionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpnConnection indicates an expected call of DeleteVpnConnection.
+func (mr *MockOscVpnInterfaceMockRecorder) DeleteVpnConnection(ctx, vpnConnectionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpnConnection", reflect.TypeOf((*MockOscVpnInterface)(nil).DeleteVpnConnection), ctx, vpnConnectionId)
+}
+
+// DeleteVpnConnectionRoute mocks base method.
+func (m *MockOscVpnInterface) DeleteVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVpnConnectionRoute", ctx, vpnConnectionId, destinationIpRange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVpnConnectionRoute indicates an expected call of DeleteVpnConnectionRoute.
+func (mr *MockOscVpnInterfaceMockRecorder) DeleteVpnConnectionRoute(ctx, vpnConnectionId, destinationIpRange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpnConnectionRoute", reflect.TypeOf((*MockOscVpnInterface)(nil).DeleteVpnConnectionRoute), ctx, vpnConnectionId, destinationIpRange)
+}
+
+// GetClientGateway mocks base method.
+func (m *MockOscVpnInterface) GetClientGateway(ctx context.Context, clientGatewayId string) (*osc.ClientGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientGateway", ctx, clientGatewayId)
+	ret0, _ := ret[0].(*osc.ClientGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientGateway indicates an expected call of GetClientGateway.
+func (mr *MockOscVpnInterfaceMockRecorder) GetClientGateway(ctx, clientGatewayId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientGateway", reflect.TypeOf((*MockOscVpnInterface)(nil).GetClientGateway), ctx, clientGatewayId)
+}
+
+// GetVpnConnection mocks base method.
+func (m *MockOscVpnInterface) GetVpnConnection(ctx context.Context, vpnConnectionId string) (*osc.VpnConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVpnConnection", ctx, vpnConnectionId)
+	ret0, _ := ret[0].(*osc.VpnConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVpnConnection indicates an expected call of GetVpnConnection.
+func (mr *MockOscVpnInterfaceMockRecorder) GetVpnConnection(ctx, vpnConnectionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVpnConnection", reflect.TypeOf((*MockOscVpnInterface)(nil).GetVpnConnection), ctx, vpnConnectionId)
+}
@@ -18,6 +18,7 @@ import (
 //go:generate ../../../bin/mockgen -destination mock_net/netaccesspoint_mock.go -package mock_net -source ./netaccesspoint.go
 type OscNetAccessPointInterface interface {
 	CreateNetAccessPoint(ctx context.Context, netId, region, service string, rtblIds []string, clusterID string) (*osc.NetAccessPoint, error)
+	LinkNetAccessPointRouteTables(ctx context.Context, netAccessPointId string, rtblIds []string) error
 	DeleteNetAccessPoint(ctx context.Context, netAccessPointId string) error
 	ListNetAccessPoints(ctx context.Context, netId string) ([]osc.NetAccessPoint, error)
 	GetNetAccessPoint(ctx context.Context, netAccessPointId string) (*osc.NetAccessPoint, error)
@@ -61,6 +62,18 @@ func (s *Service) CreateNetAccessPoint(ctx context.Context, netId, region, servi
 	return netAccessPoint, nil
 }
 
+// LinkNetAccessPointRouteTables adds route tables to a net access point.
+func (s *Service) LinkNetAccessPointRouteTables(ctx context.Context, netAccessPointId string, rtblIds []string) error {
+	updateNetAccessPointRequest := osc.UpdateNetAccessPointRequest{
+		NetAccessPointId: netAccessPointId,
+		AddRouteTableIds: &rtblIds,
+	}
+
+	_, httpRes, err := s.tenant.Client().NetAccessPointApi.UpdateNetAccessPoint(s.tenant.ContextWithAuth(ctx)).UpdateNetAccessPointRequest(updateNetAccessPointRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateNetAccessPoint", updateNetAccessPointRequest, httpRes, err)
+	return err
+}
+
 // DeleteNetAccessPoint deletes an net access point.
 func (s *Service) DeleteNetAccessPoint(ctx context.Context, netAccessPointId string) error {
 	deleteNetAccessPointRequest := osc.DeleteNetAccessPointRequest{NetAccessPointId: netAccessPointId}
@@ -18,6 +18,7 @@ import (
 
 type OscNetPeeringInterface interface {
 	CreateNetPeering(ctx context.Context, netID, mgmtNetID, mgmtAccountID, clusterID string) (*osc.NetPeering, error)
+	CreateNamedNetPeering(ctx context.Context, netID, peerNetID, peerAccountID, name string) (*osc.NetPeering, error)
 	AcceptNetPeering(ctx context.Context, netPeeringID string) error
 	DeleteNetPeering(ctx context.Context, netPeeringID string) error
 	GetNetPeering(ctx context.Context, netPeeringID string) (*osc.NetPeering, error)
@@ -58,6 +59,37 @@ func (s *Service) CreateNetPeering(ctx context.Context, netID, mgmtNetID, mgmtAc
 	return netPeering, nil
 }
 
+// CreateNamedNetPeering creates a net peering identified by a name tag.
+// The owned tag is not set, as it identifies the management net peering.
+func (s *Service) CreateNamedNetPeering(ctx context.Context, netID, peerNetID, peerAccountID, name string) (*osc.NetPeering, error) {
+	req := osc.CreateNetPeeringRequest{
+		SourceNetId:   netID,
+		AccepterNetId: peerNetID,
+	}
+	if peerAccountID != "" {
+		req.AccepterOwnerId = &peerAccountID
+	}
+	resp, httpRes, err := s.tenant.Client().NetPeeringApi.CreateNetPeering(s.tenant.ContextWithAuth(ctx)).CreateNetPeeringRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateNetPeering", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	netPeering, ok := resp.GetNetPeeringOk()
+	if !ok {
+		return nil, errors.New("cannot create netPeering")
+	}
+	resourceIds := []string{netPeering.GetNetPeeringId()}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags:        []osc.ResourceTag{{Key: tag.NameKey, Value: name}},
+	}
+	err = tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return netPeering, nil
+}
+
 // AcceptNetPeering accepts a net peering
 func (s *Service) AcceptNetPeering(ctx context.Context, netPeeringID string) error {
 	acceptNetPeeringRequest := osc.AcceptNetPeeringRequest{NetPeeringId: netPeeringID}
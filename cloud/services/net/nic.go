@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package net
+
+import (
+	"context"
+	"errors"
+
+	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	"k8s.io/utils/ptr"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_net/nic_mock.go -package mock_net -source ./nic.go
+type OscNicInterface interface {
+	CreateNic(ctx context.Context, subnetId string, securityGroupIds []string, privateIp string, clusterID, nicName string) (*osc.Nic, error)
+	DeleteNic(ctx context.Context, nicId string) error
+	GetNic(ctx context.Context, nicId string) (*osc.Nic, error)
+}
+
+// CreateNic creates a NIC, with an optional fixed private IP.
+func (s *Service) CreateNic(ctx context.Context, subnetId string, securityGroupIds []string, privateIp string, clusterID, nicName string) (*osc.Nic, error) {
+	nicRequest := osc.CreateNicRequest{
+		SubnetId:         subnetId,
+		SecurityGroupIds: &securityGroupIds,
+		Description:      &nicName,
+	}
+	if privateIp != "" {
+		nicRequest.PrivateIps = &[]osc.PrivateIpLight{{
+			PrivateIp: &privateIp,
+			IsPrimary: ptr.To(true),
+		}}
+	}
+	nicResponse, httpRes, err := s.tenant.Client().NicApi.CreateNic(s.tenant.ContextWithAuth(ctx)).CreateNicRequest(nicRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateNic", nicRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	nic, ok := nicResponse.GetNicOk()
+	if !ok {
+		return nil, errors.New("cannot create nic")
+	}
+	resourceIds := []string{nic.GetNicId()}
+	nicTagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.NameKey,
+			Value: nicName,
+		}, {
+			Key:   tag.ClusterKeyPrefix + clusterID,
+			Value: tag.OwnedValue,
+		}},
+	}
+	err = tag.AddTag(ctx, nicTagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return nic, nil
+}
+
+// DeleteNic deletes a NIC.
+func (s *Service) DeleteNic(ctx context.Context, nicId string) error {
+	deleteNicRequest := osc.DeleteNicRequest{NicId: nicId}
+	_, httpRes, err := s.tenant.Client().NicApi.DeleteNic(s.tenant.ContextWithAuth(ctx)).DeleteNicRequest(deleteNicRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteNic", deleteNicRequest, httpRes, err)
+	return err
+}
+
+// GetNic fetches a NIC by id.
+func (s *Service) GetNic(ctx context.Context, nicId string) (*osc.Nic, error) {
+	readNicsRequest := osc.ReadNicsRequest{
+		Filters: &osc.FiltersNic{
+			NicIds: &[]string{nicId},
+		},
+	}
+	resp, httpRes, err := s.tenant.Client().NicApi.ReadNics(s.tenant.ContextWithAuth(ctx)).ReadNicsRequest(readNicsRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadNics", readNicsRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	nics := resp.GetNics()
+	if len(nics) == 0 {
+		return nil, nil
+	}
+	return &nics[0], nil
+}
@@ -22,6 +22,7 @@ type OscSubnetInterface interface {
 	DeleteSubnet(ctx context.Context, subnetId string) error
 	GetSubnet(ctx context.Context, subnetId string) (*osc.Subnet, error)
 	GetSubnetFromNet(ctx context.Context, netId, ipRange string) (*osc.Subnet, error)
+	ListSubnets(ctx context.Context, subnetIds []string) ([]osc.Subnet, error)
 }
 
 // CreateSubnet create the subnet associate to the net
@@ -122,3 +123,18 @@ func (s *Service) GetSubnetFromNet(ctx context.Context, netId, ipRange string) (
 		return &subnet[0], nil
 	}
 }
+
+// ListSubnets fetches a list of subnets.
+func (s *Service) ListSubnets(ctx context.Context, subnetIds []string) ([]osc.Subnet, error) {
+	readSubnetsRequest := osc.ReadSubnetsRequest{
+		Filters: &osc.FiltersSubnet{
+			SubnetIds: &subnetIds,
+		},
+	}
+	readSubnetsResponse, httpRes, err := s.tenant.Client().SubnetApi.ReadSubnets(s.tenant.ContextWithAuth(ctx)).ReadSubnetsRequest(readSubnetsRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadSubnets", readSubnetsRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	return readSubnetsResponse.GetSubnets(), nil
+}
@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package net
+
+import (
+	"context"
+	"errors"
+
+	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+const vpnConnectionType = "ipsec.1"
+
+//go:generate ../../../bin/mockgen -destination mock_net/virtualgateway_mock.go -package mock_net -source ./virtualgateway.go
+type OscVirtualGatewayInterface interface {
+	CreateVirtualGateway(ctx context.Context, clusterID, name string) (*osc.VirtualGateway, error)
+	DeleteVirtualGateway(ctx context.Context, virtualGatewayId string) error
+	GetVirtualGateway(ctx context.Context, virtualGatewayId string) (*osc.VirtualGateway, error)
+	LinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error
+	UnlinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error
+	UpdateRoutePropagation(ctx context.Context, virtualGatewayId, routeTableId string, enable bool) error
+}
+
+// CreateVirtualGateway creates a virtual gateway.
+func (s *Service) CreateVirtualGateway(ctx context.Context, clusterID, name string) (*osc.VirtualGateway, error) {
+	req := osc.CreateVirtualGatewayRequest{
+		ConnectionType: vpnConnectionType,
+	}
+	resp, httpRes, err := s.tenant.Client().VirtualGatewayApi.CreateVirtualGateway(s.tenant.ContextWithAuth(ctx)).CreateVirtualGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateVirtualGateway", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	vgw, ok := resp.GetVirtualGatewayOk()
+	if !ok {
+		return nil, errors.New("cannot create virtual gateway")
+	}
+	resourceIds := []string{vgw.GetVirtualGatewayId()}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.NameKey,
+			Value: name,
+		}, {
+			Key:   tag.ClusterKeyPrefix + clusterID,
+			Value: tag.OwnedValue,
+		}},
+	}
+	err = tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return vgw, nil
+}
+
+// DeleteVirtualGateway deletes a virtual gateway.
+func (s *Service) DeleteVirtualGateway(ctx context.Context, virtualGatewayId string) error {
+	req := osc.DeleteVirtualGatewayRequest{VirtualGatewayId: virtualGatewayId}
+	_, httpRes, err := s.tenant.Client().VirtualGatewayApi.DeleteVirtualGateway(s.tenant.ContextWithAuth(ctx)).DeleteVirtualGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteVirtualGateway", req, httpRes, err)
+	return err
+}
+
+// GetVirtualGateway fetches a virtual gateway by id.
+func (s *Service) GetVirtualGateway(ctx context.Context, virtualGatewayId string) (*osc.VirtualGateway, error) {
+	req := osc.ReadVirtualGatewaysRequest{
+		Filters: &osc.FiltersVirtualGateway{
+			VirtualGatewayIds: &[]string{virtualGatewayId},
+		},
+	}
+	resp, httpRes, err := s.tenant.Client().VirtualGatewayApi.ReadVirtualGateways(s.tenant.ContextWithAuth(ctx)).ReadVirtualGatewaysRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadVirtualGateways", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	vgws := resp.GetVirtualGateways()
+	if len(vgws) == 0 {
+		return nil, nil
+	}
+	return &vgws[0], nil
+}
+
+// LinkVirtualGateway links a virtual gateway to a net.
+func (s *Service) LinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error {
+	req := osc.LinkVirtualGatewayRequest{
+		VirtualGatewayId: virtualGatewayId,
+		NetId:            netId,
+	}
+	_, httpRes, err := s.tenant.Client().VirtualGatewayApi.LinkVirtualGateway(s.tenant.ContextWithAuth(ctx)).LinkVirtualGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "LinkVirtualGateway", req, httpRes, err)
+	return err
+}
+
+// UnlinkVirtualGateway unlinks a virtual gateway from a net.
+func (s *Service) UnlinkVirtualGateway(ctx context.Context, virtualGatewayId, netId string) error {
+	req := osc.UnlinkVirtualGatewayRequest{
+		VirtualGatewayId: virtualGatewayId,
+		NetId:            netId,
+	}
+	_, httpRes, err := s.tenant.Client().VirtualGatewayApi.UnlinkVirtualGateway(s.tenant.ContextWithAuth(ctx)).UnlinkVirtualGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "UnlinkVirtualGateway", req, httpRes, err)
+	return err
+}
+
+// UpdateRoutePropagation enables or disables the propagation of virtual gateway routes to a route table.
+func (s *Service) UpdateRoutePropagation(ctx context.Context, virtualGatewayId, routeTableId string, enable bool) error {
+	req := osc.UpdateRoutePropagationRequest{
+		VirtualGatewayId: virtualGatewayId,
+		RouteTableId:     routeTableId,
+		Enable:           enable,
+	}
+	_, httpRes, err := s.tenant.Client().VirtualGatewayApi.UpdateRoutePropagation(s.tenant.ContextWithAuth(ctx)).UpdateRoutePropagationRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateRoutePropagation", req, httpRes, err)
+	return err
+}
@@ -0,0 +1,165 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package net
+
+import (
+	"context"
+	"errors"
+
+	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_net/vpn_mock.go -package mock_net -source ./vpn.go
+type OscVpnInterface interface {
+	CreateClientGateway(ctx context.Context, publicIp string, bgpAsn int32, clusterID, name string) (*osc.ClientGateway, error)
+	DeleteClientGateway(ctx context.Context, clientGatewayId string) error
+	GetClientGateway(ctx context.Context, clientGatewayId string) (*osc.ClientGateway, error)
+	CreateVpnConnection(ctx context.Context, clientGatewayId, virtualGatewayId string, staticRoutesOnly bool, clusterID, name string) (*osc.VpnConnection, error)
+	DeleteVpnConnection(ctx context.Context, vpnConnectionId string) error
+	GetVpnConnection(ctx context.Context, vpnConnectionId string) (*osc.VpnConnection, error)
+	CreateVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error
+	DeleteVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error
+}
+
+func (s *Service) tagVpnResource(ctx context.Context, id, clusterID, name string) error {
+	resourceIds := []string{id}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.NameKey,
+			Value: name,
+		}, {
+			Key:   tag.ClusterKeyPrefix + clusterID,
+			Value: tag.OwnedValue,
+		}},
+	}
+	return tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+}
+
+// CreateClientGateway creates a client gateway.
+func (s *Service) CreateClientGateway(ctx context.Context, publicIp string, bgpAsn int32, clusterID, name string) (*osc.ClientGateway, error) {
+	req := osc.CreateClientGatewayRequest{
+		BgpAsn:         bgpAsn,
+		ConnectionType: vpnConnectionType,
+		PublicIp:       publicIp,
+	}
+	resp, httpRes, err := s.tenant.Client().ClientGatewayApi.CreateClientGateway(s.tenant.ContextWithAuth(ctx)).CreateClientGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateClientGateway", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	cgw, ok := resp.GetClientGatewayOk()
+	if !ok {
+		return nil, errors.New("cannot create client gateway")
+	}
+	err = s.tagVpnResource(ctx, cgw.GetClientGatewayId(), clusterID, name)
+	if err != nil {
+		return nil, err
+	}
+	return cgw, nil
+}
+
+// DeleteClientGateway deletes a client gateway.
+func (s *Service) DeleteClientGateway(ctx context.Context, clientGatewayId string) error {
+	req := osc.DeleteClientGatewayRequest{ClientGatewayId: clientGatewayId}
+	_, httpRes, err := s.tenant.Client().ClientGatewayApi.DeleteClientGateway(s.tenant.ContextWithAuth(ctx)).DeleteClientGatewayRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteClientGateway", req, httpRes, err)
+	return err
+}
+
+// GetClientGateway fetches a client gateway by id.
+func (s *Service) GetClientGateway(ctx context.Context, clientGatewayId string) (*osc.ClientGateway, error) {
+	req := osc.ReadClientGatewaysRequest{
+		Filters: &osc.FiltersClientGateway{
+			ClientGatewayIds: &[]string{clientGatewayId},
+		},
+	}
+	resp, httpRes, err := s.tenant.Client().ClientGatewayApi.ReadClientGateways(s.tenant.ContextWithAuth(ctx)).ReadClientGatewaysRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadClientGateways", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	cgws := resp.GetClientGateways()
+	if len(cgws) == 0 {
+		return nil, nil
+	}
+	return &cgws[0], nil
+}
+
+// CreateVpnConnection creates a VPN connection between a client gateway and a virtual gateway.
+func (s *Service) CreateVpnConnection(ctx context.Context, clientGatewayId, virtualGatewayId string, staticRoutesOnly bool, clusterID, name string) (*osc.VpnConnection, error) {
+	req := osc.CreateVpnConnectionRequest{
+		ClientGatewayId:  clientGatewayId,
+		VirtualGatewayId: virtualGatewayId,
+		ConnectionType:   vpnConnectionType,
+		StaticRoutesOnly: &staticRoutesOnly,
+	}
+	resp, httpRes, err := s.tenant.Client().VpnConnectionApi.CreateVpnConnection(s.tenant.ContextWithAuth(ctx)).CreateVpnConnectionRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateVpnConnection", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	vpn, ok := resp.GetVpnConnectionOk()
+	if !ok {
+		return nil, errors.New("cannot create vpn connection")
+	}
+	err = s.tagVpnResource(ctx, vpn.GetVpnConnectionId(), clusterID, name)
+	if err != nil {
+		return nil, err
+	}
+	return vpn, nil
+}
+
+// DeleteVpnConnection deletes a VPN connection.
+func (s *Service) DeleteVpnConnection(ctx context.Context, vpnConnectionId string) error {
+	req := osc.DeleteVpnConnectionRequest{VpnConnectionId: vpnConnectionId}
+	_, httpRes, err := s.tenant.Client().VpnConnectionApi.DeleteVpnConnection(s.tenant.ContextWithAuth(ctx)).DeleteVpnConnectionRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteVpnConnection", req, httpRes, err)
+	return err
+}
+
+// GetVpnConnection fetches a VPN connection by id.
+func (s *Service) GetVpnConnection(ctx context.Context, vpnConnectionId string) (*osc.VpnConnection, error) {
+	req := osc.ReadVpnConnectionsRequest{
+		Filters: &osc.FiltersVpnConnection{
+			VpnConnectionIds: &[]string{vpnConnectionId},
+		},
+	}
+	resp, httpRes, err := s.tenant.Client().VpnConnectionApi.ReadVpnConnections(s.tenant.ContextWithAuth(ctx)).ReadVpnConnectionsRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "ReadVpnConnections", req, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	vpns := resp.GetVpnConnections()
+	if len(vpns) == 0 {
+		return nil, nil
+	}
+	return &vpns[0], nil
+}
+
+// CreateVpnConnectionRoute adds a static route to a VPN connection.
+func (s *Service) CreateVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error {
+	req := osc.CreateVpnConnectionRouteRequest{
+		VpnConnectionId:    vpnConnectionId,
+		DestinationIpRange: destinationIpRange,
+	}
+	_, httpRes, err := s.tenant.Client().VpnConnectionApi.CreateVpnConnectionRoute(s.tenant.ContextWithAuth(ctx)).CreateVpnConnectionRouteRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateVpnConnectionRoute", req, httpRes, err)
+	return err
+}
+
+// DeleteVpnConnectionRoute removes a static route from a VPN connection.
+func (s *Service) DeleteVpnConnectionRoute(ctx context.Context, vpnConnectionId, destinationIpRange string) error {
+	req := osc.DeleteVpnConnectionRouteRequest{
+		VpnConnectionId:    vpnConnectionId,
+		DestinationIpRange: destinationIpRange,
+	}
+	_, httpRes, err := s.tenant.Client().VpnConnectionApi.DeleteVpnConnectionRoute(s.tenant.ContextWithAuth(ctx)).DeleteVpnConnectionRouteRequest(req).Execute()
+	err = utils.LogAndExtractError(ctx, "DeleteVpnConnectionRoute", req, httpRes, err)
+	return err
+}
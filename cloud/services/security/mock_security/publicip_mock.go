@@ -100,6 +100,20 @@ func (mr *MockOscPublicIpInterfaceMockRecorder) GetPublicIpByIp(ctx, publicIp an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicIpByIp", reflect.TypeOf((*MockOscPublicIpInterface)(nil).GetPublicIpByIp), ctx, publicIp)
 }
 
+// LinkPublicIp mocks base method.
+func (m *MockOscPublicIpInterface) LinkPublicIp(ctx context.Context, publicIpId, vmId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkPublicIp", ctx, publicIpId, vmId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkPublicIp indicates an expected call of LinkPublicIp.
+func (mr *MockOscPublicIpInterfaceMockRecorder) LinkPublicIp(ctx, publicIpId, vmId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkPublicIp", reflect.TypeOf((*MockOscPublicIpInterface)(nil).LinkPublicIp), ctx, publicIpId, vmId)
+}
+
 // ListPublicIpsFromPool mocks base method.
 func (m *MockOscPublicIpInterface) ListPublicIpsFromPool(ctx context.Context, pool string) ([]osc.PublicIp, error) {
 	m.ctrl.T.Helper()
@@ -172,3 +172,17 @@ func (mr *MockOscRouteTableInterfaceMockRecorder) UnlinkRouteTable(ctx, linkRout
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkRouteTable", reflect.TypeOf((*MockOscRouteTableInterface)(nil).UnlinkRouteTable), ctx, linkRouteTableId)
 }
+
+// UpdateRoute mocks base method.
+func (m *MockOscRouteTableInterface) UpdateRoute(ctx context.Context, destinationIpRange, routeTableId, resourceId, resourceType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoute", ctx, destinationIpRange, routeTableId, resourceId, resourceType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoute indicates an expected call of UpdateRoute.
+func (mr *MockOscRouteTableInterfaceMockRecorder) UpdateRoute(ctx, destinationIpRange, routeTableId, resourceId, resourceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoute", reflect.TypeOf((*MockOscRouteTableInterface)(nil).UpdateRoute), ctx, destinationIpRange, routeTableId, resourceId, resourceType)
+}
@@ -42,6 +42,21 @@ func (m *MockOscSecurityGroupInterface) EXPECT() *MockOscSecurityGroupInterfaceM
 	return m.recorder
 }
 
+// CreateMachineSecurityGroup mocks base method.
+func (m *MockOscSecurityGroupInterface) CreateMachineSecurityGroup(ctx context.Context, netId, clusterID, machineUID, securityGroupName, securityGroupDescription string) (*osc.SecurityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMachineSecurityGroup", ctx, netId, clusterID, machineUID, securityGroupName, securityGroupDescription)
+	ret0, _ := ret[0].(*osc.SecurityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMachineSecurityGroup indicates an expected call of CreateMachineSecurityGroup.
+func (mr *MockOscSecurityGroupInterfaceMockRecorder) CreateMachineSecurityGroup(ctx, netId, clusterID, machineUID, securityGroupName, securityGroupDescription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMachineSecurityGroup", reflect.TypeOf((*MockOscSecurityGroupInterface)(nil).CreateMachineSecurityGroup), ctx, netId, clusterID, machineUID, securityGroupName, securityGroupDescription)
+}
+
 // CreateSecurityGroup mocks base method.
 func (m *MockOscSecurityGroupInterface) CreateSecurityGroup(ctx context.Context, netId, clusterID, securityGroupName, securityGroupDescription, securityGroupTag string, roles []v1beta1.OscRole) (*osc.SecurityGroup, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +87,20 @@ func (mr *MockOscSecurityGroupInterfaceMockRecorder) CreateSecurityGroupRule(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRule", reflect.TypeOf((*MockOscSecurityGroupInterface)(nil).CreateSecurityGroupRule), ctx, securityGroupId, flow, ipProtocol, ipRange, securityGroupMemberId, fromPortRange, toPortRange)
 }
 
+// CreateSecurityGroupRules mocks base method.
+func (m *MockOscSecurityGroupInterface) CreateSecurityGroupRules(ctx context.Context, securityGroupId, flow string, rules []osc.SecurityGroupRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityGroupRules", ctx, securityGroupId, flow, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecurityGroupRules indicates an expected call of CreateSecurityGroupRules.
+func (mr *MockOscSecurityGroupInterfaceMockRecorder) CreateSecurityGroupRules(ctx, securityGroupId, flow, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroupRules", reflect.TypeOf((*MockOscSecurityGroupInterface)(nil).CreateSecurityGroupRules), ctx, securityGroupId, flow, rules)
+}
+
 // DeleteSecurityGroup mocks base method.
 func (m *MockOscSecurityGroupInterface) DeleteSecurityGroup(ctx context.Context, securityGroupId string) error {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ type OscPublicIpInterface interface {
 	GetPublicIp(ctx context.Context, publicIpId string) (*osc.PublicIp, error)
 	GetPublicIpByIp(ctx context.Context, publicIp string) (*osc.PublicIp, error)
 	ListPublicIpsFromPool(ctx context.Context, pool string) ([]osc.PublicIp, error)
+	LinkPublicIp(ctx context.Context, publicIpId, vmId string) error
 }
 
 // CreatePublicIp retrieve a publicip associated with you account
@@ -174,3 +175,15 @@ func PoolName(ip *osc.PublicIp) string {
 	}
 	return ""
 }
+
+// LinkPublicIp links a public ip to a VM.
+func (s *Service) LinkPublicIp(ctx context.Context, publicIpId, vmId string) error {
+	linkPublicIpRequest := osc.LinkPublicIpRequest{
+		PublicIpId: &publicIpId,
+		VmId:       &vmId,
+	}
+
+	_, httpRes, err := s.tenant.Client().PublicIpApi.LinkPublicIp(s.tenant.ContextWithAuth(ctx)).LinkPublicIpRequest(linkPublicIpRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "LinkPublicIp", linkPublicIpRequest, httpRes, err)
+	return err
+}
@@ -23,6 +23,7 @@ import (
 type OscRouteTableInterface interface {
 	CreateRouteTable(ctx context.Context, netId string, clusterID string, routeTableName string) (*osc.RouteTable, error)
 	CreateRoute(ctx context.Context, destinationIpRange string, routeTableId string, resourceId string, resourceType string) (*osc.RouteTable, error)
+	UpdateRoute(ctx context.Context, destinationIpRange string, routeTableId string, resourceId string, resourceType string) error
 	DeleteRouteTable(ctx context.Context, routeTableId string) error
 	DeleteRoute(ctx context.Context, destinationIpRange string, routeTableId string) error
 	GetRouteTable(ctx context.Context, routeTableId string) (*osc.RouteTable, error)
@@ -90,6 +91,12 @@ func (s *Service) CreateRoute(ctx context.Context, destinationIpRange, routeTabl
 			RouteTableId:       routeTableId,
 			NetPeeringId:       &resourceId,
 		}
+	case "vm":
+		routeRequest = osc.CreateRouteRequest{
+			DestinationIpRange: destinationIpRange,
+			RouteTableId:       routeTableId,
+			VmId:               &resourceId,
+		}
 	default:
 		return nil, fmt.Errorf("invalid type %q", resourceType)
 	}
@@ -106,6 +113,30 @@ func (s *Service) CreateRoute(ctx context.Context, destinationIpRange, routeTabl
 	return route, nil
 }
 
+// UpdateRoute replaces the target of a route
+func (s *Service) UpdateRoute(ctx context.Context, destinationIpRange, routeTableId, resourceId string, resourceType string) error {
+	routeRequest := osc.UpdateRouteRequest{
+		DestinationIpRange: destinationIpRange,
+		RouteTableId:       routeTableId,
+	}
+	switch resourceType {
+	case "gateway":
+		routeRequest.GatewayId = &resourceId
+	case "nat":
+		routeRequest.NatServiceId = &resourceId
+	case "netPeering":
+		routeRequest.NetPeeringId = &resourceId
+	case "vm":
+		routeRequest.VmId = &resourceId
+	default:
+		return fmt.Errorf("invalid type %q", resourceType)
+	}
+
+	_, httpRes, err := s.tenant.Client().RouteApi.UpdateRoute(s.tenant.ContextWithAuth(ctx)).UpdateRouteRequest(routeRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "UpdateRoute", routeRequest, httpRes, err)
+	return err
+}
+
 // DeleteRouteTable delete the route table
 func (s *Service) DeleteRouteTable(ctx context.Context, routeTableId string) error {
 	deleteRouteTableRequest := osc.DeleteRouteTableRequest{RouteTableId: routeTableId}
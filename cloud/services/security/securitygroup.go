@@ -23,7 +23,9 @@ var ErrResourceConflict = errors.New("conflict with existing resource")
 
 type OscSecurityGroupInterface interface {
 	CreateSecurityGroup(ctx context.Context, netId, clusterID, securityGroupName, securityGroupDescription, securityGroupTag string, roles []infrastructurev1beta1.OscRole) (*osc.SecurityGroup, error)
+	CreateMachineSecurityGroup(ctx context.Context, netId, clusterID, machineUID, securityGroupName, securityGroupDescription string) (*osc.SecurityGroup, error)
 	CreateSecurityGroupRule(ctx context.Context, securityGroupId, flow, ipProtocol, ipRange, securityGroupMemberId string, fromPortRange int32, toPortRange int32) (*osc.SecurityGroup, error)
+	CreateSecurityGroupRules(ctx context.Context, securityGroupId, flow string, rules []osc.SecurityGroupRule) error
 	DeleteSecurityGroupRule(ctx context.Context, securityGroupId, flow, ipProtocol, ipRange, securityGroupMemberId string, fromPortRange int32, toPortRange int32) error
 	DeleteSecurityGroup(ctx context.Context, securityGroupId string) error
 	GetSecurityGroup(ctx context.Context, securityGroupId string) (*osc.SecurityGroup, error)
@@ -80,6 +82,40 @@ func (s *Service) CreateSecurityGroup(ctx context.Context, netId string, cluster
 	return securityGroup, nil
 }
 
+// CreateMachineSecurityGroup creates a securityGroup dedicated to a machine, tagged with the machine UID.
+func (s *Service) CreateMachineSecurityGroup(ctx context.Context, netId, clusterID, machineUID, securityGroupName, securityGroupDescription string) (*osc.SecurityGroup, error) {
+	securityGroupRequest := osc.CreateSecurityGroupRequest{
+		SecurityGroupName: securityGroupName,
+		Description:       securityGroupDescription,
+		NetId:             &netId,
+	}
+	securityGroupResponse, httpRes, err := s.tenant.Client().SecurityGroupApi.CreateSecurityGroup(s.tenant.ContextWithAuth(ctx)).CreateSecurityGroupRequest(securityGroupRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateSecurityGroup", securityGroupRequest, httpRes, err)
+	if err != nil {
+		return nil, err
+	}
+	securityGroup, ok := securityGroupResponse.GetSecurityGroupOk()
+	if !ok {
+		return nil, errors.New("cannot create securitygroup")
+	}
+	resourceIds := []string{securityGroup.GetSecurityGroupId()}
+	tagRequest := osc.CreateTagsRequest{
+		ResourceIds: resourceIds,
+		Tags: []osc.ResourceTag{{
+			Key:   tag.ClusterKeyPrefix + clusterID,
+			Value: tag.OwnedValue,
+		}, {
+			Key:   tag.MachineUIDKey,
+			Value: machineUID,
+		}},
+	}
+	err = tag.AddTag(ctx, tagRequest, resourceIds, s.tenant.Client(), s.tenant.ContextWithAuth(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return securityGroup, nil
+}
+
 // CreateSecurityGroupRule create the security group rule associated with the security group and the net
 func (s *Service) CreateSecurityGroupRule(ctx context.Context, securityGroupId string, flow string, ipProtocol string, ipRange string, securityGroupMemberId string, fromPortRange int32, toPortRange int32) (*osc.SecurityGroup, error) {
 	var rule osc.SecurityGroupRule
@@ -123,6 +159,18 @@ func (s *Service) CreateSecurityGroupRule(ctx context.Context, securityGroupId s
 	return securityGroupRule, nil
 }
 
+// CreateSecurityGroupRules creates multiple rules having the same flow in a single call.
+func (s *Service) CreateSecurityGroupRules(ctx context.Context, securityGroupId, flow string, rules []osc.SecurityGroupRule) error {
+	createSecurityGroupRuleRequest := osc.CreateSecurityGroupRuleRequest{
+		Flow:            flow,
+		SecurityGroupId: securityGroupId,
+		Rules:           &rules,
+	}
+	_, httpRes, err := s.tenant.Client().SecurityGroupRuleApi.CreateSecurityGroupRule(s.tenant.ContextWithAuth(ctx)).CreateSecurityGroupRuleRequest(createSecurityGroupRuleRequest).Execute()
+	err = utils.LogAndExtractError(ctx, "CreateSecurityGroupRule", createSecurityGroupRuleRequest, httpRes, err)
+	return err
+}
+
 // DeleteSecurityGroupRule delete the security group rule associated with the security group and the net
 func (s *Service) DeleteSecurityGroupRule(ctx context.Context, securityGroupId string, flow string, ipProtocol string, ipRange string, securityGroupMemberId string, fromPortRange int32, toPortRange int32) error {
 	var rule osc.SecurityGroupRule
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./object.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock_storage/object_mock.go -package mock_storage -source ./object.go
+//
+
+// Package mock_storage is a generated GoMock package.
+package mock_storage
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOscObjectStorageInterface is a mock of OscObjectStorageInterface interface.
+type MockOscObjectStorageInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOscObjectStorageInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOscObjectStorageInterfaceMockRecorder is the mock recorder for MockOscObjectStorageInterface.
+type MockOscObjectStorageInterfaceMockRecorder struct {
+	mock *MockOscObjectStorageInterface
+}
+
+// NewMockOscObjectStorageInterface creates a new mock instance.
+func NewMockOscObjectStorageInterface(ctrl *gomock.Controller) *MockOscObjectStorageInterface {
+	mock := &MockOscObjectStorageInterface{ctrl: ctrl}
+	mock.recorder = &MockOscObjectStorageInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOscObjectStorageInterface) EXPECT() *MockOscObjectStorageInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteObject mocks base method.
+func (m *MockOscObjectStorageInterface) DeleteObject(ctx context.Context, bucket, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, bucket, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockOscObjectStorageInterfaceMockRecorder) DeleteObject(ctx, bucket, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockOscObjectStorageInterface)(nil).DeleteObject), ctx, bucket, key)
+}
+
+// PresignGetObject mocks base method.
+func (m *MockOscObjectStorageInterface) PresignGetObject(ctx context.Context, bucket, key string, expiration time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignGetObject", ctx, bucket, key, expiration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignGetObject indicates an expected call of PresignGetObject.
+func (mr *MockOscObjectStorageInterfaceMockRecorder) PresignGetObject(ctx, bucket, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignGetObject", reflect.TypeOf((*MockOscObjectStorageInterface)(nil).PresignGetObject), ctx, bucket, key, expiration)
+}
+
+// PutObject mocks base method.
+func (m *MockOscObjectStorageInterface) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, bucket, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockOscObjectStorageInterfaceMockRecorder) PutObject(ctx, bucket, key, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockOscObjectStorageInterface)(nil).PutObject), ctx, bucket, key, data)
+}
@@ -0,0 +1,102 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"             //nolint
+	"github.com/aws/aws-sdk-go/aws/credentials" //nolint
+	"github.com/aws/aws-sdk-go/aws/session"     //nolint
+	"github.com/aws/aws-sdk-go/service/s3"      //nolint
+	osc "github.com/outscale/osc-sdk-go/v2"
+)
+
+//go:generate ../../../bin/mockgen -destination mock_storage/object_mock.go -package mock_storage -source ./object.go
+type OscObjectStorageInterface interface {
+	PutObject(ctx context.Context, bucket, key string, data []byte) error
+	PresignGetObject(ctx context.Context, bucket, key string, expiration time.Duration) (string, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// Endpoint returns the OOS endpoint of the service.
+func (s *Service) Endpoint() string {
+	if s.endpoint != "" {
+		return s.endpoint
+	}
+	return "https://oos." + s.tenant.Region() + ".outscale.com"
+}
+
+func (s *Service) client(ctx context.Context) (*s3.S3, error) {
+	creds, ok := s.tenant.ContextWithAuth(ctx).Value(osc.ContextAWSv4).(osc.AWSv4)
+	if !ok {
+		return nil, errors.New("OOS requires an access key")
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials(creds.AccessKey, creds.SecretKey, ""),
+		Region:           aws.String(s.tenant.Region()),
+		Endpoint:         aws.String(s.Endpoint()),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OOS session: %w", err)
+	}
+	return s3.New(sess), nil
+}
+
+// PutObject uploads an object.
+func (s *Service) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	client, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot put object %s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// PresignGetObject returns a presigned URL to download an object, valid for expiration.
+func (s *Service) PresignGetObject(ctx context.Context, bucket, key string, expiration time.Duration) (string, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	req, _ := client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+	})
+	url, err := req.Presign(expiration)
+	if err != nil {
+		return "", fmt.Errorf("cannot presign object %s/%s: %w", bucket, key, err)
+	}
+	return url, nil
+}
+
+// DeleteObject deletes an object. Deleting a missing object is not an error.
+func (s *Service) DeleteObject(ctx context.Context, bucket, key string) error {
+	client, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete object %s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
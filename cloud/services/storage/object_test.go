@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTenant struct {
+	tenant.Tenant
+}
+
+func (testTenant) Region() string {
+	return "eu-west-2"
+}
+
+func (testTenant) ContextWithAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, osc.ContextAWSv4, osc.AWSv4{AccessKey: "foo", SecretKey: "bar"})
+}
+
+// fakeS3 is a minimal local S3 stand-in, storing objects in memory.
+// Requests must be signed, either by header or by a presigned URL.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential=foo/") &&
+		!strings.HasPrefix(r.URL.Query().Get("X-Amz-Credential"), "foo/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, found := s.objects[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestObjectStorage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	svc := NewService(testTenant{}, srv.URL)
+	ctx := context.TODO()
+
+	err := svc.PutObject(ctx, "bootstrap", "ns/foo", []byte("#cloud-config\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("#cloud-config\n"), fake.objects["/bootstrap/ns/foo"])
+
+	url, err := svc.PresignGetObject(ctx, "bootstrap", "ns/foo", 10*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, srv.URL+"/bootstrap/ns/foo?"))
+	assert.Contains(t, url, "X-Amz-Expires=600")
+	resp, err := http.Get(url) //nolint:gosec,noctx
+	require.NoError(t, err)
+	data, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "#cloud-config\n", string(data))
+
+	err = svc.DeleteObject(ctx, "bootstrap", "ns/foo")
+	require.NoError(t, err)
+	assert.Empty(t, fake.objects)
+	err = svc.DeleteObject(ctx, "bootstrap", "ns/foo")
+	require.NoError(t, err)
+}
+
+func TestObjectStorage_DefaultEndpoint(t *testing.T) {
+	assert.Equal(t, "https://oos.eu-west-2.outscale.com", NewService(testTenant{}, "").Endpoint())
+}
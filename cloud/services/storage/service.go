@@ -0,0 +1,25 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package storage
+
+import (
+	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
+)
+
+// Service is a collection of interfaces
+type Service struct {
+	tenant   tenant.Tenant
+	endpoint string
+}
+
+// NewService return a service which is based on the OOS API.
+// The default regional endpoint is used if endpoint is empty.
+func NewService(t tenant.Tenant, endpoint string) *Service {
+	return &Service{
+		tenant:   t,
+		endpoint: endpoint,
+	}
+}
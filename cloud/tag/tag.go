@@ -30,6 +30,11 @@ const (
 	RouteTableResourceType      ResourceType = "route-table"
 	SecurityGroupResourceType   ResourceType = "security-group"
 	PublicIPResourceType        ResourceType = "public-ip"
+	NicResourceType             ResourceType = "network-interface"
+	VirtualGatewayResourceType  ResourceType = "virtual-private-gateway"
+	ClientGatewayResourceType   ResourceType = "customer-gateway"
+	VpnConnectionResourceType   ResourceType = "vpn-connection"
+	DhcpOptionsResourceType     ResourceType = "dhcp-options"
 )
 
 const (
@@ -37,6 +42,8 @@ const (
 
 	ClusterKeyPrefix = "OscK8sClusterID/"
 	OwnedValue       = "owned"
+
+	MachineUIDKey = "OscK8sMachineUID"
 )
 
 //go:generate ../../bin/mockgen -destination mock_tag/tag_mock.go -package mock_tag -source ./tag.go
@@ -0,0 +1,70 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package utils
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// CompactCIDRs merges overlapping and adjacent CIDRs into a minimal list.
+// The list is returned unchanged if no CIDR can be merged, and invalid CIDRs are kept as is.
+func CompactCIDRs(cidrs []string) []string {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	var invalid []string
+	for _, cidr := range cidrs {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			invalid = append(invalid, cidr)
+			continue
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	slices.SortFunc(prefixes, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+	stack := make([]netip.Prefix, 0, len(prefixes))
+	for _, p := range prefixes {
+		if len(stack) > 0 && contains(stack[len(stack)-1], p) {
+			continue
+		}
+		stack = append(stack, p)
+		for len(stack) >= 2 {
+			parent, ok := mergeSiblings(stack[len(stack)-2], stack[len(stack)-1])
+			if !ok {
+				break
+			}
+			stack = append(stack[:len(stack)-2], parent)
+		}
+	}
+	if len(stack)+len(invalid) == len(cidrs) {
+		return cidrs
+	}
+	out := make([]string, 0, len(stack)+len(invalid))
+	for _, p := range stack {
+		out = append(out, p.String())
+	}
+	return append(out, invalid...)
+}
+
+func contains(a, b netip.Prefix) bool {
+	return a.Bits() <= b.Bits() && a.Contains(b.Addr())
+}
+
+// mergeSiblings returns the parent of a and b if they are the two halves of the same prefix.
+func mergeSiblings(a, b netip.Prefix) (netip.Prefix, bool) {
+	if a.Bits() != b.Bits() || a.Bits() == 0 || a == b || a.Addr().Is4() != b.Addr().Is4() {
+		return netip.Prefix{}, false
+	}
+	parent := netip.PrefixFrom(a.Addr(), a.Bits()-1).Masked()
+	if parent.Addr() != a.Addr() || !parent.Contains(b.Addr()) {
+		return netip.Prefix{}, false
+	}
+	return parent, true
+}
@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactCIDRs(t *testing.T) {
+	tcs := []struct {
+		name     string
+		in       []string
+		expected []string
+	}{{
+		name:     "distinct ranges are kept in order",
+		in:       []string{"10.0.2.0/24", "10.0.0.0/24"},
+		expected: []string{"10.0.2.0/24", "10.0.0.0/24"},
+	}, {
+		name:     "adjacent ranges are merged",
+		in:       []string{"10.0.1.0/24", "10.0.0.0/24", "10.0.2.0/23"},
+		expected: []string{"10.0.0.0/22"},
+	}, {
+		name:     "overlapping ranges are merged",
+		in:       []string{"10.0.0.0/16", "10.0.3.0/24", "1.2.3.4/32", "1.2.3.4/32"},
+		expected: []string{"1.2.3.4/32", "10.0.0.0/16"},
+	}, {
+		name:     "unaligned neighbours are not merged",
+		in:       []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.4.0/24"},
+		expected: []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.4.0/24"},
+	}, {
+		name:     "invalid ranges are kept",
+		in:       []string{"10.0.1.0/24", "foo", "10.0.0.0/24"},
+		expected: []string{"10.0.0.0/23", "foo"},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CompactCIDRs(tc.in))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"unicode/utf8"
+)
+
+// UserDataPart is a part of a cloud-init MIME multipart user data.
+type UserDataPart struct {
+	ContentType string
+	// MergeType is the cloud-init merge type of a cloud-config part.
+	MergeType string
+	Content   string
+}
+
+// NewUserDataPart returns a part, with a content type detected from its content.
+func NewUserDataPart(content string) UserDataPart {
+	part := UserDataPart{Content: content}
+	switch {
+	case strings.HasPrefix(content, "\x1f\x8b"):
+		part.ContentType = "application/x-gzip"
+	case strings.HasPrefix(content, "#cloud-config"):
+		part.ContentType = "text/cloud-config"
+	case strings.HasPrefix(content, "#!"):
+		part.ContentType = "text/x-shellscript"
+	case strings.HasPrefix(content, "#cloud-boothook"):
+		part.ContentType = "text/cloud-boothook"
+	case strings.HasPrefix(content, "Content-Type:") || strings.HasPrefix(content, "MIME-Version:"):
+		// Already a MIME document, its headers are reused for the part.
+		r := textproto.NewReader(bufio.NewReader(strings.NewReader(content)))
+		header, err := r.ReadMIMEHeader()
+		if err != nil || header.Get("Content-Type") == "" {
+			part.ContentType = "text/plain"
+			break
+		}
+		part.ContentType = header.Get("Content-Type")
+		body := &strings.Builder{}
+		_, _ = r.R.WriteTo(body)
+		part.Content = body.String()
+	default:
+		part.ContentType = "text/plain"
+	}
+	return part
+}
+
+// MergeUserData merges parts into a MIME multipart user data, processed by cloud-init in order.
+func MergeUserData(parts ...UserDataPart) (string, error) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	_, _ = fmt.Fprintf(buf, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", w.Boundary())
+	for i, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.ContentType)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"part-%03d\"", i+1))
+		if part.MergeType != "" {
+			header.Set("Merge-Type", part.MergeType)
+		}
+		content := part.Content
+		if !utf8.ValidString(content) {
+			// Binary parts (e.g. gzip'ed ones) are base64 encoded.
+			header.Set("Content-Transfer-Encoding", "base64")
+			content = wrapLines(base64.StdEncoding.EncodeToString([]byte(content)), 76)
+		}
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("cannot create user data part: %w", err)
+		}
+		if _, err := pw.Write([]byte(content)); err != nil {
+			return "", fmt.Errorf("cannot write user data part: %w", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("cannot close user data: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func wrapLines(s string, width int) string {
+	b := &strings.Builder{}
+	for len(s) > width {
+		b.WriteString(s[:width])
+		b.WriteString("\r\n")
+		s = s[width:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
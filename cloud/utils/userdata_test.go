@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserDataPart(t *testing.T) {
+	assert.Equal(t, "text/cloud-config", NewUserDataPart("#cloud-config\n").ContentType)
+	assert.Equal(t, "text/x-shellscript", NewUserDataPart("#!/bin/sh\n").ContentType)
+	assert.Equal(t, "text/plain", NewUserDataPart("foo").ContentType)
+
+	part := NewUserDataPart("Content-Type: multipart/mixed; boundary=\"foo\"\nMIME-Version: 1.0\n\n--foo\n--foo--\n")
+	assert.Equal(t, "multipart/mixed; boundary=\"foo\"", part.ContentType)
+	assert.Equal(t, "--foo\n--foo--\n", part.Content)
+}
+
+func TestMergeUserData(t *testing.T) {
+	userData, err := MergeUserData(
+		NewUserDataPart("#cloud-config\nruncmd:\n- foo\n"),
+		UserDataPart{ContentType: "text/cloud-config", MergeType: "list(append)", Content: "#cloud-config\nruncmd:\n- bar\n"},
+	)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(userData))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/cloud-config", part.Header.Get("Content-Type"))
+	assert.Empty(t, part.Header.Get("Merge-Type"))
+	buf, err := io.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, "#cloud-config\nruncmd:\n- foo\n", string(buf))
+
+	part, err = r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "list(append)", part.Header.Get("Merge-Type"))
+	buf, err = io.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, "#cloud-config\nruncmd:\n- bar\n", string(buf))
+
+	_, err = r.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestMergeUserData_Gzip(t *testing.T) {
+	gz := &bytes.Buffer{}
+	w := gzip.NewWriter(gz)
+	_, _ = w.Write([]byte("#cloud-config\n"))
+	require.NoError(t, w.Close())
+	bootstrap := NewUserDataPart(gz.String())
+	assert.Equal(t, "application/x-gzip", bootstrap.ContentType)
+
+	userData, err := MergeUserData(bootstrap)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(userData))
+	require.NoError(t, err)
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	part, err := multipart.NewReader(msg.Body, params["boundary"]).NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "base64", part.Header.Get("Content-Transfer-Encoding"))
+	buf, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+	require.NoError(t, err)
+	assert.Equal(t, gz.Bytes(), buf)
+}
@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/account"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/account/mock_account"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute/mock_compute"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/loadbalancer"
@@ -21,6 +23,8 @@ import (
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/net/mock_net"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/security"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/security/mock_security"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/storage"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/storage/mock_storage"
 	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tag/mock_tag"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
@@ -62,6 +66,11 @@ type MockCloudServices struct {
 	NetPeeringMock     *mock_net.MockOscNetPeeringInterface
 	NetAccessPointMock *mock_net.MockOscNetAccessPointInterface
 	SubnetMock         *mock_net.MockOscSubnetInterface
+	NicMock            *mock_net.MockOscNicInterface
+	VirtualGatewayMock *mock_net.MockOscVirtualGatewayInterface
+	VpnMock            *mock_net.MockOscVpnInterface
+	DirectLinkMock     *mock_net.MockOscDirectLinkInterface
+	DhcpOptionsMock    *mock_net.MockOscDhcpOptionsInterface
 	SecurityGroupMock  *mock_security.MockOscSecurityGroupInterface
 
 	InternetServiceMock *mock_net.MockOscInternetServiceInterface
@@ -74,6 +83,10 @@ type MockCloudServices struct {
 	ImageMock *mock_compute.MockOscImageInterface
 
 	TagMock *mock_tag.MockOscTagInterface
+
+	ObjectStorageMock *mock_storage.MockOscObjectStorageInterface
+
+	AccountMock *mock_account.MockOscAccountInterface
 }
 
 func newMockCloudServices(mockCtrl *gomock.Controller, region string) *MockCloudServices {
@@ -84,6 +97,11 @@ func newMockCloudServices(mockCtrl *gomock.Controller, region string) *MockCloud
 		NetPeeringMock:     mock_net.NewMockOscNetPeeringInterface(mockCtrl),
 		NetAccessPointMock: mock_net.NewMockOscNetAccessPointInterface(mockCtrl),
 		SubnetMock:         mock_net.NewMockOscSubnetInterface(mockCtrl),
+		NicMock:            mock_net.NewMockOscNicInterface(mockCtrl),
+		VirtualGatewayMock: mock_net.NewMockOscVirtualGatewayInterface(mockCtrl),
+		VpnMock:            mock_net.NewMockOscVpnInterface(mockCtrl),
+		DirectLinkMock:     mock_net.NewMockOscDirectLinkInterface(mockCtrl),
+		DhcpOptionsMock:    mock_net.NewMockOscDhcpOptionsInterface(mockCtrl),
 		SecurityGroupMock:  mock_security.NewMockOscSecurityGroupInterface(mockCtrl),
 
 		InternetServiceMock: mock_net.NewMockOscInternetServiceInterface(mockCtrl),
@@ -96,6 +114,10 @@ func newMockCloudServices(mockCtrl *gomock.Controller, region string) *MockCloud
 		ImageMock: mock_compute.NewMockOscImageInterface(mockCtrl),
 
 		TagMock: mock_tag.NewMockOscTagInterface(mockCtrl),
+
+		ObjectStorageMock: mock_storage.NewMockOscObjectStorageInterface(mockCtrl),
+
+		AccountMock: mock_account.NewMockOscAccountInterface(mockCtrl),
 	}
 }
 
@@ -123,9 +145,57 @@ func (s *MockCloudServices) Subnet(t tenant.Tenant) net.OscSubnetInterface {
 	return s.SubnetMock
 }
 
+func (s *MockCloudServices) Nic(t tenant.Tenant) net.OscNicInterface {
+	s.tenant = t
+	return s.NicMock
+}
+
+func (s *MockCloudServices) VirtualGateway(t tenant.Tenant) net.OscVirtualGatewayInterface {
+	s.tenant = t
+	return s.VirtualGatewayMock
+}
+
+func (s *MockCloudServices) Vpn(t tenant.Tenant) net.OscVpnInterface {
+	s.tenant = t
+	return s.VpnMock
+}
+
+func (s *MockCloudServices) DirectLink(t tenant.Tenant) net.OscDirectLinkInterface {
+	s.tenant = t
+	return s.DirectLinkMock
+}
+
+func (s *MockCloudServices) DhcpOptions(t tenant.Tenant) net.OscDhcpOptionsInterface {
+	s.tenant = t
+	return s.DhcpOptionsMock
+}
+
 func (s *MockCloudServices) SecurityGroup(t tenant.Tenant) security.OscSecurityGroupInterface {
 	s.tenant = t
-	return s.SecurityGroupMock
+	return unbatchedSecurityGroupMock{s.SecurityGroupMock}
+}
+
+// unbatchedSecurityGroupMock splits batched rule creations, allowing expectations to be set for each rule.
+type unbatchedSecurityGroupMock struct {
+	*mock_security.MockOscSecurityGroupInterface
+}
+
+func (m unbatchedSecurityGroupMock) CreateSecurityGroupRules(ctx context.Context, securityGroupId, flow string, rules []osc.SecurityGroupRule) error {
+	for _, rule := range rules {
+		for _, ipRange := range rule.GetIpRanges() {
+			_, err := m.CreateSecurityGroupRule(ctx, securityGroupId, flow, rule.GetIpProtocol(), ipRange, "", rule.GetFromPortRange(), rule.GetToPortRange())
+			if err != nil {
+				return err
+			}
+		}
+		for _, member := range rule.GetSecurityGroupsMembers() {
+			_, err := m.CreateSecurityGroupRule(ctx, securityGroupId, flow, rule.GetIpProtocol(), "", member.GetSecurityGroupId(), rule.GetFromPortRange(), rule.GetToPortRange())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (s *MockCloudServices) InternetService(t tenant.Tenant) net.OscInternetServiceInterface {
@@ -168,6 +238,16 @@ func (s *MockCloudServices) Tag(t tenant.Tenant) tag.OscTagInterface {
 	return s.TagMock
 }
 
+func (s *MockCloudServices) ObjectStorage(t tenant.Tenant, endpoint string) storage.OscObjectStorageInterface {
+	s.tenant = t
+	return s.ObjectStorageMock
+}
+
+func (s *MockCloudServices) Account(t tenant.Tenant) account.OscAccountInterface {
+	s.tenant = t
+	return s.AccountMock
+}
+
 type patchOSCClusterFunc func(m *v1beta1.OscCluster)
 type patchOSCMachineFunc func(m *v1beta1.OscMachine)
 
@@ -176,6 +256,7 @@ type mockFunc func(s *MockCloudServices)
 type assertOSCMachineFunc func(t *testing.T, m *v1beta1.OscMachine)
 type assertOSCClusterFunc func(t *testing.T, c *v1beta1.OscCluster)
 type assertTenantFunc func(t *testing.T, tnt tenant.Tenant)
+type assertKubeFunc func(t *testing.T, c client.Client)
 
 type testcase struct {
 	name                             string
@@ -186,12 +267,14 @@ type testcase struct {
 	machinePatches                   []patchOSCMachineFunc
 	mockFuncs                        []mockFunc
 	kubeObjects                      []client.Object
+	bootstrapData, bootstrapFormat   string
 	hasError                         bool
 	requeue                          bool
 	assertDeleted                    bool
 	clusterAsserts                   []assertOSCClusterFunc
 	machineAsserts                   []assertOSCMachineFunc
 	tenantAsserts                    []assertTenantFunc
+	kubeAsserts                      []assertKubeFunc
 
 	next *testcase
 }
@@ -226,6 +309,12 @@ func loadMachineSpecs(t *testing.T, spec, base string) (*clusterv1.Machine, *v1b
 	return &machine, &oscmachine
 }
 
+// machineObjects returns a Machine and its OscMachine, to be loaded in the fake client.
+func machineObjects(t *testing.T, spec, base string) []client.Object {
+	machine, oscMachine := loadMachineSpecs(t, spec, base)
+	return []client.Object{machine, oscMachine}
+}
+
 func mockReadTagByNameNoneFound(typ tag.ResourceType, name string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.TagMock.EXPECT().
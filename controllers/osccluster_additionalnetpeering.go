@@ -0,0 +1,257 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// hasAdditionalNetPeerings returns true if additional net peerings are defined in the spec or still exist.
+func hasAdditionalNetPeerings(clusterScope *scope.ClusterScope) bool {
+	if len(clusterScope.GetNetwork().AdditionalNetPeerings) > 0 {
+		return true
+	}
+	for name := range clusterScope.GetResources().NetPeering {
+		if name != defaultResource {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileAdditionalNetPeering creates and accepts an additional net peering.
+func (r *OscClusterReconciler) reconcileAdditionalNetPeering(ctx context.Context, clusterScope *scope.ClusterScope, spec infrastructurev1beta1.OscAdditionalNetPeering, netId string) (*osc.NetPeering, error) {
+	log := ctrl.LoggerFrom(ctx)
+	np, err := r.Tracker.getAdditionalNetPeering(ctx, spec, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return nil, fmt.Errorf("get existing: %w", err)
+	case np.State.GetName() == "active":
+		log.V(4).Info("Found active netPeering", "name", spec.Name, "netPeeringId", np.GetNetPeeringId())
+		return np, nil
+	}
+	if np == nil || np.State.GetName() != "pending-acceptance" {
+		log.V(3).Info("Creating netPeering", "name", spec.Name, "peerNetId", spec.NetId)
+		np, err = r.Cloud.NetPeering(clusterScope.Tenant).CreateNamedNetPeering(ctx, netId, spec.NetId, spec.AccountId, clusterScope.GetAdditionalNetPeeringName(spec))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create netPeering: %w", err)
+		}
+		log.V(2).Info("Created netPeering", "name", spec.Name, "netPeeringId", np.GetNetPeeringId())
+		r.Tracker.setAdditionalNetPeeringId(clusterScope, spec, np.GetNetPeeringId())
+		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.NetPeeringCreatedReason, "NetPeering %s created", spec.Name)
+	}
+	status := &clusterScope.OscCluster.Status
+	if slices.Contains(status.AcceptedNetPeerings, np.GetNetPeeringId()) {
+		log.V(3).Info("NetPeering already accepted, waiting for it to be active", "name", spec.Name, "netPeeringId", np.GetNetPeeringId())
+		return np, nil
+	}
+	peer, err := getAdditionalNetPeeringTenant(ctx, r.Client, clusterScope, spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get peer credentials: %w", err)
+	}
+	log.V(2).Info("Accepting netPeering", "name", spec.Name, "netPeeringId", np.GetNetPeeringId())
+	err = r.Cloud.NetPeering(peer).AcceptNetPeering(ctx, np.GetNetPeeringId())
+	if err != nil {
+		return nil, fmt.Errorf("cannot accept netPeering: %w", err)
+	}
+	// Only the net peerings still tracked are kept.
+	tracked := slices.Collect(maps.Values(clusterScope.GetResources().NetPeering))
+	status.AcceptedNetPeerings = slices.DeleteFunc(status.AcceptedNetPeerings, func(id string) bool { return !slices.Contains(tracked, id) })
+	status.AcceptedNetPeerings = append(status.AcceptedNetPeerings, np.GetNetPeeringId())
+	r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.NetPeeringCreatedReason, "NetPeering %s accepted", spec.Name)
+	return np, nil
+}
+
+// getRouteTablesWithRoles returns the route tables linked to a subnet having one of the roles, or all route tables if no role is set.
+func (r *OscClusterReconciler) getRouteTablesWithRoles(ctx context.Context, clusterScope *scope.ClusterScope, rtbls []osc.RouteTable, roles []infrastructurev1beta1.OscRole) ([]osc.RouteTable, error) {
+	if len(roles) == 0 {
+		return rtbls, nil
+	}
+	subnetIds := map[string]bool{}
+	for _, subnet := range clusterScope.GetSubnets() {
+		if !slices.ContainsFunc(roles, func(role infrastructurev1beta1.OscRole) bool {
+			return clusterScope.SubnetHasRole(subnet, role)
+		}) {
+			continue
+		}
+		id, err := r.Tracker.getSubnetId(ctx, subnet, clusterScope)
+		if err != nil {
+			return nil, fmt.Errorf("get subnet: %w", err)
+		}
+		subnetIds[id] = true
+	}
+	var selected []osc.RouteTable
+	for _, rtbl := range rtbls {
+		if slices.ContainsFunc(rtbl.GetLinkRouteTables(), func(l osc.LinkRouteTable) bool {
+			return subnetIds[l.GetSubnetId()]
+		}) {
+			selected = append(selected, rtbl)
+		}
+	}
+	return selected, nil
+}
+
+// reconcileAdditionalNetPeeringRoutes adds routes to the destination ranges of a net peering, and removes routes to ranges no longer listed.
+func (r *OscClusterReconciler) reconcileAdditionalNetPeeringRoutes(ctx context.Context, clusterScope *scope.ClusterScope, spec infrastructurev1beta1.OscAdditionalNetPeering,
+	npId string, rtbls []osc.RouteTable) error {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	selected, err := r.getRouteTablesWithRoles(ctx, clusterScope, rtbls, spec.RouteTableRoles)
+	if err != nil {
+		return err
+	}
+	for _, rtbl := range rtbls {
+		var ranges []string
+		if slices.ContainsFunc(selected, func(s osc.RouteTable) bool { return s.GetRouteTableId() == rtbl.GetRouteTableId() }) {
+			ranges = spec.DestinationIpRanges
+		}
+		for _, ipRange := range ranges {
+			if slices.ContainsFunc(rtbl.GetRoutes(), func(route osc.Route) bool {
+				return route.GetDestinationIpRange() == ipRange && route.GetNetPeeringId() == npId
+			}) {
+				continue
+			}
+			log.V(3).Info("Creating route to netPeering", "name", spec.Name, "routeTableId", rtbl.GetRouteTableId(), "IPRange", ipRange)
+			_, err := svc.CreateRoute(ctx, ipRange, rtbl.GetRouteTableId(), npId, "netPeering")
+			if err != nil {
+				return fmt.Errorf("cannot create route: %w", err)
+			}
+		}
+		for _, route := range rtbl.GetRoutes() {
+			if route.GetNetPeeringId() != npId || slices.Contains(ranges, route.GetDestinationIpRange()) {
+				continue
+			}
+			log.V(3).Info("Deleting route to netPeering", "name", spec.Name, "routeTableId", rtbl.GetRouteTableId(), "IPRange", route.GetDestinationIpRange())
+			err := svc.DeleteRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId())
+			if err != nil {
+				return fmt.Errorf("cannot delete route: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileAdditionalNetPeerings reconciles the additional net peerings and their routes. Each net peering has its own condition.
+func (r *OscClusterReconciler) reconcileAdditionalNetPeerings(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerAdditionalNetPeerings) {
+		log.V(4).Info("No need for additional netPeerings reconciliation")
+		return reconcile.Result{}, nil
+	}
+	log.V(4).Info("Reconciling additional netPeerings")
+
+	netId, err := r.Tracker.getNetId(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	rtbls, err := r.Cloud.RouteTable(clusterScope.Tenant).GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("list route tables: %w", err)
+	}
+	var errs []error
+	for _, spec := range clusterScope.GetNetwork().AdditionalNetPeerings {
+		cond := infrastructurev1beta1.AdditionalNetPeeringReadyCondition(spec.Name)
+		np, err := r.reconcileAdditionalNetPeering(ctx, clusterScope, spec, netId)
+		if err == nil {
+			err = r.reconcileAdditionalNetPeeringRoutes(ctx, clusterScope, spec, np.GetNetPeeringId(), rtbls)
+		}
+		if err != nil {
+			conditions.MarkFalse(clusterScope.OscCluster, cond, infrastructurev1beta1.NetPeeringReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			errs = append(errs, fmt.Errorf("netPeering %s: %w", spec.Name, err))
+			continue
+		}
+		conditions.MarkTrue(clusterScope.OscCluster, cond)
+	}
+	if len(errs) > 0 {
+		return reconcile.Result{}, errors.Join(errs...)
+	}
+	err = r.deleteRemovedAdditionalNetPeerings(ctx, clusterScope, rtbls)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerAdditionalNetPeerings)
+	return reconcile.Result{}, nil
+}
+
+// deleteAdditionalNetPeering deletes an additional net peering, and its routes if route tables are listed.
+func (r *OscClusterReconciler) deleteAdditionalNetPeering(ctx context.Context, clusterScope *scope.ClusterScope, spec infrastructurev1beta1.OscAdditionalNetPeering, rtbls []osc.RouteTable) error {
+	log := ctrl.LoggerFrom(ctx)
+	np, err := r.Tracker.getAdditionalNetPeering(ctx, spec, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		delete(clusterScope.GetResources().NetPeering, spec.Name)
+		return nil
+	case err != nil:
+		return fmt.Errorf("get netPeering %s: %w", spec.Name, err)
+	}
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	for _, rtbl := range rtbls {
+		for _, route := range rtbl.GetRoutes() {
+			if route.GetNetPeeringId() != np.GetNetPeeringId() {
+				continue
+			}
+			log.V(3).Info("Deleting route to netPeering", "name", spec.Name, "routeTableId", rtbl.GetRouteTableId(), "IPRange", route.GetDestinationIpRange())
+			err := svc.DeleteRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId())
+			if err != nil {
+				return fmt.Errorf("cannot delete route: %w", err)
+			}
+		}
+	}
+	if np.State.GetName() == "pending-acceptance" || np.State.GetName() == "active" {
+		log.V(2).Info("Deleting netPeering", "name", spec.Name, "netPeeringId", np.GetNetPeeringId())
+		err = r.Cloud.NetPeering(clusterScope.Tenant).DeleteNetPeering(ctx, np.GetNetPeeringId())
+		if err != nil {
+			return fmt.Errorf("cannot delete netPeering %s: %w", spec.Name, err)
+		}
+	}
+	delete(clusterScope.GetResources().NetPeering, spec.Name)
+	return nil
+}
+
+// deleteRemovedAdditionalNetPeerings deletes the additional net peerings having been removed from the spec.
+func (r *OscClusterReconciler) deleteRemovedAdditionalNetPeerings(ctx context.Context, clusterScope *scope.ClusterScope, rtbls []osc.RouteTable) error {
+	inSpec := map[string]bool{defaultResource: true}
+	for _, spec := range clusterScope.GetNetwork().AdditionalNetPeerings {
+		inSpec[spec.Name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(clusterScope.GetResources().NetPeering)) {
+		if inSpec[name] {
+			continue
+		}
+		err := r.deleteAdditionalNetPeering(ctx, clusterScope, infrastructurev1beta1.OscAdditionalNetPeering{Name: name}, rtbls)
+		if err != nil {
+			return err
+		}
+		conditions.Delete(clusterScope.OscCluster, infrastructurev1beta1.AdditionalNetPeeringReadyCondition(name))
+	}
+	return nil
+}
+
+// reconcileDeleteAdditionalNetPeerings deletes all additional net peerings. Routes are deleted with the route tables.
+func (r *OscClusterReconciler) reconcileDeleteAdditionalNetPeerings(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	for _, spec := range clusterScope.GetNetwork().AdditionalNetPeerings {
+		err := r.deleteAdditionalNetPeering(ctx, clusterScope, spec, nil)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	err := r.deleteRemovedAdditionalNetPeerings(ctx, clusterScope, nil)
+	return reconcile.Result{}, err
+}
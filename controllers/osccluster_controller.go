@@ -48,7 +48,7 @@ type OscClusterReconciler struct {
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters/status,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;get;list;patch;update;watch
 
 func (r *OscClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -118,6 +118,15 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 		return reconcile.Result{}, errs.ToAggregate()
 	}
 
+	var preflightResult reconcile.Result
+	if clusterScope.IsAirgapped() {
+		var err error
+		preflightResult, err = r.reconcileAirgapPreflight(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("airgap preflight: %w", err)
+		}
+	}
+
 	// Reconcile each element of the cluster
 	_, err := r.reconcileNet(ctx, clusterScope)
 	if err != nil {
@@ -126,11 +135,16 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	}
 	conditions.MarkTrue(osccluster, infrastructurev1beta1.NetReadyCondition)
 
-	_, err = r.reconcileSubnets(ctx, clusterScope)
+	subnetResult, err := r.reconcileSubnets(ctx, clusterScope)
 	if err != nil {
 		conditions.MarkFalse(osccluster, infrastructurev1beta1.SubnetsReadyCondition, infrastructurev1beta1.SubnetsReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 		return reconcile.Result{}, fmt.Errorf("reconcile subnets: %w", err)
 	}
+	capacityResult, err := r.reconcileSubnetCapacity(ctx, clusterScope)
+	if err != nil {
+		conditions.MarkFalse(osccluster, infrastructurev1beta1.SubnetsReadyCondition, infrastructurev1beta1.SubnetsReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return reconcile.Result{}, fmt.Errorf("reconcile subnet capacity: %w", err)
+	}
 	conditions.MarkTrue(osccluster, infrastructurev1beta1.SubnetsReadyCondition)
 
 	if !clusterScope.IsInternetDisabled() {
@@ -157,7 +171,8 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	}
 
 	// Add all other route tables, whose destinations are the NAT services previously created.
-	_, err = r.reconcileRouteTable(ctx, clusterScope)
+	// Routes to NAT instances are delayed until the instances are created.
+	routeTableResult, err := r.reconcileRouteTable(ctx, clusterScope)
 	if err != nil {
 		conditions.MarkFalse(osccluster, infrastructurev1beta1.RouteTablesReadyCondition, infrastructurev1beta1.RouteTableReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 		return reconcile.Result{}, fmt.Errorf("reconcile routeTables: %w", err)
@@ -178,7 +193,63 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 		conditions.MarkTrue(osccluster, infrastructurev1beta1.NetPeeringReadyCondition)
 	}
 
-	if len(clusterScope.GetNetwork().NetAccessPoints) > 0 {
+	if hasAdditionalNetPeerings(clusterScope) {
+		_, err = r.reconcileAdditionalNetPeerings(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile additional netPeerings: %w", err)
+		}
+	}
+
+	var vpnResult reconcile.Result
+	switch {
+	case clusterScope.GetNetwork().Vpn.Enable:
+		vpnResult, err = r.reconcileVpn(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.VpnReadyCondition, infrastructurev1beta1.VpnReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile vpn: %w", err)
+		}
+		conditions.MarkTrue(osccluster, infrastructurev1beta1.VpnReadyCondition)
+	case hasVpn(clusterScope):
+		vpnResult, err = r.reconcileDisabledVpn(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.VpnReadyCondition, infrastructurev1beta1.VpnReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile disabled vpn: %w", err)
+		}
+		if vpnResult.IsZero() {
+			conditions.Delete(osccluster, infrastructurev1beta1.VpnReadyCondition)
+		}
+	}
+
+	var directLinkResult reconcile.Result
+	switch {
+	case clusterScope.GetNetwork().DirectLink.DirectLinkId != "":
+		directLinkResult, err = r.reconcileDirectLink(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.DirectLinkReadyCondition, infrastructurev1beta1.DirectLinkReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile directLink: %w", err)
+		}
+	case hasDirectLink(clusterScope):
+		directLinkResult, err = r.reconcileDeleteDirectLink(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.DirectLinkReadyCondition, infrastructurev1beta1.DirectLinkReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile removed directLink: %w", err)
+		}
+		if directLinkResult.IsZero() {
+			conditions.Delete(osccluster, infrastructurev1beta1.DirectLinkReadyCondition)
+		}
+	}
+
+	// The virtual gateway is deleted once neither the VPN nor the DirectLink use it.
+	var virtualGatewayResult reconcile.Result
+	if !clusterScope.GetNetwork().Vpn.Enable && clusterScope.GetNetwork().DirectLink.DirectLinkId == "" &&
+		hasVirtualGateway(clusterScope) && !hasVpn(clusterScope) && !hasDirectLink(clusterScope) {
+		virtualGatewayResult, err = r.reconcileDeleteVirtualGateway(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete virtual gateway: %w", err)
+		}
+	}
+
+	if len(clusterScope.GetNetAccessPoints()) > 0 {
 		_, err = r.reconcileNetAccessPoints(ctx, clusterScope)
 		if err != nil {
 			conditions.MarkFalse(osccluster, infrastructurev1beta1.NetAccessPointsReadyCondition, infrastructurev1beta1.NetAccessPointsReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
@@ -194,6 +265,29 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 		return reconcile.Result{}, fmt.Errorf("reconcile securityGroups: %w", err)
 	}
 	conditions.MarkTrue(osccluster, infrastructurev1beta1.SecurityGroupReadyCondition)
+	auditResult, err := r.reconcileSecurityGroupAudit(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("audit securityGroups: %w", err)
+	}
+
+	// NAT instances need the nat securityGroup.
+	var natInstanceResult reconcile.Result
+	if !clusterScope.IsInternetDisabled() && clusterScope.HasNatInstances() {
+		natInstanceResult, err = r.reconcileNatInstances(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.NatServicesReadyCondition, infrastructurev1beta1.NatServicesReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile natInstances: %w", err)
+		}
+	}
+
+	if clusterScope.GetNetwork().ControlPlaneNics.Enable {
+		_, err = r.reconcileControlPlaneNics(ctx, clusterScope)
+		if err != nil {
+			conditions.MarkFalse(osccluster, infrastructurev1beta1.ControlPlaneNicsReadyCondition, infrastructurev1beta1.ControlPlaneNicReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, fmt.Errorf("reconcile controlplane nics: %w", err)
+		}
+		conditions.MarkTrue(osccluster, infrastructurev1beta1.ControlPlaneNicsReadyCondition)
+	}
 
 	if !clusterScope.IsLBDisabled() {
 		_, err = r.reconcileLoadBalancer(ctx, clusterScope)
@@ -215,7 +309,14 @@ func (r *OscClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 
 	log.V(2).Info("OscCluster is ready")
 	clusterScope.SetReady()
-	return reconcile.Result{}, nil
+	// Removed subnets may still be in use, routes to NAT instances may be pending, VPN tunnels or DirectLink interfaces may not be ready yet,
+	// airgap prerequisites may be missing, and subnet capacity, audited securityGroups and NAT instances need to be checked periodically,
+	// check again at the earliest requested time.
+	var res reconcile.Result
+	for _, result := range []reconcile.Result{subnetResult, routeTableResult, vpnResult, directLinkResult, virtualGatewayResult, preflightResult, auditResult, natInstanceResult, capacityResult} {
+		res = util.LowestNonZeroResult(res, result)
+	}
+	return res, nil
 }
 
 // reconcileDelete reconcile the deletion of the cluster
@@ -254,7 +355,23 @@ func (r *OscClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		}
 	}
 
+	if clusterScope.GetNetwork().ControlPlaneNics.Enable {
+		_, err = r.reconcileDeleteControlPlaneNics(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete controlplane nics: %w", err)
+		}
+	}
+
 	if !clusterScope.IsInternetDisabled() {
+		if clusterScope.HasNatInstances() {
+			res, err := r.reconcileDeleteNatInstances(ctx, clusterScope)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("reconcile delete natInstances: %w", err)
+			}
+			if !res.IsZero() {
+				return res, nil
+			}
+		}
 		_, err = r.reconcileDeleteNatService(ctx, clusterScope)
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("reconcile delete natServices: %w", err)
@@ -280,6 +397,39 @@ func (r *OscClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 			return reconcile.Result{}, fmt.Errorf("reconcile delete netPeering: %w", err)
 		}
 	}
+	if hasAdditionalNetPeerings(clusterScope) {
+		_, err = r.reconcileDeleteAdditionalNetPeerings(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete additional netPeerings: %w", err)
+		}
+	}
+	if hasVpn(clusterScope) {
+		res, err := r.reconcileDeleteVpn(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete vpn: %w", err)
+		}
+		if !res.IsZero() {
+			return res, nil
+		}
+	}
+	if hasDirectLink(clusterScope) {
+		res, err := r.reconcileDeleteDirectLink(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete directLink: %w", err)
+		}
+		if !res.IsZero() {
+			return res, nil
+		}
+	}
+	if hasVirtualGateway(clusterScope) {
+		res, err := r.reconcileDeleteVirtualGateway(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("reconcile delete virtual gateway: %w", err)
+		}
+		if !res.IsZero() {
+			return res, nil
+		}
+	}
 	_, err = r.reconcileDeleteRouteTable(ctx, clusterScope)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("reconcile delete routeTables: %w", err)
@@ -7,6 +7,7 @@ package controllers_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -40,6 +41,7 @@ func runClusterTest(t *testing.T, tc testcase) {
 		Kind:       "Cluster",
 		Name:       c.Name,
 	}}
+	patchSubnetCapacityRefreshed()(oc)
 	for _, fn := range tc.clusterPatches {
 		fn(oc)
 	}
@@ -84,7 +86,7 @@ func runClusterTest(t *testing.T, tc testcase) {
 			assert.Zero(t, res)
 		} else {
 			require.NoError(t, err)
-			assert.Equal(t, step.requeue, res.RequeueAfter > 0 || res.Requeue)
+			assert.Equal(t, step.requeue, res.Requeue || (res.RequeueAfter > 0 && res.RequeueAfter < periodicRequeue))
 		}
 		var out infrastructurev1beta1.OscCluster
 		err = client.Get(context.TODO(), nsn, &out)
@@ -100,6 +102,9 @@ func runClusterTest(t *testing.T, tc testcase) {
 		for _, fn := range step.tenantAsserts {
 			fn(t, cs.tenant)
 		}
+		for _, fn := range step.kubeAsserts {
+			fn(t, client)
+		}
 		step = step.next
 	}
 }
@@ -945,8 +950,7 @@ func TestReconcileOSCCluster_Create(t *testing.T) {
 					"Worker securityGroup for test-cluster-api", "", []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker}, "sg-kw"),
 				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.3.0/24", 10250, 10250),
 				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.4.0/24", 10250, 10250),
-				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.6.0/24", 10250, 10250),
-				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.7.0/24", 10250, 10250),
+				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.6.0/23", 10250, 10250),
 				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.4.0/24", 443, 443),
 				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.7.0/24", 443, 443),
 				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "10.0.4.0/24", 1024, 65535),
@@ -1784,218 +1788,1154 @@ func TestReconcileOSCCluster_Update(t *testing.T) {
 			clusterBaseSpec: "base",
 		},
 		{
-			name:        "An inbound rule may be added to a 0.4 cluster (IpRange)",
-			clusterSpec: "ready-0.4",
+			name:            "Subnet capacity is published in status",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
 			clusterPatches: []patchOSCClusterFunc{
-				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
-					Flow: "Inbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRange: "1.2.3.4/32",
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
 				}),
+				patchSubnetCapacityOutdated(),
 			},
 			mockFuncs: []mockFunc{
-				mockNetFound("vpc-24ba90ce"),
-				mockSubnetFound("subnet-c1a282b0"),
-				mockSubnetFound("subnet-1555ea91"),
-				mockSubnetFound("subnet-174f5ec4"),
-				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
-
-				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
-					},
+				mockListSubnets([]string{"subnet-public", "subnet-kw", "subnet-kcp"}, []osc.Subnet{
+					{SubnetId: ptr.To("subnet-public"), AvailableIpsCount: ptr.To[int32](249)},
+					{SubnetId: ptr.To("subnet-kw"), AvailableIpsCount: ptr.To[int32](12)},
+					{SubnetId: ptr.To("subnet-kcp"), AvailableIpsCount: ptr.To[int32](247)},
 				}),
-				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.4/32", 32, 32),
-				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-					},
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertSubnetCapacity([]infrastructurev1beta1.OscSubnetCapacity{
+					{SubnetId: "subnet-public", IpSubnetRange: "10.0.2.0/24", SubregionName: "eu-west-2a", AvailableIps: 249,
+						Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleLoadBalancer, infrastructurev1beta1.RoleBastion, infrastructurev1beta1.RoleNat}},
+					{SubnetId: "subnet-kw", IpSubnetRange: "10.0.3.0/24", SubregionName: "eu-west-2a", AvailableIps: 12,
+						Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker}},
+					{SubnetId: "subnet-kcp", IpSubnetRange: "10.0.4.0/24", SubregionName: "eu-west-2a", AvailableIps: 247,
+						Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleControlPlane}},
 				}),
-				mockGetSecurityGroup("sg-7eb16ccb", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"0.0.0.0/0"}},
-					},
+			},
+		},
+		{
+			name:            "A running NAT instance is checked periodically",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-				mockGetSecurityGroup("sg-0cd1f87e", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](4789), ToPortRange: ptr.To[int32](4789), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](5473), ToPortRange: ptr.To[int32](5473), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51820), ToPortRange: ptr.To[int32](51820), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51821), ToPortRange: ptr.To[int32](51821), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8285), ToPortRange: ptr.To[int32](8285), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8472), ToPortRange: ptr.To[int32](8472), IpRanges: &[]string{"10.0.0.0/16"}},
-					},
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNatInstance("i-nat", "running", false),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
+			},
+			requeue: true,
+		},
+		{
+			name:            "A NAT instance is checked before the next securityGroup audit",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-
-				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
-					{
-						RouteTableId:    ptr.To("rtb-0a4640a6"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-194c971e"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-eeacfe8a"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
+				patchSecurityGroupsSpec(infrastructurev1beta1.OscSecurityGroup{
+					Name:  "kw",
+					Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+					Audit: true,
 				}),
-				mockGetNatServiceFromClientToken("test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
-				mockReadTagByNameFound(tag.NatResourceType, "test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", "nat-223a4dd4"),
-				mockNatServiceFound("nat-223a4dd4"),
-				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
-					{
-						RouteTableId:    ptr.To("rtb-0a4640a6"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-194c971e"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-eeacfe8a"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
+				patchSecurityGroupsAudited(),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNatInstance("i-nat", "running", false),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
+			},
+			requeue: true,
+		},
+		{
+			name:            "The source/dest check of a NAT instance is disabled",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-
-				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNatInstance("i-nat", "running", true),
+				mockUpdateVmSourceDestCheck("i-nat"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
 			},
+			requeue: true,
 		},
 		{
-			name:        "An outbound rule may be added to a 0.4 cluster (IpRange)",
-			clusterSpec: "ready-0.4",
+			name:            "A route not targeting the current NAT instance is repaired",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
 			clusterPatches: []patchOSCClusterFunc{
-				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
-					Flow: "Outbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRange: "1.2.3.4/32",
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
 			},
 			mockFuncs: []mockFunc{
-				mockNetFound("vpc-24ba90ce"),
-				mockSubnetFound("subnet-c1a282b0"),
-				mockSubnetFound("subnet-1555ea91"),
-				mockSubnetFound("subnet-174f5ec4"),
-				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
-
-				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
-					},
+				mockGetNatInstance("i-nat", "running", false),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-public"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-public")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), GatewayId: ptr.To("igw-foo")}},
+				}, {
+					RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-old")}},
+				}, {
+					RouteTableId: ptr.To("rtb-kcp"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kcp")}},
+					Routes: &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
+				mockUpdateRoute("rtb-kw", "0.0.0.0/0", "i-nat", "vm"),
+			},
+			requeue: true,
+		},
+		{
+			name:            "A stopped NAT instance is replaced and routes are moved to the new instance",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-				mockCreateSecurityGroupRule("sg-750ae810", "Outbound", "udp", "1.2.3.4/32", 32, 32),
-				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-					},
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNatInstance("i-nat", "stopped", false),
+				mockDeleteVm("i-nat"),
+				mockGetPublicIp("ipalloc-nat", "198.51.100.1"),
+				mockCreateVmNat(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat", VmType: "tinav6.c1r1p2"}, "subnet-public", []string{"sg-nat"},
+					"NAT instance for test-cluster-api/eu-west-2a", "nat-eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520-i-nat", "ami-nat", "10.0.0.0/16", "198.51.100.1", "i-nat-new"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-public"),
+					Routes:       &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), GatewayId: ptr.To("igw-foo")}},
+				}, {
+					RouteTableId: ptr.To("rtb-kw"),
+					Routes:       &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
+				mockUpdateRoute("rtb-kw", "0.0.0.0/0", "i-nat-new", "vm"),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat-new"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-				mockGetSecurityGroup("sg-7eb16ccb", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"0.0.0.0/0"}},
-					},
+			},
+		},
+		{
+			name:            "A deleted NAT instance is replaced",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:           map[string]string{"default": "vpc-foo"},
+					Subnet:        map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+					SecurityGroup: map[string]string{"test-cluster-api-nat-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-nat"},
+					NatInstance:   map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "i-nat"},
+					PublicIPs:     map[string]string{"eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520": "ipalloc-nat"},
 				}),
-				mockGetSecurityGroup("sg-0cd1f87e", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](4789), ToPortRange: ptr.To[int32](4789), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](5473), ToPortRange: ptr.To[int32](5473), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51820), ToPortRange: ptr.To[int32](51820), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51821), ToPortRange: ptr.To[int32](51821), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8285), ToPortRange: ptr.To[int32](8285), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8472), ToPortRange: ptr.To[int32](8472), IpRanges: &[]string{"10.0.0.0/16"}},
-					},
+				patchNatInstance(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat"}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNatInstanceNotFound("i-nat"),
+				mockGetPublicIp("ipalloc-nat", "198.51.100.1"),
+				mockCreateVmNat(infrastructurev1beta1.OscNatInstance{ImageId: "ami-nat", VmType: "tinav6.c1r1p2"}, "subnet-public", []string{"sg-nat"},
+					"NAT instance for test-cluster-api/eu-west-2a", "nat-eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520-i-nat", "ami-nat", "10.0.0.0/16", "198.51.100.1", "i-nat-new"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"),
+					Routes:       &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0"), VmId: ptr.To("i-nat")}},
+				}}),
+				mockUpdateRoute("rtb-kw", "0.0.0.0/0", "i-nat-new", "vm"),
+			},
+			requeue: true,
+		},
+		{
+			name:            "A VPN may be enabled on a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
 				}),
-
-				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
-					{
-						RouteTableId:    ptr.To("rtb-0a4640a6"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-194c971e"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-eeacfe8a"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
+				patchVpn(infrastructurev1beta1.OscVpnConnection{
+					Name:         "onprem",
+					PublicIp:     "198.51.100.1",
+					StaticRoutes: []string{"192.168.0.0/16"},
 				}),
-				mockGetNatServiceFromClientToken("test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
-				mockReadTagByNameFound(tag.NatResourceType, "test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", "nat-223a4dd4"),
-				mockNatServiceFound("nat-223a4dd4"),
-				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
-					{
-						RouteTableId:    ptr.To("rtb-0a4640a6"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-194c971e"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
-					{
-						RouteTableId:    ptr.To("rtb-eeacfe8a"),
-						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
-						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
-					},
+			},
+			mockFuncs: []mockFunc{
+				mockReadOwnedByTag(tag.VirtualGatewayResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockCreateVirtualGateway("9e1db9c4-bf0a-4583-8999-203ec002c520", "Virtual gateway for test-cluster-api", "vgw-foo"),
+				mockLinkVirtualGateway("vgw-foo", "vpc-foo"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{
+					{RouteTableId: ptr.To("rtb-public")},
+					{RouteTableId: ptr.To("rtb-kw")},
 				}),
-
-				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockUpdateRoutePropagation("vgw-foo", "rtb-public"),
+				mockUpdateRoutePropagation("vgw-foo", "rtb-kw"),
+				mockReadTagByNameNoneFound(tag.ClientGatewayResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateClientGateway("198.51.100.1", 65000, "9e1db9c4-bf0a-4583-8999-203ec002c520", "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520", "cgw-foo"),
+				mockReadTagByNameNoneFound(tag.VpnConnectionResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVpnConnection("cgw-foo", "vgw-foo", true, "9e1db9c4-bf0a-4583-8999-203ec002c520", "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520",
+					osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), ClientGatewayConfiguration: ptr.To("<config/>")}),
+				mockCreateVpnConnectionRoute("vpn-foo", "192.168.0.0/16"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					VirtualGateway: map[string]string{"default": "vgw-foo"},
+					ClientGateway:  map[string]string{"onprem": "cgw-foo"},
+					VpnConnection:  map[string]string{"onprem": "vpn-foo"},
+				}),
+				assertConditionTrue(infrastructurev1beta1.VpnReadyCondition),
+			},
+			kubeAsserts: []assertKubeFunc{
+				assertVpnSecret(map[string]string{"onprem": "<config/>"}),
 			},
 		},
 		{
-			name:        "An inbound rule may be added to a 0.4 cluster (IpRanges)",
-			clusterSpec: "ready-0.4",
+			name:            "A VPN connection without a tunnel configuration is checked again",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
 			clusterPatches: []patchOSCClusterFunc{
-				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
-					Flow: "Inbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRanges: []string{"1.2.3.4/32", "1.2.3.5/32"},
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					VirtualGateway: map[string]string{"default": "vgw-foo"},
+					ClientGateway:  map[string]string{"onprem": "cgw-foo"},
+					VpnConnection:  map[string]string{"onprem": "vpn-foo"},
+				}),
+				patchVpn(infrastructurev1beta1.OscVpnConnection{
+					Name:     "onprem",
+					PublicIp: "198.51.100.1",
 				}),
 			},
 			mockFuncs: []mockFunc{
-				mockNetFound("vpc-24ba90ce"),
-				mockSubnetFound("subnet-c1a282b0"),
-				mockSubnetFound("subnet-1555ea91"),
-				mockSubnetFound("subnet-174f5ec4"),
-				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
-
-				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
-					},
+				mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-foo"), State: ptr.To("attached")}}),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId:                    ptr.To("rtb-public"),
+					RoutePropagatingVirtualGateways: &[]osc.RoutePropagatingVirtualGateway{{VirtualGatewayId: ptr.To("vgw-foo")}},
+				}}),
+				mockGetClientGateway("cgw-foo"),
+				mockGetVpnConnection(osc.VpnConnection{
+					VpnConnectionId: ptr.To("vpn-foo"),
+					Routes:          &[]osc.RouteLight{{DestinationIpRange: ptr.To("10.10.0.0/16")}},
 				}),
-				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.4/32", 32, 32),
-				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.5/32", 32, 32),
-				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
-					InboundRules: &[]osc.SecurityGroupRule{
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
-					},
+				mockDeleteVpnConnectionRoute("vpn-foo", "10.10.0.0/16"),
+			},
+			requeue: true,
+		},
+		{
+			name:            "A VPN connection removed from the spec is deleted",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					VirtualGateway: map[string]string{"default": "vgw-foo"},
+					ClientGateway:  map[string]string{"onprem": "cgw-foo"},
+					VpnConnection:  map[string]string{"onprem": "vpn-foo"},
+				}),
+				patchVpn(),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-foo"), State: ptr.To("attached")}}),
+				mockGetRouteTablesFromNet("vpc-foo", nil),
+				mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("available")}),
+				mockDeleteVpnConnection("vpn-foo"),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-foo"), State: ptr.To("attached")}}),
+					mockGetRouteTablesFromNet("vpc-foo", nil),
+					mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("deleted")}),
+					mockGetClientGateway("cgw-foo"),
+					mockDeleteClientGateway("cgw-foo"),
+				},
+				clusterAsserts: []assertOSCClusterFunc{
+					assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+						Net:            map[string]string{"default": "vpc-foo"},
+						VirtualGateway: map[string]string{"default": "vgw-foo"},
+					}),
+				},
+			},
+		},
+		{
+			name:            "Disabling the VPN deletes its connections, virtual gateway and secret",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					VirtualGateway: map[string]string{"default": "vgw-foo"},
+					ClientGateway:  map[string]string{"onprem": "cgw-foo"},
+					VpnConnection:  map[string]string{"onprem": "vpn-foo"},
+				}),
+			},
+			kubeObjects: []client.Object{vpnSecret(map[string]string{"onprem": "<config/>"})},
+			mockFuncs: []mockFunc{
+				mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("available")}),
+				mockDeleteVpnConnection("vpn-foo"),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("deleted")}),
+					mockGetClientGateway("cgw-foo"),
+					mockDeleteClientGateway("cgw-foo"),
+					mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-foo"), State: ptr.To("attached")}}),
+					mockUnlinkVirtualGateway("vgw-foo", "vpc-foo"),
+				},
+				requeue: true,
+				kubeAsserts: []assertKubeFunc{
+					assertVpnSecretDeleted(),
+				},
+				next: &testcase{
+					mockFuncs: []mockFunc{
+						mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-foo"), State: ptr.To("detached")}}),
+						mockDeleteVirtualGateway("vgw-foo"),
+					},
+					clusterAsserts: []assertOSCClusterFunc{
+						assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+							Net: map[string]string{"default": "vpc-foo"},
+						}),
+						assertConditionUnset(infrastructurev1beta1.VpnReadyCondition),
+					},
+				},
+			},
+		},
+		{
+			name:            "A DirectLink interface may be created on a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+				}),
+				patchDirectLink(infrastructurev1beta1.OscDirectLink{
+					DirectLinkId:     "dxcon-foo",
+					Vlan:             42,
+					BgpKeyFromSecret: "bgp",
+					OnPremiseRanges:  []string{"192.168.0.0/16"},
+				}),
+			},
+			kubeObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-api-test", Name: "bgp"},
+					Data:       map[string][]byte{"bgpKey": []byte("s3cr3t")},
+				},
+			},
+			mockFuncs: []mockFunc{
+				mockListDirectLinkInterfaces("dxcon-foo", []osc.DirectLinkInterfaces{{
+					DirectLinkInterfaceId: ptr.To("dxvif-other"), DirectLinkInterfaceName: ptr.To("other"), State: ptr.To("available"),
+				}}),
+				mockReadOwnedByTag(tag.VirtualGatewayResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockCreateVirtualGateway("9e1db9c4-bf0a-4583-8999-203ec002c520", "Virtual gateway for test-cluster-api", "vgw-foo"),
+				mockLinkVirtualGateway("vgw-foo", "vpc-foo"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId:                    ptr.To("rtb-public"),
+					RoutePropagatingVirtualGateways: &[]osc.RoutePropagatingVirtualGateway{{VirtualGatewayId: ptr.To("vgw-foo")}},
+				}}),
+				mockCreateDirectLinkInterface("dxcon-foo", osc.DirectLinkInterface{
+					BgpAsn:                  65000,
+					BgpKey:                  ptr.To("s3cr3t"),
+					DirectLinkInterfaceName: "directlink-9e1db9c4-bf0a-4583-8999-203ec002c520",
+					VirtualGatewayId:        "vgw-foo",
+					Vlan:                    42,
+				}, osc.DirectLinkInterfaces{DirectLinkInterfaceId: ptr.To("dxvif-foo"), State: ptr.To("pending")}),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-public"),
+					Routes: &[]osc.Route{{
+						DestinationIpRange: ptr.To("10.10.0.0/16"), GatewayId: ptr.To("vgw-foo"), CreationMethod: ptr.To("CreateRoute"),
+					}, {
+						DestinationIpRange: ptr.To("172.16.0.0/16"), GatewayId: ptr.To("vgw-foo"), CreationMethod: ptr.To("EnableVgwRoutePropagation"),
+					}},
+				}}),
+				mockCreateRoute("rtb-public", "192.168.0.0/16", "vgw-foo", "gateway"),
+				mockDeleteRoute("rtb-public", "10.10.0.0/16"),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:                 map[string]string{"default": "vpc-foo"},
+					VirtualGateway:      map[string]string{"default": "vgw-foo"},
+					DirectLinkInterface: map[string]string{"default": "dxvif-foo"},
+				}),
+				assertConditionFalse(infrastructurev1beta1.DirectLinkReadyCondition, infrastructurev1beta1.DirectLinkInterfaceNotAvailableReason),
+			},
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetDirectLinkInterface(osc.DirectLinkInterfaces{DirectLinkInterfaceId: ptr.To("dxvif-foo"), State: ptr.To("available")}),
+				},
+				clusterAsserts: []assertOSCClusterFunc{
+					assertConditionTrue(infrastructurev1beta1.DirectLinkReadyCondition),
+				},
+			},
+		},
+		{
+			name:            "A DirectLink removed from the spec is deleted with its virtual gateway",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:                 map[string]string{"default": "vpc-foo"},
+					VirtualGateway:      map[string]string{"default": "vgw-foo"},
+					DirectLinkInterface: map[string]string{"default": "dxvif-foo"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetDirectLinkInterface(osc.DirectLinkInterfaces{
+					DirectLinkInterfaceId: ptr.To("dxvif-foo"), VirtualGatewayId: ptr.To("vgw-foo"), State: ptr.To("available"),
+				}),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-public"),
+					Routes: &[]osc.Route{{
+						DestinationIpRange: ptr.To("192.168.0.0/16"), GatewayId: ptr.To("vgw-foo"), CreationMethod: ptr.To("CreateRoute"),
+					}},
+				}}),
+				mockDeleteRoute("rtb-public", "192.168.0.0/16"),
+				mockDeleteDirectLinkInterface("dxvif-foo"),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetDirectLinkInterface(osc.DirectLinkInterfaces{DirectLinkInterfaceId: ptr.To("dxvif-foo"), State: ptr.To("deleted")}),
+					mockGetVirtualGateway("vgw-foo", nil),
+					mockDeleteVirtualGateway("vgw-foo"),
+				},
+				clusterAsserts: []assertOSCClusterFunc{
+					assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+						Net: map[string]string{"default": "vpc-foo"},
+					}),
+					assertConditionUnset(infrastructurev1beta1.DirectLinkReadyCondition),
+				},
+			},
+		},
+		{
+			name:            "A konnectivity listener may be added to the loadbalancer of a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerLoadbalancer),
+				patchLoadBalancerListeners(
+					infrastructurev1beta1.OscLoadBalancerListener{LoadBalancerPort: 6443},
+					infrastructurev1beta1.OscLoadBalancerListener{LoadBalancerPort: 8132},
+				),
+			},
+			mockFuncs: []mockFunc{
+				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateLoadBalancerListeners("test-cluster-api-k8s", []infrastructurev1beta1.OscLoadBalancerListener{{
+					BackendPort: 8132, BackendProtocol: "TCP", LoadBalancerPort: 8132, LoadBalancerProtocol: "TCP",
+				}}),
+			},
+		},
+		{
+			name:            "Changed loadbalancer listeners are recreated and the healthcheck is updated",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerLoadbalancer),
+				patchLoadBalancerListeners(
+					infrastructurev1beta1.OscLoadBalancerListener{LoadBalancerPort: 6443, BackendPort: 6444},
+				),
+			},
+			mockFuncs: []mockFunc{
+				mockLoadBalancerFoundWith("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520",
+					[]osc.Listener{defaultListener, {
+						BackendPort: ptr.To[int32](8132), BackendProtocol: ptr.To("TCP"),
+						LoadBalancerPort: ptr.To[int32](8132), LoadBalancerProtocol: ptr.To("TCP"),
+					}}, v04HealthCheck),
+				mockDeleteLoadBalancerListeners("test-cluster-api-k8s", []int32{6443, 8132}),
+				mockCreateLoadBalancerListeners("test-cluster-api-k8s", []infrastructurev1beta1.OscLoadBalancerListener{{
+					BackendPort: 6444, BackendProtocol: "TCP", LoadBalancerPort: 6443, LoadBalancerProtocol: "TCP",
+				}}),
+				mockConfigureHealthCheck("test-cluster-api-k8s"),
+			},
+		},
+		{
+			name:            "DHCP options may be added on a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerNet),
+				patchDhcpOptions(infrastructurev1beta1.OscDhcpOptions{
+					DomainName:        "corp.example.com",
+					DomainNameServers: []string{"10.1.0.2"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNet("vpc-foo", &osc.Net{NetId: ptr.To("vpc-foo"), DhcpOptionsSetId: ptr.To("dopt-default")}),
+				mockReadOwnedByTag(tag.DhcpOptionsResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockCreateDhcpOptions(infrastructurev1beta1.OscDhcpOptions{
+					DomainName:        "corp.example.com",
+					DomainNameServers: []string{"10.1.0.2"},
+				}, "9e1db9c4-bf0a-4583-8999-203ec002c520", "DHCP options for test-cluster-api", "dopt-foo"),
+				mockLinkDhcpOptions("dopt-foo", "vpc-foo"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-foo"},
+				}),
+			},
+		},
+		{
+			name:            "DHCP options are swapped when the spec changes",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-old"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerNet),
+				patchDhcpOptions(infrastructurev1beta1.OscDhcpOptions{
+					DomainName: "corp.example.com",
+					NtpServers: []string{"10.1.0.3"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNet("vpc-foo", &osc.Net{NetId: ptr.To("vpc-foo"), DhcpOptionsSetId: ptr.To("dopt-old")}),
+				mockGetDhcpOptions(osc.DhcpOptionsSet{DhcpOptionsSetId: ptr.To("dopt-old"), DomainName: ptr.To("corp.example.com")}),
+				mockCreateDhcpOptions(infrastructurev1beta1.OscDhcpOptions{
+					DomainName: "corp.example.com",
+					NtpServers: []string{"10.1.0.3"},
+				}, "9e1db9c4-bf0a-4583-8999-203ec002c520", "DHCP options for test-cluster-api", "dopt-new"),
+				mockLinkDhcpOptions("dopt-new", "vpc-foo"),
+				mockDeleteDhcpOptions("dopt-old"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-new"},
+				}),
+			},
+		},
+		{
+			name:            "DHCP options are not swapped when servers are returned in another order",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-foo"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerNet),
+				patchDhcpOptions(infrastructurev1beta1.OscDhcpOptions{
+					DomainNameServers: []string{"10.1.0.2", "10.1.0.1"},
+					NtpServers:        []string{"10.1.0.4", "10.1.0.3"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNet("vpc-foo", &osc.Net{NetId: ptr.To("vpc-foo"), DhcpOptionsSetId: ptr.To("dopt-foo")}),
+				mockGetDhcpOptions(osc.DhcpOptionsSet{
+					DhcpOptionsSetId:  ptr.To("dopt-foo"),
+					DomainNameServers: &[]string{"10.1.0.1", "10.1.0.2"},
+					NtpServers:        &[]string{"10.1.0.3", "10.1.0.4"},
+				}),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-foo"},
+				}),
+			},
+		},
+		{
+			name:            "DHCP options removed from the spec are deleted",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:            map[string]string{"default": "vpc-foo"},
+					DhcpOptionsSet: map[string]string{"default": "dopt-old"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerNet),
+			},
+			mockFuncs: []mockFunc{
+				mockGetNet("vpc-foo", &osc.Net{NetId: ptr.To("vpc-foo"), DhcpOptionsSetId: ptr.To("dopt-old")}),
+				mockGetDhcpOptions(osc.DhcpOptionsSet{DhcpOptionsSetId: ptr.To("dopt-old"), DomainName: ptr.To("corp.example.com")}),
+				mockLinkDhcpOptions("default", "vpc-foo"),
+				mockDeleteDhcpOptions("dopt-old"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+				}),
+			},
+		},
+		{
+			name:            "An additional netPeering may be added on a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{
+						"10.0.2.0/24": "subnet-public",
+						"10.0.3.0/24": "subnet-kw",
+						"10.0.4.0/24": "subnet-kcp",
+					},
+				}),
+				patchAdditionalNetPeerings(infrastructurev1beta1.OscAdditionalNetPeering{
+					Name:                "shared",
+					NetId:               "vpc-shared",
+					AccountId:           "0123",
+					DestinationIpRanges: []string{"10.100.0.0/16"},
+					RouteTableRoles:     []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId:    ptr.To("rtb-public"),
+					LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-public")}},
+				}, {
+					RouteTableId:    ptr.To("rtb-kw"),
+					LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}},
+				}}),
+				mockReadTagByNameNoneFound(tag.NetPeeringResourceType, "netpeering-shared-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateNamedNetPeering("vpc-foo", "vpc-shared", "0123", "netpeering-shared-9e1db9c4-bf0a-4583-8999-203ec002c520", "pcx-shared"),
+				mockAcceptNetPeeringId("pcx-shared", nil),
+				mockCreateRoute("rtb-kw", "10.100.0.0/16", "pcx-shared", "netPeering"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{
+						"10.0.2.0/24": "subnet-public",
+						"10.0.3.0/24": "subnet-kw",
+						"10.0.4.0/24": "subnet-kcp",
+					},
+					NetPeering: map[string]string{"shared": "pcx-shared"},
+				}),
+				assertConditionTrue(infrastructurev1beta1.AdditionalNetPeeringReadyCondition("shared")),
+				assertAcceptedNetPeerings("pcx-shared"),
+			},
+		},
+		{
+			name:            "An additional netPeering already accepted is not accepted again",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:        map[string]string{"default": "vpc-foo"},
+					NetPeering: map[string]string{"shared": "pcx-shared"},
+				}),
+				patchAcceptedNetPeerings("pcx-shared"),
+				patchAdditionalNetPeerings(infrastructurev1beta1.OscAdditionalNetPeering{
+					Name:                "shared",
+					NetId:               "vpc-shared",
+					DestinationIpRanges: []string{"10.100.0.0/16"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"),
+					Routes: &[]osc.Route{{
+						DestinationIpRange: ptr.To("10.100.0.0/16"), NetPeeringId: ptr.To("pcx-shared"),
+					}},
+				}}),
+				mockGetNetPeeringId("pcx-shared", "pending-acceptance"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertAcceptedNetPeerings("pcx-shared"),
+			},
+		},
+		{
+			name:            "A failure to accept an additional netPeering is reported in its condition",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+				}),
+				patchAdditionalNetPeerings(infrastructurev1beta1.OscAdditionalNetPeering{
+					Name:                "shared",
+					NetId:               "vpc-shared",
+					DestinationIpRanges: []string{"10.100.0.0/16"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetRouteTablesFromNet("vpc-foo", nil),
+				mockReadTagByNameNoneFound(tag.NetPeeringResourceType, "netpeering-shared-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateNamedNetPeering("vpc-foo", "vpc-shared", "", "netpeering-shared-9e1db9c4-bf0a-4583-8999-203ec002c520", "pcx-shared"),
+				mockAcceptNetPeeringId("pcx-shared", errors.New("OAPI error")),
+			},
+			hasError: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionFalse(infrastructurev1beta1.AdditionalNetPeeringReadyCondition("shared"), infrastructurev1beta1.NetPeeringReconciliationFailedReason),
+			},
+		},
+		{
+			name:            "An additional netPeering removed from the spec is deleted",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:        map[string]string{"default": "vpc-foo"},
+					NetPeering: map[string]string{"shared": "pcx-shared"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-kw"),
+					Routes: &[]osc.Route{{
+						DestinationIpRange: ptr.To("10.100.0.0/16"), NetPeeringId: ptr.To("pcx-shared"),
+					}},
+				}}),
+				mockGetNetPeeringId("pcx-shared", "active"),
+				mockDeleteRoute("rtb-kw", "10.100.0.0/16"),
+				mockDeleteNetPeering("pcx-shared"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+				}),
+			},
+		},
+		{
+			name:            "Controlplane NICs may be enabled on a v1.0 cluster",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches:  []patchOSCClusterFunc{patchControlPlaneNics()},
+			mockFuncs: []mockFunc{
+				mockReadTagByNameNoneFound(tag.NicResourceType, "controlplane-eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockReadOwnedByTag(tag.NetResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Tag{ResourceId: ptr.To("vpc-foo")}),
+				mockGetSubnetFromNet("vpc-foo", "10.0.4.0/24", &osc.Subnet{SubnetId: ptr.To("subnet-kcp")}),
+				mockGetSecurityGroupFromName("test-cluster-api-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.SecurityGroup{SecurityGroupId: ptr.To("sg-kcp")}),
+				mockGetSecurityGroupFromName("test-cluster-api-node-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.SecurityGroup{SecurityGroupId: ptr.To("sg-node")}),
+				mockCreateNic("subnet-kcp", []string{"sg-kcp", "sg-node"}, "", "9e1db9c4-bf0a-4583-8999-203ec002c520",
+					"controlplane-eu-west-2a-9e1db9c4-bf0a-4583-8999-203ec002c520", "eni-kcp"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertControlPlaneNic("eu-west-2a", "eni-kcp"),
+			},
+		},
+		{
+			name:            "Controlplane NICs may have fixed IPs",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{patchControlPlaneNics(infrastructurev1beta1.OscNicSlot{
+				Name:      "kcp-1",
+				PrivateIp: "10.0.4.10",
+			})},
+			mockFuncs: []mockFunc{
+				mockReadTagByNameNoneFound(tag.NicResourceType, "controlplane-kcp-1-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockReadOwnedByTag(tag.NetResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Tag{ResourceId: ptr.To("vpc-foo")}),
+				mockGetSubnetFromNet("vpc-foo", "10.0.4.0/24", &osc.Subnet{SubnetId: ptr.To("subnet-kcp")}),
+				mockGetSecurityGroupFromName("test-cluster-api-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.SecurityGroup{SecurityGroupId: ptr.To("sg-kcp")}),
+				mockGetSecurityGroupFromName("test-cluster-api-node-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.SecurityGroup{SecurityGroupId: ptr.To("sg-node")}),
+				mockCreateNic("subnet-kcp", []string{"sg-kcp", "sg-node"}, "10.0.4.10", "9e1db9c4-bf0a-4583-8999-203ec002c520",
+					"controlplane-kcp-1-9e1db9c4-bf0a-4583-8999-203ec002c520", "eni-kcp"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertControlPlaneNic("kcp-1", "eni-kcp"),
+			},
+		},
+		{
+			name:            "An empty subnetPlan added to a v1.0 cluster keeps the existing subnets",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSubnet),
+				patchSubnetPlan(infrastructurev1beta1.OscSubnetPlan{}),
+			},
+			mockFuncs: []mockFunc{
+				mockSubnetFound("subnet-public"),
+				mockSubnetFound("subnet-kw"),
+				mockSubnetFound("subnet-kcp"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+			},
+		},
+		{
+			name:            "A subnet removed from the spec is deleted",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp", "10.0.5.0/24": "subnet-old"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSubnet),
+			},
+			mockFuncs: []mockFunc{
+				mockSubnetFound("subnet-public"),
+				mockSubnetFound("subnet-kw"),
+				mockSubnetFound("subnet-kcp"),
+				mockListVmsFromSubnet("subnet-old", nil),
+				mockListNatServices("vpc-foo", []osc.NatService{{NatServiceId: ptr.To("nat-foo"), SubnetId: ptr.To("subnet-public"), State: ptr.To("available")}}),
+				mockListLoadBalancers([]osc.LoadBalancer{{LoadBalancerName: ptr.To("test-cluster-api-k8s"), Subnets: &[]string{"subnet-public"}}}),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{
+					{RouteTableId: ptr.To("rtb-kcp"), LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-kcp"), SubnetId: ptr.To("subnet-kcp")}}},
+					{RouteTableId: ptr.To("rtb-old"), LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-old"), SubnetId: ptr.To("subnet-old")}}},
+				}),
+				mockUnlinkRouteTable("rtbassoc-old"),
+				mockDeleteRouteTable("rtb-old"),
+				mockDeleteSubnet("subnet-old"),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+			},
+		},
+		{
+			name:            "Subnets are not deleted if the subnetPlan cannot be applied",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSubnet),
+				patchSubnetPlan(infrastructurev1beta1.OscSubnetPlan{WorkerPrefixLength: 16}),
+			},
+			hasError: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+			},
+		},
+		{
+			name:            "A subnet removed from the spec is not deleted if still in use",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp", "10.0.5.0/24": "subnet-old"},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSubnet),
+			},
+			mockFuncs: []mockFunc{
+				mockSubnetFound("subnet-public"),
+				mockSubnetFound("subnet-kw"),
+				mockSubnetFound("subnet-kcp"),
+				mockListVmsFromSubnet("subnet-old", []osc.Vm{{VmId: ptr.To("i-foo")}}),
+				mockListNatServices("vpc-foo", []osc.NatService{{NatServiceId: ptr.To("nat-foo"), SubnetId: ptr.To("subnet-public"), State: ptr.To("available")}}),
+				mockListLoadBalancers([]osc.LoadBalancer{{LoadBalancerName: ptr.To("test-cluster-api-k8s"), Subnets: &[]string{"subnet-public"}}}),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionFalse(infrastructurev1beta1.SubnetsRemovedCondition, infrastructurev1beta1.SubnetInUseReason),
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp", "10.0.5.0/24": "subnet-old"},
+				}),
+			},
+		},
+		{
+			name:            "Rules may reference other securityGroups",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					SecurityGroup: map[string]string{
+						"lb-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-lb",
+						"kw-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-kw",
+					},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSecurityGroup),
+				patchSecurityGroupsSpec(infrastructurev1beta1.OscSecurityGroup{
+					Name:  "lb",
+					Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleLoadBalancer},
+				}, infrastructurev1beta1.OscSecurityGroup{
+					Name:          "kw",
+					Roles:         []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+					Authoritative: true,
+					SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+						Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 10250, ToPortRange: 10250,
+						SecurityGroupMembers: []infrastructurev1beta1.OscSecurityGroupMember{{Role: infrastructurev1beta1.RoleLoadBalancer}, {SecurityGroupId: "sg-external"}},
+					}, {
+						Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 22, ToPortRange: 22,
+						SecurityGroupMembers: []infrastructurev1beta1.OscSecurityGroupMember{{Name: "kw"}},
+					}},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetSecurityGroup("sg-lb", &osc.SecurityGroup{}),
+				mockGetSecurityGroup("sg-kw", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), SecurityGroupsMembers: &[]osc.SecurityGroupsMember{{SecurityGroupId: ptr.To("sg-lb")}}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), SecurityGroupsMembers: &[]osc.SecurityGroupsMember{{SecurityGroupId: ptr.To("sg-ccm")}}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), SecurityGroupsMembers: &[]osc.SecurityGroupsMember{{SecurityGroupId: ptr.To("sg-lb")}}},
+					},
+				}),
+				mockCreateSecurityGroupMemberRule("sg-kw", "Inbound", "tcp", "sg-external", 10250, 10250),
+				mockCreateSecurityGroupMemberRule("sg-kw", "Inbound", "tcp", "sg-kw", 22, 22),
+				mockDeleteSecurityGroupRule("sg-kw", "Inbound", "tcp", "", "sg-lb", 6443, 6443),
+			},
+		},
+		{
+			name:            "Rules exceeding the quota are spread over an overflow securityGroup",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					SecurityGroup: map[string]string{
+						"kw-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-kw",
+					},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSecurityGroup),
+				patchSecurityGroupRuleQuota(2),
+				patchSecurityGroupsSpec(infrastructurev1beta1.OscSecurityGroup{
+					Name:        "kw",
+					Description: "Worker",
+					Roles:       []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+					SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+						Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 443, ToPortRange: 443,
+						IpRanges: []string{"1.2.3.0/25", "1.2.3.128/25", "1.2.5.0/24", "1.2.7.0/24"},
+					}},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetSecurityGroup("sg-kw", &osc.SecurityGroup{}),
+				mockGetSecurityGroupFromName("kw-overflow-1-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockCreateSecurityGroup("vpc-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520", "kw-overflow-1-9e1db9c4-bf0a-4583-8999-203ec002c520", "Worker (overflow 1)", "",
+					[]infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker}, "sg-kw-overflow"),
+				mockListVmsFromSecurityGroup("sg-kw", []osc.Vm{
+					{VmId: ptr.To("i-worker"), SecurityGroups: &[]osc.SecurityGroupLight{{SecurityGroupId: ptr.To("sg-kw")}}},
+					{VmId: ptr.To("i-worker-ok"), SecurityGroups: &[]osc.SecurityGroupLight{
+						{SecurityGroupId: ptr.To("sg-kw")}, {SecurityGroupId: ptr.To("sg-kw-overflow")},
+					}},
+				}),
+				mockUpdateVmSecurityGroups("i-worker", "sg-kw", "sg-kw-overflow"),
+				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "1.2.3.0/24", 443, 443),
+				mockCreateSecurityGroupRule("sg-kw", "Inbound", "tcp", "1.2.5.0/24", 443, 443),
+				mockCreateSecurityGroupRule("sg-kw-overflow", "Inbound", "tcp", "1.2.7.0/24", 443, 443),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertStatusClusterResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					SecurityGroup: map[string]string{
+						"kw-9e1db9c4-bf0a-4583-8999-203ec002c520":            "sg-kw",
+						"kw-overflow-1-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-kw-overflow",
+					},
+				}),
+			},
+		},
+		{
+			name:            "Drift on securityGroups in audit mode is reported, not fixed",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net: map[string]string{"default": "vpc-foo"},
+					SecurityGroup: map[string]string{
+						"kw-9e1db9c4-bf0a-4583-8999-203ec002c520": "sg-kw",
+					},
+				}),
+				patchReconcileAgain(infrastructurev1beta1.ReconcilerSecurityGroup),
+				patchSecurityGroupsSpec(infrastructurev1beta1.OscSecurityGroup{
+					Name:  "kw",
+					Roles: []infrastructurev1beta1.OscRole{infrastructurev1beta1.RoleWorker},
+					Audit: true,
+					SecurityGroupRules: []infrastructurev1beta1.OscSecurityGroupRule{{
+						Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 10250, ToPortRange: 10250, IpRange: "10.0.0.0/16",
+					}, {
+						Flow: "Inbound", IpProtocol: "tcp", FromPortRange: 22, ToPortRange: 22, IpRange: "10.0.0.0/16",
+					}},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetSecurityGroup("sg-kw", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](22), ToPortRange: ptr.To[int32](22), IpRanges: &[]string{"0.0.0.0/0"}},
+					},
+				}),
+				mockGetSecurityGroup("sg-kw", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](22), ToPortRange: ptr.To[int32](22), IpRanges: &[]string{"0.0.0.0/0"}},
+					},
+				}),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionTrue(infrastructurev1beta1.SecurityGroupReadyCondition),
+				assertConditionDrift("sg-kw: missing [Inbound tcp 22-22 10.0.0.0/16], extra [Inbound tcp 22-22 0.0.0.0/0]"),
+			},
+		},
+		{
+			name:        "An inbound rule may be added to a 0.4 cluster (IpRange)",
+			clusterSpec: "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
+					Flow: "Inbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRange: "1.2.3.4/32",
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockNetFound("vpc-24ba90ce"),
+				mockSubnetFound("subnet-c1a282b0"),
+				mockSubnetFound("subnet-1555ea91"),
+				mockSubnetFound("subnet-174f5ec4"),
+				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+
+				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
+					},
+				}),
+				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.4/32", 32, 32),
+				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+					},
+				}),
+				mockGetSecurityGroup("sg-7eb16ccb", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"0.0.0.0/0"}},
+					},
+				}),
+				mockGetSecurityGroup("sg-0cd1f87e", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](4789), ToPortRange: ptr.To[int32](4789), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](5473), ToPortRange: ptr.To[int32](5473), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51820), ToPortRange: ptr.To[int32](51820), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51821), ToPortRange: ptr.To[int32](51821), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8285), ToPortRange: ptr.To[int32](8285), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8472), ToPortRange: ptr.To[int32](8472), IpRanges: &[]string{"10.0.0.0/16"}},
+					},
+				}),
+
+				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
+					{
+						RouteTableId:    ptr.To("rtb-0a4640a6"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-194c971e"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-eeacfe8a"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+				}),
+				mockGetNatServiceFromClientToken("test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameFound(tag.NatResourceType, "test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", "nat-223a4dd4"),
+				mockNatServiceFound("nat-223a4dd4"),
+				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
+					{
+						RouteTableId:    ptr.To("rtb-0a4640a6"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-194c971e"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-eeacfe8a"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+				}),
+
+				mockLoadBalancerFoundWith("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520", []osc.Listener{defaultListener}, v04HealthCheck),
+			},
+		},
+		{
+			name:        "An outbound rule may be added to a 0.4 cluster (IpRange)",
+			clusterSpec: "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
+					Flow: "Outbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRange: "1.2.3.4/32",
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockNetFound("vpc-24ba90ce"),
+				mockSubnetFound("subnet-c1a282b0"),
+				mockSubnetFound("subnet-1555ea91"),
+				mockSubnetFound("subnet-174f5ec4"),
+				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+
+				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
+					},
+				}),
+				mockCreateSecurityGroupRule("sg-750ae810", "Outbound", "udp", "1.2.3.4/32", 32, 32),
+				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+					},
 				}),
 				mockGetSecurityGroup("sg-7eb16ccb", &osc.SecurityGroup{
 					InboundRules: &[]osc.SecurityGroupRule{
@@ -2051,7 +2991,98 @@ func TestReconcileOSCCluster_Update(t *testing.T) {
 					},
 				}),
 
-				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockLoadBalancerFoundWith("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520", []osc.Listener{defaultListener}, v04HealthCheck),
+			},
+		},
+		{
+			name:        "An inbound rule may be added to a 0.4 cluster (IpRanges)",
+			clusterSpec: "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAddSGRule("test-cluster-api-securitygroup-kcp", infrastructurev1beta1.OscSecurityGroupRule{
+					Flow: "Inbound", IpProtocol: "udp", FromPortRange: 32, ToPortRange: 32, IpRanges: []string{"1.2.3.4/32", "1.2.3.6/32"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockNetFound("vpc-24ba90ce"),
+				mockSubnetFound("subnet-c1a282b0"),
+				mockSubnetFound("subnet-1555ea91"),
+				mockSubnetFound("subnet-174f5ec4"),
+				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+
+				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](2378), ToPortRange: ptr.To[int32](2379), IpRanges: &[]string{"10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10252), IpRanges: &[]string{"10.0.4.0/24"}},
+					},
+				}),
+				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.4/32", 32, 32),
+				mockCreateSecurityGroupRule("sg-750ae810", "Inbound", "udp", "1.2.3.6/32", 32, 32),
+				mockGetSecurityGroup("sg-a093d014", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](179), ToPortRange: ptr.To[int32](179), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](10250), ToPortRange: ptr.To[int32](10250), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](30000), ToPortRange: ptr.To[int32](32767), IpRanges: &[]string{"10.0.3.0/24", "10.0.4.0/24"}},
+					},
+				}),
+				mockGetSecurityGroup("sg-7eb16ccb", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("tcp"), FromPortRange: ptr.To[int32](6443), ToPortRange: ptr.To[int32](6443), IpRanges: &[]string{"0.0.0.0/0"}},
+					},
+				}),
+				mockGetSecurityGroup("sg-0cd1f87e", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](4789), ToPortRange: ptr.To[int32](4789), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](5473), ToPortRange: ptr.To[int32](5473), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51820), ToPortRange: ptr.To[int32](51820), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](51821), ToPortRange: ptr.To[int32](51821), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8285), ToPortRange: ptr.To[int32](8285), IpRanges: &[]string{"10.0.0.0/16"}},
+						{IpProtocol: ptr.To("udp"), FromPortRange: ptr.To[int32](8472), ToPortRange: ptr.To[int32](8472), IpRanges: &[]string{"10.0.0.0/16"}},
+					},
+				}),
+
+				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
+					{
+						RouteTableId:    ptr.To("rtb-0a4640a6"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-194c971e"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-eeacfe8a"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+				}),
+				mockGetNatServiceFromClientToken("test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameFound(tag.NatResourceType, "test-cluster-api-natservice-9e1db9c4-bf0a-4583-8999-203ec002c520", "nat-223a4dd4"),
+				mockNatServiceFound("nat-223a4dd4"),
+				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{
+					{
+						RouteTableId:    ptr.To("rtb-0a4640a6"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-643430b3"), SubnetId: ptr.To("subnet-1555ea91")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-194c971e"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-09475c37"), SubnetId: ptr.To("subnet-c1a282b0")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+					{
+						RouteTableId:    ptr.To("rtb-eeacfe8a"),
+						LinkRouteTables: &[]osc.LinkRouteTable{{LinkRouteTableId: ptr.To("rtbassoc-90bda9c8"), SubnetId: ptr.To("subnet-174f5ec4")}},
+						Routes:          &[]osc.Route{{DestinationIpRange: ptr.To("0.0.0.0/0")}},
+					},
+				}),
+
+				mockLoadBalancerFoundWith("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520", []osc.Listener{defaultListener}, v04HealthCheck),
 			},
 		},
 		{
@@ -2371,6 +3402,7 @@ func TestReconcileOSCCluster_Update(t *testing.T) {
 				mockSubnetFound("subnet-c1a282b0"),
 				mockSubnetFound("subnet-1555ea91"),
 				mockSubnetFound("subnet-174f5ec4"),
+				mockSubnetCapacity(),
 				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
 
 				mockGetSecurityGroup("sg-750ae810", &osc.SecurityGroup{
@@ -2444,7 +3476,7 @@ func TestReconcileOSCCluster_Update(t *testing.T) {
 					},
 				}),
 
-				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockLoadBalancerFoundWith("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520", []osc.Listener{defaultListener}, v04HealthCheck),
 			},
 			clusterAsserts: []assertOSCClusterFunc{
 				// All other resources have a ResourceId field, no need to store a ref in status.
@@ -2607,6 +3639,119 @@ func TestReconcileOSCCluster_Update(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:            "switching to airgapped mode creates all net access points on all route tables and reports missing prerequisites",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAirgapped(),
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{
+					{RouteTableId: ptr.To("rtb-public"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-public")}}},
+					{RouteTableId: ptr.To("rtb-kcp"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kcp")}}},
+					{RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}}},
+				}),
+				mockGetNetAccessPoint("vpc-foo", "api", nil),
+				mockCreateNetAccessPoint("vpc-foo", "api", "9e1db9c4-bf0a-4583-8999-203ec002c520", []string{"rtb-public", "rtb-kcp", "rtb-kw"}),
+				mockGetNetAccessPoint("vpc-foo", "eim", nil),
+				mockCreateNetAccessPoint("vpc-foo", "eim", "9e1db9c4-bf0a-4583-8999-203ec002c520", []string{"rtb-public", "rtb-kcp", "rtb-kw"}),
+				mockGetNetAccessPoint("vpc-foo", "lbu", nil),
+				mockCreateNetAccessPoint("vpc-foo", "lbu", "9e1db9c4-bf0a-4583-8999-203ec002c520", []string{"rtb-public", "rtb-kcp", "rtb-kw"}),
+				mockGetNetAccessPoint("vpc-foo", "oos", nil),
+				mockCreateNetAccessPoint("vpc-foo", "oos", "9e1db9c4-bf0a-4583-8999-203ec002c520", []string{"rtb-public", "rtb-kcp", "rtb-kw"}),
+				mockGetNetAccessPoint("vpc-foo", "kms", nil),
+				mockCreateNetAccessPoint("vpc-foo", "kms", "9e1db9c4-bf0a-4583-8999-203ec002c520", []string{"rtb-public", "rtb-kcp", "rtb-kw"}),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionFalse(infrastructurev1beta1.AirgapPreflightReadyCondition, infrastructurev1beta1.AirgapPrerequisitesMissingReason),
+			},
+		},
+		{
+			name:            "in airgapped mode, missing route tables are linked to existing net access points and machine images are checked",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAirgapped(),
+				patchEnableNetPeering(),
+				patchReconciled(infrastructurev1beta1.ReconcilerNetPeering, infrastructurev1beta1.ReconcilerNetPeeringRoutes),
+				patchResources(infrastructurev1beta1.OscClusterResources{
+					Net:    map[string]string{"default": "vpc-foo"},
+					Subnet: map[string]string{"10.0.2.0/24": "subnet-public", "10.0.3.0/24": "subnet-kw", "10.0.4.0/24": "subnet-kcp"},
+				}),
+			},
+			kubeObjects: machineObjects(t, "base-worker", "base-worker"),
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetRouteTablesFromNet("vpc-foo", []osc.RouteTable{
+					{RouteTableId: ptr.To("rtb-public"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-public")}}},
+					{RouteTableId: ptr.To("rtb-kcp"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kcp")}}},
+					{RouteTableId: ptr.To("rtb-kw"), LinkRouteTables: &[]osc.LinkRouteTable{{SubnetId: ptr.To("subnet-kw")}}},
+				}),
+				mockGetNetAccessPoint("vpc-foo", "api", &osc.NetAccessPoint{NetAccessPointId: ptr.To("vpce-api"), RouteTableIds: &[]string{"rtb-kcp", "rtb-kw"}}),
+				mockLinkNetAccessPointRouteTables("vpce-api", []string{"rtb-public"}),
+				mockGetNetAccessPoint("vpc-foo", "eim", &osc.NetAccessPoint{NetAccessPointId: ptr.To("vpce-eim"), RouteTableIds: &[]string{"rtb-public", "rtb-kcp", "rtb-kw"}}),
+				mockGetNetAccessPoint("vpc-foo", "lbu", &osc.NetAccessPoint{NetAccessPointId: ptr.To("vpce-lbu"), RouteTableIds: &[]string{"rtb-public", "rtb-kcp", "rtb-kw"}}),
+				mockGetNetAccessPoint("vpc-foo", "oos", &osc.NetAccessPoint{NetAccessPointId: ptr.To("vpce-oos"), RouteTableIds: &[]string{"rtb-public", "rtb-kcp", "rtb-kw"}}),
+				mockGetNetAccessPoint("vpc-foo", "kms", &osc.NetAccessPoint{NetAccessPointId: ptr.To("vpce-kms"), RouteTableIds: &[]string{"rtb-public", "rtb-kcp", "rtb-kw"}}),
+			},
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionTrue(infrastructurev1beta1.AirgapPreflightReadyCondition),
+			},
+		},
+		{
+			name:            "in airgapped mode, missing images are reported",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAirgapped(),
+				patchEnableNetPeering(),
+				patchReconciled(infrastructurev1beta1.ReconcilerNetPeering, infrastructurev1beta1.ReconcilerNetPeeringRoutes, infrastructurev1beta1.ReconcilerNetAccessPoint),
+			},
+			kubeObjects: machineObjects(t, "base-worker", "base-worker"),
+			mockFuncs: []mockFunc{
+				mockImageNotFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234"),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionFalse(infrastructurev1beta1.AirgapPreflightReadyCondition, infrastructurev1beta1.AirgapPrerequisitesMissingReason),
+			},
+		},
+		{
+			name:            "in airgapped mode, preflight errors are reported and do not block the reconciliation",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAirgapped(),
+				patchEnableNetPeering(),
+				patchReconciled(infrastructurev1beta1.ReconcilerNetPeering, infrastructurev1beta1.ReconcilerNetPeeringRoutes, infrastructurev1beta1.ReconcilerNetAccessPoint),
+			},
+			kubeObjects: machineObjects(t, "base-worker", "base-worker"),
+			mockFuncs: []mockFunc{
+				mockImageByNameError("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", errors.New("OAPI error")),
+			},
+			requeue: true,
+			clusterAsserts: []assertOSCClusterFunc{
+				assertConditionFalse(infrastructurev1beta1.AirgapPreflightReadyCondition, infrastructurev1beta1.AirgapPreflightFailedReason),
+			},
+		},
+		{
+			name:            "in airgapped mode, prerequisites are not checked again for the same generation",
+			clusterSpec:     "ready-1.0",
+			clusterBaseSpec: "base",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAirgapped(),
+				patchEnableNetPeering(),
+				patchReconciled(infrastructurev1beta1.ReconcilerNetPeering, infrastructurev1beta1.ReconcilerNetPeeringRoutes, infrastructurev1beta1.ReconcilerNetAccessPoint,
+					infrastructurev1beta1.ReconcilerAirgapPreflight),
+			},
+			kubeObjects: machineObjects(t, "base-worker", "base-worker"),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2743,6 +3888,180 @@ func TestReconcileOSCCluster_Delete(t *testing.T) {
 			},
 			assertDeleted: true,
 		},
+		{
+			name:           "Deleting a cluster with a VPN waits for the VPN to be deleted",
+			clusterSpec:    "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{patchDeleteCluster(), patchVpn(infrastructurev1beta1.OscVpnConnection{Name: "onprem", PublicIp: "198.51.100.1"})},
+			kubeObjects:    []client.Object{vpnSecret(map[string]string{"onprem": "<config/>"})},
+			mockFuncs: []mockFunc{
+				mockGetLoadBalancer("test-cluster-api-k8s", nil),
+				mockListNatServices("vpc-24ba90ce", nil),
+				mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+				mockReadTagByNameFound(tag.VpnConnectionResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520", "vpn-foo"),
+				mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("available")}),
+				mockDeleteVpnConnection("vpn-foo"),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetLoadBalancer("test-cluster-api-k8s", nil),
+					mockListNatServices("vpc-24ba90ce", nil),
+					mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+					mockGetVpnConnection(osc.VpnConnection{VpnConnectionId: ptr.To("vpn-foo"), State: ptr.To("deleted")}),
+					mockReadTagByNameFound(tag.ClientGatewayResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520", "cgw-foo"),
+					mockGetClientGateway("cgw-foo"),
+					mockDeleteClientGateway("cgw-foo"),
+					mockReadOwnedByTag(tag.VirtualGatewayResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Tag{ResourceId: ptr.To("vgw-foo")}),
+					mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-24ba90ce"), State: ptr.To("attached")}}),
+					mockUnlinkVirtualGateway("vgw-foo", "vpc-24ba90ce"),
+				},
+				requeue: true,
+				next: &testcase{
+					mockFuncs: []mockFunc{
+						mockGetLoadBalancer("test-cluster-api-k8s", nil),
+						mockListNatServices("vpc-24ba90ce", nil),
+						mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+						mockReadTagByNameNoneFound(tag.VpnConnectionResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+						mockReadTagByNameNoneFound(tag.ClientGatewayResourceType, "vpn-onprem-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+						mockGetVirtualGateway("vgw-foo", []osc.NetToVirtualGatewayLink{{NetId: ptr.To("vpc-24ba90ce"), State: ptr.To("detached")}}),
+						mockDeleteVirtualGateway("vgw-foo"),
+
+						mockGetRouteTablesFromNet("vpc-24ba90ce", nil),
+						mockGetSecurityGroupsFromNet("vpc-24ba90ce", nil),
+						mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+						mockUnlinkInternetService("igw-c3c49899", "vpc-24ba90ce"),
+						mockDeleteInternetService("igw-c3c49899"),
+						mockSubnetFound("subnet-c1a282b0"),
+						mockDeleteSubnet("subnet-c1a282b0"),
+						mockSubnetFound("subnet-1555ea91"),
+						mockDeleteSubnet("subnet-1555ea91"),
+						mockSubnetFound("subnet-174f5ec4"),
+						mockDeleteSubnet("subnet-174f5ec4"),
+						mockNetFound("vpc-24ba90ce"),
+						mockDeleteNet("vpc-24ba90ce"),
+					},
+					assertDeleted: true,
+					kubeAsserts: []assertKubeFunc{
+						assertVpnSecretDeleted(),
+					},
+				},
+			},
+		},
+		{
+			name:           "Deleting a cluster with a DirectLink deletes the interface and its routes",
+			clusterSpec:    "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{patchDeleteCluster(), patchDirectLink(infrastructurev1beta1.OscDirectLink{DirectLinkId: "dxcon-foo", Vlan: 42})},
+			mockFuncs: []mockFunc{
+				mockGetLoadBalancer("test-cluster-api-k8s", nil),
+				mockListNatServices("vpc-24ba90ce", nil),
+				mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+				mockListDirectLinkInterfaces("dxcon-foo", []osc.DirectLinkInterfaces{{
+					DirectLinkInterfaceId: ptr.To("dxvif-foo"), DirectLinkInterfaceName: ptr.To("directlink-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+					VirtualGatewayId: ptr.To("vgw-foo"), State: ptr.To("available"),
+				}}),
+				mockGetRouteTablesFromNet("vpc-24ba90ce", []osc.RouteTable{{
+					RouteTableId: ptr.To("rtb-0a4640a6"),
+					Routes: &[]osc.Route{{
+						DestinationIpRange: ptr.To("192.168.0.0/16"), GatewayId: ptr.To("vgw-foo"), CreationMethod: ptr.To("CreateRoute"),
+					}},
+				}}),
+				mockDeleteRoute("rtb-0a4640a6", "192.168.0.0/16"),
+				mockDeleteDirectLinkInterface("dxvif-foo"),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetLoadBalancer("test-cluster-api-k8s", nil),
+					mockListNatServices("vpc-24ba90ce", nil),
+					mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+					mockGetDirectLinkInterface(osc.DirectLinkInterfaces{DirectLinkInterfaceId: ptr.To("dxvif-foo"), State: ptr.To("deleted")}),
+					mockReadOwnedByTag(tag.VirtualGatewayResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Tag{ResourceId: ptr.To("vgw-foo")}),
+					mockGetVirtualGateway("vgw-foo", nil),
+					mockDeleteVirtualGateway("vgw-foo"),
+
+					mockGetRouteTablesFromNet("vpc-24ba90ce", nil),
+					mockGetSecurityGroupsFromNet("vpc-24ba90ce", nil),
+					mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+					mockUnlinkInternetService("igw-c3c49899", "vpc-24ba90ce"),
+					mockDeleteInternetService("igw-c3c49899"),
+					mockSubnetFound("subnet-c1a282b0"),
+					mockDeleteSubnet("subnet-c1a282b0"),
+					mockSubnetFound("subnet-1555ea91"),
+					mockDeleteSubnet("subnet-1555ea91"),
+					mockSubnetFound("subnet-174f5ec4"),
+					mockDeleteSubnet("subnet-174f5ec4"),
+					mockNetFound("vpc-24ba90ce"),
+					mockDeleteNet("vpc-24ba90ce"),
+				},
+				assertDeleted: true,
+			},
+		},
+		{
+			name:           "Deleting a cluster with DHCP options deletes them after the net",
+			clusterSpec:    "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{patchDeleteCluster(), patchDhcpOptions(infrastructurev1beta1.OscDhcpOptions{DomainName: "corp.example.com"})},
+			mockFuncs: []mockFunc{
+				mockGetLoadBalancer("test-cluster-api-k8s", nil),
+				mockListNatServices("vpc-24ba90ce", nil),
+				mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+				mockGetRouteTablesFromNet("vpc-24ba90ce", nil),
+				mockGetSecurityGroupsFromNet("vpc-24ba90ce", nil),
+				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+				mockUnlinkInternetService("igw-c3c49899", "vpc-24ba90ce"),
+				mockDeleteInternetService("igw-c3c49899"),
+				mockSubnetFound("subnet-c1a282b0"),
+				mockDeleteSubnet("subnet-c1a282b0"),
+				mockSubnetFound("subnet-1555ea91"),
+				mockDeleteSubnet("subnet-1555ea91"),
+				mockSubnetFound("subnet-174f5ec4"),
+				mockDeleteSubnet("subnet-174f5ec4"),
+				mockNetFound("vpc-24ba90ce"),
+				mockDeleteNet("vpc-24ba90ce"),
+				mockReadOwnedByTag(tag.DhcpOptionsResourceType, "9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Tag{ResourceId: ptr.To("dopt-foo")}),
+				mockGetDhcpOptions(osc.DhcpOptionsSet{DhcpOptionsSetId: ptr.To("dopt-foo")}),
+				mockDeleteDhcpOptions("dopt-foo"),
+			},
+			assertDeleted: true,
+		},
+		{
+			name:        "Deleting a cluster with additional netPeerings deletes them",
+			clusterSpec: "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{patchDeleteCluster(), patchAdditionalNetPeerings(infrastructurev1beta1.OscAdditionalNetPeering{
+				Name:                "shared",
+				NetId:               "vpc-shared",
+				DestinationIpRanges: []string{"10.100.0.0/16"},
+			})},
+			mockFuncs: []mockFunc{
+				mockGetLoadBalancer("test-cluster-api-k8s", nil),
+				mockListNatServices("vpc-24ba90ce", nil),
+				mockListNetAccessPoints("vpc-24ba90ce", nil),
+
+				mockReadTagByNameFound(tag.NetPeeringResourceType, "netpeering-shared-9e1db9c4-bf0a-4583-8999-203ec002c520", "pcx-shared"),
+				mockGetNetPeeringId("pcx-shared", "active"),
+				mockDeleteNetPeering("pcx-shared"),
+
+				mockGetRouteTablesFromNet("vpc-24ba90ce", nil),
+				mockGetSecurityGroupsFromNet("vpc-24ba90ce", nil),
+				mockInternetServiceFound("vpc-24ba90ce", "igw-c3c49899"),
+				mockUnlinkInternetService("igw-c3c49899", "vpc-24ba90ce"),
+				mockDeleteInternetService("igw-c3c49899"),
+				mockSubnetFound("subnet-c1a282b0"),
+				mockDeleteSubnet("subnet-c1a282b0"),
+				mockSubnetFound("subnet-1555ea91"),
+				mockDeleteSubnet("subnet-1555ea91"),
+				mockSubnetFound("subnet-174f5ec4"),
+				mockDeleteSubnet("subnet-174f5ec4"),
+				mockNetFound("vpc-24ba90ce"),
+				mockDeleteNet("vpc-24ba90ce"),
+			},
+			assertDeleted: true,
+		},
 		{
 			name:           "Delete securityGroupRules with securityGroups before deleting securityGroups",
 			clusterSpec:    "ready-0.4",
@@ -2803,6 +4122,23 @@ func TestReconcileOSCCluster_Delete(t *testing.T) {
 			},
 			assertDeleted: true,
 		},
+		{
+			name:        "Deleting a cluster with controlplane NICs",
+			clusterSpec: "ready-0.4",
+			clusterPatches: []patchOSCClusterFunc{
+				patchDeleteCluster(),
+				patchUseExistingNet(),
+				patchUseExistingSecurityGroups(),
+				patchControlPlaneNics(infrastructurev1beta1.OscNicSlot{Name: "kcp"}),
+				patchControlPlaneNicStatus("kcp", "eni-kcp"),
+			},
+			mockFuncs: []mockFunc{
+				mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-k8s-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockDeleteLoadBalancer("test-cluster-api-k8s"),
+				mockDeleteNic("eni-kcp"),
+			},
+			assertDeleted: true,
+		},
 		{
 			name:        "Deleting a cluster based on an existing network & security groups",
 			clusterSpec: "ready-0.4",
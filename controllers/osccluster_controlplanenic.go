@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileControlPlaneNics creates the NICs used by controlplane VMs, one per slot.
+func (r *OscClusterReconciler) reconcileControlPlaneNics(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerControlPlaneNic) {
+		log.V(4).Info("No need for controlplane nic reconciliation")
+		return reconcile.Result{}, nil
+	}
+	log.V(4).Info("Reconciling controlplane nics")
+	var securityGroupIds []string
+	for _, slot := range clusterScope.GetControlPlaneNicSlots() {
+		nicId, err := r.Tracker.getControlPlaneNicId(ctx, slot, clusterScope)
+		switch {
+		case errors.Is(err, ErrNoResourceFound):
+		case err != nil:
+			return reconcile.Result{}, fmt.Errorf("get existing: %w", err)
+		default:
+			log.V(4).Info("Found existing controlplane nic", "slot", slot.Name, "nicId", nicId)
+			continue
+		}
+		subnetSpec, err := clusterScope.GetSubnet("", infrastructurev1beta1.RoleControlPlane, slot.SubregionName)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("slot %s: %w", slot.Name, err)
+		}
+		subnetId, err := r.Tracker.getSubnetId(ctx, subnetSpec, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot get subnet: %w", err)
+		}
+		if securityGroupIds == nil {
+			securityGroups, err := clusterScope.GetSecurityGroupsFor(nil, infrastructurev1beta1.RoleControlPlane)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot find securityGroup: %w", err)
+			}
+			for _, sgSpec := range securityGroups {
+				securityGroupId, err := r.Tracker.getSecurityGroupId(ctx, sgSpec, clusterScope)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				securityGroupIds = append(securityGroupIds, securityGroupId)
+			}
+		}
+		log.V(3).Info("Creating controlplane nic", "slot", slot.Name, "subnetId", subnetId, "privateIp", slot.PrivateIp)
+		nic, err := r.Cloud.Nic(clusterScope.Tenant).CreateNic(ctx, subnetId, securityGroupIds, slot.PrivateIp, clusterScope.GetUID(), clusterScope.GetControlPlaneNicName(slot))
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot create controlplane nic: %w", err)
+		}
+		log.V(2).Info("Created controlplane nic", "slot", slot.Name, "nicId", nic.GetNicId())
+		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.ControlPlaneNicCreatedReason, "Controlplane NIC created for slot %s", slot.Name)
+		r.Tracker.setControlPlaneNicId(clusterScope, slot, nic.GetNicId())
+	}
+	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerControlPlaneNic)
+	return reconcile.Result{}, nil
+}
+
+// reconcileDeleteControlPlaneNics deletes the NICs used by controlplane VMs.
+func (r *OscClusterReconciler) reconcileDeleteControlPlaneNics(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	for _, slot := range clusterScope.GetControlPlaneNicSlots() {
+		nicId, err := r.Tracker.getControlPlaneNicId(ctx, slot, clusterScope)
+		switch {
+		case errors.Is(err, ErrNoResourceFound):
+			log.V(4).Info("The controlplane nic is already deleted", "slot", slot.Name)
+			continue
+		case err != nil:
+			return reconcile.Result{}, err
+		}
+		log.V(2).Info("Deleting controlplane nic", "slot", slot.Name, "nicId", nicId)
+		err = r.Cloud.Nic(clusterScope.Tenant).DeleteNic(ctx, nicId)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("delete controlplane nic: %w", err)
+		}
+		delete(clusterScope.GetResources().Nic, slot.Name)
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,193 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const defaultDirectLinkBgpAsn = 65000
+
+// isDirectLinkRoute returns true if the route has been created by CAPOSC to route on-premise ranges to the virtual gateway.
+func isDirectLinkRoute(route osc.Route, vgwId string) bool {
+	return route.GetGatewayId() == vgwId && route.GetCreationMethod() == "CreateRoute"
+}
+
+// reconcileDirectLinkRoutes adds routes to the on-premise ranges in all route tables, and removes routes to ranges no longer listed.
+func (r *OscClusterReconciler) reconcileDirectLinkRoutes(ctx context.Context, clusterScope *scope.ClusterScope, netId, vgwId string) error {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	rtbls, err := svc.GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return fmt.Errorf("list route tables: %w", err)
+	}
+	ranges := clusterScope.GetNetwork().DirectLink.OnPremiseRanges
+	for _, rtbl := range rtbls {
+		for _, ipRange := range ranges {
+			if slices.ContainsFunc(rtbl.GetRoutes(), func(route osc.Route) bool {
+				return route.GetDestinationIpRange() == ipRange && route.GetGatewayId() == vgwId
+			}) {
+				continue
+			}
+			log.V(3).Info("Creating route to virtual gateway", "routeTableId", rtbl.GetRouteTableId(), "IPRange", ipRange)
+			_, err := svc.CreateRoute(ctx, ipRange, rtbl.GetRouteTableId(), vgwId, "gateway")
+			if err != nil {
+				return fmt.Errorf("cannot create route: %w", err)
+			}
+		}
+		for _, route := range rtbl.GetRoutes() {
+			if !isDirectLinkRoute(route, vgwId) || slices.Contains(ranges, route.GetDestinationIpRange()) {
+				continue
+			}
+			log.V(3).Info("Deleting route to virtual gateway", "routeTableId", rtbl.GetRouteTableId(), "IPRange", route.GetDestinationIpRange())
+			err := svc.DeleteRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId())
+			if err != nil {
+				return fmt.Errorf("cannot delete route: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// hasDirectLink returns true if a DirectLink is set, or if its interface remains after the DirectLink has been removed from the spec.
+func hasDirectLink(clusterScope *scope.ClusterScope) bool {
+	return clusterScope.GetNetwork().DirectLink.DirectLinkId != "" || len(clusterScope.GetResources().DirectLinkInterface) > 0
+}
+
+// reconcileDirectLink reconciles the DirectLink interface of the cluster, and reports its state in the DirectLinkReady condition.
+func (r *OscClusterReconciler) reconcileDirectLink(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := clusterScope.GetNetwork().DirectLink
+	svc := r.Cloud.DirectLink(clusterScope.Tenant)
+	dli, err := r.Tracker.getDirectLinkInterface(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("get existing directLink interface: %w", err)
+	}
+	if dli == nil || clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerDirectLink) {
+		log.V(4).Info("Reconciling directLink")
+		netId, err := r.Tracker.getNetId(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		vgw, err := r.reconcileVirtualGateway(ctx, clusterScope, netId)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if dli == nil {
+			asn := spec.BgpAsn
+			if asn == 0 {
+				asn = defaultDirectLinkBgpAsn
+			}
+			req := osc.DirectLinkInterface{
+				BgpAsn:                  asn,
+				DirectLinkInterfaceName: clusterScope.GetDirectLinkInterfaceName(),
+				VirtualGatewayId:        vgw.GetVirtualGatewayId(),
+				Vlan:                    spec.Vlan,
+			}
+			if spec.BgpKeyFromSecret != "" {
+				key, err := clusterScope.GetSecretValue(ctx, spec.BgpKeyFromSecret, cmp.Or(spec.BgpKeySecretKey, "bgpKey"))
+				if err != nil {
+					return reconcile.Result{}, fmt.Errorf("get bgp key: %w", err)
+				}
+				req.BgpKey = &key
+			}
+			if spec.ClientPrivateIp != "" {
+				req.ClientPrivateIp = &spec.ClientPrivateIp
+			}
+			if spec.OutscalePrivateIp != "" {
+				req.OutscalePrivateIp = &spec.OutscalePrivateIp
+			}
+			log.V(3).Info("Creating directLink interface", "directLinkId", spec.DirectLinkId)
+			dli, err = svc.CreateDirectLinkInterface(ctx, spec.DirectLinkId, req)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot create directLink interface: %w", err)
+			}
+			log.V(2).Info("Created directLink interface", "directLinkInterfaceId", dli.GetDirectLinkInterfaceId())
+			r.Tracker.setDirectLinkInterfaceId(clusterScope, dli.GetDirectLinkInterfaceId())
+			r.Recorder.Event(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.DirectLinkCreatedReason, "DirectLink interface created")
+		}
+		err = r.reconcileDirectLinkRoutes(ctx, clusterScope, netId, vgw.GetVirtualGatewayId())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerDirectLink)
+	}
+
+	// The interface state is checked on each reconciliation, BGP sessions being established out of band.
+	switch dli.GetState() {
+	case "available":
+		conditions.MarkTrue(clusterScope.OscCluster, infrastructurev1beta1.DirectLinkReadyCondition)
+		return reconcile.Result{}, nil
+	case "down":
+		conditions.MarkFalse(clusterScope.OscCluster, infrastructurev1beta1.DirectLinkReadyCondition, infrastructurev1beta1.DirectLinkInterfaceNotAvailableReason,
+			clusterv1.ConditionSeverityWarning, "DirectLink interface %s is down", dli.GetDirectLinkInterfaceId())
+	default:
+		conditions.MarkFalse(clusterScope.OscCluster, infrastructurev1beta1.DirectLinkReadyCondition, infrastructurev1beta1.DirectLinkInterfaceNotAvailableReason,
+			clusterv1.ConditionSeverityInfo, "DirectLink interface %s is %s", dli.GetDirectLinkInterfaceId(), dli.GetState())
+	}
+	log.V(3).Info("DirectLink interface is not available", "directLinkInterfaceId", dli.GetDirectLinkInterfaceId(), "state", dli.GetState())
+	return reconcile.Result{RequeueAfter: time.Minute}, nil
+}
+
+// reconcileDeleteDirectLink deletes the on-premise routes and the DirectLink interface of the cluster.
+func (r *OscClusterReconciler) reconcileDeleteDirectLink(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	dli, err := r.Tracker.getDirectLinkInterface(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		log.V(4).Info("The directLink interface is already deleted")
+		delete(clusterScope.GetResources().DirectLinkInterface, defaultResource)
+		return reconcile.Result{}, nil
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("get directLink interface: %w", err)
+	case dli.GetState() == "deleting":
+		log.V(3).Info("Waiting for directLink interface to be deleted", "directLinkInterfaceId", dli.GetDirectLinkInterfaceId())
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	netId, err := r.Tracker.getNetId(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("get net: %w", err)
+	}
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	rtbls, err := svc.GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("list route tables: %w", err)
+	}
+	for _, rtbl := range rtbls {
+		for _, route := range rtbl.GetRoutes() {
+			if !isDirectLinkRoute(route, dli.GetVirtualGatewayId()) {
+				continue
+			}
+			log.V(3).Info("Deleting route to virtual gateway", "routeTableId", rtbl.GetRouteTableId(), "IPRange", route.GetDestinationIpRange())
+			err := svc.DeleteRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId())
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot delete route: %w", err)
+			}
+		}
+	}
+	log.V(2).Info("Deleting directLink interface", "directLinkInterfaceId", dli.GetDirectLinkInterfaceId())
+	err = r.Cloud.DirectLink(clusterScope.Tenant).DeleteDirectLinkInterface(ctx, dli.GetDirectLinkInterfaceId())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot delete directLink interface: %w", err)
+	}
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
@@ -6,17 +6,25 @@ SPDX-License-Identifier: BSD-3-Clause
 package controllers_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
 	tag "github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	"github.com/outscale/cluster-api-provider-outscale/controllers"
 	osc "github.com/outscale/osc-sdk-go/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -66,6 +74,19 @@ func patchManualSGs(sgs []infrastructurev1beta1.OscSecurityGroup) patchOSCCluste
 	}
 }
 
+// patchSecurityGroupsSpec sets manual securityGroups without changing the generation, to be used with patchReconcileAgain.
+func patchSecurityGroupsSpec(sgs ...infrastructurev1beta1.OscSecurityGroup) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.SecurityGroups = sgs
+	}
+}
+
+func patchSecurityGroupRuleQuota(quota int) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.SecurityGroupRuleQuota = quota
+	}
+}
+
 func patchAddSGRule(name string, r infrastructurev1beta1.OscSecurityGroupRule) patchOSCClusterFunc {
 	return func(m *infrastructurev1beta1.OscCluster) {
 		m.Generation++
@@ -103,6 +124,45 @@ func patchNATIPFromPool(name string) patchOSCClusterFunc {
 	}
 }
 
+func patchNatInstance(spec infrastructurev1beta1.OscNatInstance) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.NatService.Mode = infrastructurev1beta1.NatServiceModeInstance
+		m.Spec.Network.NatService.Instance = spec
+	}
+}
+
+func patchProxy(proxy infrastructurev1beta1.OscProxy) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Proxy = proxy
+	}
+}
+
+func patchBootstrapStorage(bucket string) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.BootstrapStorage = infrastructurev1beta1.OscBootstrapStorage{Enable: true, Bucket: bucket}
+	}
+}
+
+func patchAirgapped() patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.Mode = infrastructurev1beta1.NetworkModeAirgapped
+	}
+}
+
+func patchEnableNetPeering() patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.NetPeering.Enable = true
+	}
+}
+
+func patchReconciled(reconcilers ...infrastructurev1beta1.Reconciler) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		for _, reconciler := range reconcilers {
+			m.Status.ReconcilerGeneration[reconciler] = m.Generation
+		}
+	}
+}
+
 func patchUseCredentials(c infrastructurev1beta1.OscCredentials) patchOSCClusterFunc {
 	return func(m *infrastructurev1beta1.OscCluster) {
 		m.Spec.Credentials = c
@@ -119,6 +179,107 @@ func patchDisableLB() patchOSCClusterFunc {
 	}
 }
 
+func patchControlPlaneNics(slots ...infrastructurev1beta1.OscNicSlot) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.ControlPlaneNics = infrastructurev1beta1.OscControlPlaneNics{
+			Enable: true,
+			Slots:  slots,
+		}
+	}
+}
+
+func patchControlPlaneNicStatus(slot, nicId string) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		if m.Status.Resources.Nic == nil {
+			m.Status.Resources.Nic = map[string]string{}
+		}
+		m.Status.Resources.Nic[slot] = nicId
+	}
+}
+
+func patchResources(rsrc infrastructurev1beta1.OscClusterResources) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Status.Resources = rsrc
+	}
+}
+
+func patchReconcileAgain(reconciler infrastructurev1beta1.Reconciler) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		delete(m.Status.ReconcilerGeneration, reconciler)
+	}
+}
+
+func patchAddSubnet(spec infrastructurev1beta1.OscSubnet) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.Subnets = append(m.Spec.Network.Subnets, spec)
+	}
+}
+
+func patchVpn(conns ...infrastructurev1beta1.OscVpnConnection) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.Vpn = infrastructurev1beta1.OscVpn{Enable: true, Connections: conns}
+	}
+}
+
+func patchDirectLink(dl infrastructurev1beta1.OscDirectLink) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.DirectLink = dl
+	}
+}
+
+func patchAdditionalNetPeerings(nps ...infrastructurev1beta1.OscAdditionalNetPeering) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.AdditionalNetPeerings = nps
+	}
+}
+
+func patchDhcpOptions(spec infrastructurev1beta1.OscDhcpOptions) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.Net.DhcpOptions = spec
+	}
+}
+
+func patchSubnetPlan(plan infrastructurev1beta1.OscSubnetPlan) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.SubnetPlan = &plan
+	}
+}
+
+// periodicRequeue is the minimal interval of periodic checks (subnet capacity, security group audits).
+// Requeues at that interval are not reported as requeues in tests.
+const periodicRequeue = 4 * time.Minute
+
+// patchSubnetCapacityRefreshed is applied to all clusters, so that tests do not need to mock the refresh of subnet capacity.
+func patchSubnetCapacityRefreshed() patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Status.SubnetCapacityUpdatedAt = ptr.To(metav1.Now())
+	}
+}
+
+func patchSecurityGroupsAudited() patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Status.SecurityGroupAuditedAt = ptr.To(metav1.Now())
+	}
+}
+
+func patchAcceptedNetPeerings(ids ...string) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Status.AcceptedNetPeerings = ids
+	}
+}
+
+func patchSubnetCapacityOutdated() patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Status.SubnetCapacityUpdatedAt = nil
+	}
+}
+
+func patchLoadBalancerListeners(listeners ...infrastructurev1beta1.OscLoadBalancerListener) patchOSCClusterFunc {
+	return func(m *infrastructurev1beta1.OscCluster) {
+		m.Spec.Network.LoadBalancer.Listeners = listeners
+	}
+}
+
 func mockNetFound(id string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.NetMock.EXPECT().
@@ -277,6 +438,14 @@ func mockCreateSecurityGroupRule(sg, flow, proto, ipRanges string, portFrom, por
 	}
 }
 
+func mockCreateSecurityGroupMemberRule(sg, flow, proto, sgMember string, portFrom, portTo int32) mockFunc {
+	return func(s *MockCloudServices) {
+		s.SecurityGroupMock.EXPECT().
+			CreateSecurityGroupRule(gomock.Any(), sg, flow, proto, "", sgMember, portFrom, portTo).
+			Return(&osc.SecurityGroup{SecurityGroupId: ptr.To(sg)}, nil)
+	}
+}
+
 func mockDeleteSecurityGroupRule(sg, flow, proto, ipRange, sgMember string, fromPort int32, toPort int32) mockFunc {
 	return func(s *MockCloudServices) {
 		s.SecurityGroupMock.EXPECT().
@@ -325,6 +494,22 @@ func mockCreateRoute(routeTableId, dest, resourceId, resourceType string) mockFu
 	}
 }
 
+func mockUpdateRoute(routeTableId, dest, resourceId, resourceType string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.RouteTableMock.EXPECT().
+			UpdateRoute(gomock.Any(), gomock.Eq(dest), gomock.Eq(routeTableId), gomock.Eq(resourceId), gomock.Eq(resourceType)).
+			Return(nil)
+	}
+}
+
+func mockDeleteRoute(routeTableId, dest string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.RouteTableMock.EXPECT().
+			DeleteRoute(gomock.Any(), gomock.Eq(dest), gomock.Eq(routeTableId)).
+			Return(nil)
+	}
+}
+
 func mockUnlinkRouteTable(id string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.RouteTableMock.EXPECT().
@@ -400,6 +585,10 @@ func mockListNetAccessPoints(netId string, naps []osc.NetAccessPoint) mockFunc {
 }
 
 func mockLoadBalancerFound(name, nameTag string) mockFunc {
+	return mockLoadBalancerFoundWith(name, nameTag, []osc.Listener{defaultListener}, defaultHealthCheck)
+}
+
+func mockLoadBalancerFoundWith(name, nameTag string, listeners []osc.Listener, healthCheck osc.HealthCheck) mockFunc {
 	return func(s *MockCloudServices) {
 		s.LoadBalancerMock.EXPECT().
 			GetLoadBalancer(gomock.Any(), gomock.Eq(name)).
@@ -407,11 +596,37 @@ func mockLoadBalancerFound(name, nameTag string) mockFunc {
 				LoadBalancerName: ptr.To(name),
 				DnsName:          ptr.To(name + ".lbu.outscale.com"),
 				Tags:             &[]osc.ResourceTag{{Key: tag.NameKey, Value: nameTag}},
-				HealthCheck:      &osc.HealthCheck{},
+				Listeners:        &listeners,
+				HealthCheck:      &healthCheck,
 			}, nil)
 	}
 }
 
+var (
+	defaultListener = osc.Listener{
+		BackendPort:          ptr.To[int32](6443),
+		BackendProtocol:      ptr.To("TCP"),
+		LoadBalancerPort:     ptr.To[int32](6443),
+		LoadBalancerProtocol: ptr.To("TCP"),
+	}
+	defaultHealthCheck = osc.HealthCheck{
+		CheckInterval:      10,
+		HealthyThreshold:   2,
+		Port:               6443,
+		Protocol:           "TCP",
+		Timeout:            10,
+		UnhealthyThreshold: 3,
+	}
+	v04HealthCheck = osc.HealthCheck{
+		CheckInterval:      5,
+		HealthyThreshold:   5,
+		Port:               6443,
+		Protocol:           "TCP",
+		Timeout:            5,
+		UnhealthyThreshold: 2,
+	}
+)
+
 func mockCreateLoadBalancer(loadBalancerName, loadBalancerType, subnetId, securityGroupId string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.LoadBalancerMock.EXPECT().
@@ -440,6 +655,22 @@ func mockConfigureHealthCheck(loadBalancerName string) mockFunc {
 	}
 }
 
+func mockCreateLoadBalancerListeners(loadBalancerName string, listeners []infrastructurev1beta1.OscLoadBalancerListener) mockFunc {
+	return func(s *MockCloudServices) {
+		s.LoadBalancerMock.EXPECT().
+			CreateLoadBalancerListeners(gomock.Any(), gomock.Eq(loadBalancerName), gomock.Eq(listeners)).
+			Return(nil)
+	}
+}
+
+func mockDeleteLoadBalancerListeners(loadBalancerName string, ports []int32) mockFunc {
+	return func(s *MockCloudServices) {
+		s.LoadBalancerMock.EXPECT().
+			DeleteLoadBalancerListeners(gomock.Any(), gomock.Eq(loadBalancerName), gomock.Eq(ports)).
+			Return(nil)
+	}
+}
+
 func mockCreateLoadBalancerTag(loadBalancerName, nameTag string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.LoadBalancerMock.EXPECT().
@@ -490,6 +721,47 @@ func mockCreateVmBastion(vmId, subnetId string, securityGroupIds, privateIps []s
 	}
 }
 
+func mockGetNatInstance(vmId, state string, sourceDestChecked bool) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			GetVm(gomock.Any(), gomock.Eq(vmId)).
+			Return(&osc.Vm{VmId: &vmId, State: &state, IsSourceDestChecked: &sourceDestChecked}, nil)
+	}
+}
+
+func mockGetNatInstanceNotFound(vmId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			GetVm(gomock.Any(), gomock.Eq(vmId)).
+			Return(nil, nil)
+	}
+}
+
+func mockCreateVmNat(spec infrastructurev1beta1.OscNatInstance, subnetId string, securityGroupIds []string, vmName, clientToken, imageId, ipRange, publicIp, vmId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			CreateVmNat(gomock.Any(), gomock.Eq(&spec), gomock.Eq(subnetId), gomock.Eq(securityGroupIds), gomock.Eq(vmName), gomock.Eq(clientToken),
+				gomock.Eq(imageId), gomock.Eq(ipRange), gomock.Eq(map[string]string{compute.AutoAttachExternapIPTag: publicIp})).
+			Return(&osc.Vm{VmId: &vmId, State: ptr.To("pending")}, nil)
+	}
+}
+
+func mockGetPublicIp(publicIpId, publicIp string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.PublicIpMock.EXPECT().
+			GetPublicIp(gomock.Any(), gomock.Eq(publicIpId)).
+			Return(&osc.PublicIp{PublicIpId: &publicIpId, PublicIp: &publicIp}, nil)
+	}
+}
+
+func mockUpdateVmSourceDestCheck(vmId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			UpdateVmSourceDestCheck(gomock.Any(), gomock.Eq(vmId), gomock.Eq(false)).
+			Return(nil)
+	}
+}
+
 func mockCreateNetPeering(netID, mgmtNetID, mgmtAccountID, clusterID string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.NetPeeringMock.EXPECT().CreateNetPeering(gomock.Any(), gomock.Eq(netID), gomock.Eq(mgmtNetID), gomock.Eq(mgmtAccountID), gomock.Eq(clusterID)).
@@ -521,6 +793,72 @@ func mockGetNetPeering(state string) mockFunc {
 	}
 }
 
+func mockCreateNamedNetPeering(netID, peerNetID, peerAccountID, name, netPeeringId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NetPeeringMock.EXPECT().CreateNamedNetPeering(gomock.Any(), gomock.Eq(netID), gomock.Eq(peerNetID), gomock.Eq(peerAccountID), gomock.Eq(name)).
+			Return(&osc.NetPeering{
+				NetPeeringId: &netPeeringId,
+				State: &osc.NetPeeringState{
+					Name: ptr.To("pending-acceptance"),
+				},
+			}, nil)
+	}
+}
+
+func mockAcceptNetPeeringId(netPeeringId string, err error) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NetPeeringMock.EXPECT().AcceptNetPeering(gomock.Any(), gomock.Eq(netPeeringId)).
+			Return(err)
+	}
+}
+
+func mockGetNetPeeringId(netPeeringId, state string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NetPeeringMock.EXPECT().GetNetPeering(gomock.Any(), gomock.Eq(netPeeringId)).
+			Return(&osc.NetPeering{
+				NetPeeringId: &netPeeringId,
+				State: &osc.NetPeeringState{
+					Name: &state,
+				},
+			}, nil)
+	}
+}
+
+func mockDeleteNetPeering(netPeeringId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NetPeeringMock.EXPECT().DeleteNetPeering(gomock.Any(), gomock.Eq(netPeeringId)).
+			Return(nil)
+	}
+}
+
+func mockCreateDhcpOptions(spec infrastructurev1beta1.OscDhcpOptions, clusterID, name, dhcpOptionsSetId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DhcpOptionsMock.EXPECT().CreateDhcpOptions(gomock.Any(), gomock.Eq(spec), gomock.Eq(clusterID), gomock.Eq(name)).
+			Return(&osc.DhcpOptionsSet{DhcpOptionsSetId: &dhcpOptionsSetId}, nil)
+	}
+}
+
+func mockGetDhcpOptions(dhcp osc.DhcpOptionsSet) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DhcpOptionsMock.EXPECT().GetDhcpOptions(gomock.Any(), gomock.Eq(dhcp.GetDhcpOptionsSetId())).
+			Return(&dhcp, nil)
+	}
+}
+
+func mockLinkDhcpOptions(dhcpOptionsSetId, netId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DhcpOptionsMock.EXPECT().LinkDhcpOptions(gomock.Any(), gomock.Eq(dhcpOptionsSetId), gomock.Eq(netId)).
+			Return(nil)
+	}
+}
+
+func mockDeleteDhcpOptions(dhcpOptionsSetId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DhcpOptionsMock.EXPECT().DeleteDhcpOptions(gomock.Any(), gomock.Eq(dhcpOptionsSetId)).
+			Return(nil)
+	}
+}
+
 func mockCreateNetAccessPoint(netID, service, clusterID string, routeTables []string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.NetAccessPointMock.EXPECT().CreateNetAccessPoint(gomock.Any(), gomock.Eq(netID), gomock.Eq("eu-west-2"), gomock.Eq(service), gomock.Eq(routeTables), gomock.Eq(clusterID)).
@@ -528,6 +866,13 @@ func mockCreateNetAccessPoint(netID, service, clusterID string, routeTables []st
 	}
 }
 
+func mockLinkNetAccessPointRouteTables(netAccessPointId string, routeTables []string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NetAccessPointMock.EXPECT().LinkNetAccessPointRouteTables(gomock.Any(), gomock.Eq(netAccessPointId), gomock.Eq(routeTables)).
+			Return(nil)
+	}
+}
+
 func mockGetNetAccessPoint(netID, service string, nap *osc.NetAccessPoint) mockFunc {
 	return func(s *MockCloudServices) {
 		s.NetAccessPointMock.EXPECT().GetNetAccessPointFor(gomock.Any(), gomock.Eq(netID), gomock.Eq("eu-west-2"), gomock.Eq(service)).
@@ -535,6 +880,251 @@ func mockGetNetAccessPoint(netID, service string, nap *osc.NetAccessPoint) mockF
 	}
 }
 
+func mockCreateNic(subnetId string, securityGroupIds []string, privateIp, clusterID, name, nicId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NicMock.EXPECT().
+			CreateNic(gomock.Any(), gomock.Eq(subnetId), gomock.Eq(securityGroupIds), gomock.Eq(privateIp), gomock.Eq(clusterID), gomock.Eq(name)).
+			Return(&osc.Nic{NicId: &nicId, State: ptr.To("available")}, nil)
+	}
+}
+
+func mockDeleteNic(nicId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.NicMock.EXPECT().
+			DeleteNic(gomock.Any(), gomock.Eq(nicId)).
+			Return(nil)
+	}
+}
+
+func assertControlPlaneNic(slot, nicId string) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.Equal(t, nicId, c.Status.Resources.Nic[slot])
+	}
+}
+
+func mockListSubnets(subnetIds []string, subnets []osc.Subnet) mockFunc {
+	return func(s *MockCloudServices) {
+		s.SubnetMock.EXPECT().
+			ListSubnets(gomock.Any(), gomock.Eq(subnetIds)).
+			Return(subnets, nil)
+	}
+}
+
+func mockSubnetCapacity() mockFunc {
+	return func(s *MockCloudServices) {
+		s.SubnetMock.EXPECT().
+			ListSubnets(gomock.Any(), gomock.Any()).
+			Return(nil, nil)
+	}
+}
+
+func mockCreateVirtualGateway(clusterID, name, vgwId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			CreateVirtualGateway(gomock.Any(), gomock.Eq(clusterID), gomock.Eq(name)).
+			Return(&osc.VirtualGateway{VirtualGatewayId: &vgwId}, nil)
+	}
+}
+
+func mockGetVirtualGateway(vgwId string, links []osc.NetToVirtualGatewayLink) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			GetVirtualGateway(gomock.Any(), gomock.Eq(vgwId)).
+			Return(&osc.VirtualGateway{VirtualGatewayId: &vgwId, NetToVirtualGatewayLinks: &links}, nil)
+	}
+}
+
+func mockLinkVirtualGateway(vgwId, netId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			LinkVirtualGateway(gomock.Any(), gomock.Eq(vgwId), gomock.Eq(netId)).
+			Return(nil)
+	}
+}
+
+func mockUnlinkVirtualGateway(vgwId, netId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			UnlinkVirtualGateway(gomock.Any(), gomock.Eq(vgwId), gomock.Eq(netId)).
+			Return(nil)
+	}
+}
+
+func mockDeleteVirtualGateway(vgwId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			DeleteVirtualGateway(gomock.Any(), gomock.Eq(vgwId)).
+			Return(nil)
+	}
+}
+
+func mockUpdateRoutePropagation(vgwId, routeTableId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VirtualGatewayMock.EXPECT().
+			UpdateRoutePropagation(gomock.Any(), gomock.Eq(vgwId), gomock.Eq(routeTableId), gomock.Eq(true)).
+			Return(nil)
+	}
+}
+
+func mockCreateClientGateway(publicIp string, bgpAsn int32, clusterID, name, cgwId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			CreateClientGateway(gomock.Any(), gomock.Eq(publicIp), gomock.Eq(bgpAsn), gomock.Eq(clusterID), gomock.Eq(name)).
+			Return(&osc.ClientGateway{ClientGatewayId: &cgwId}, nil)
+	}
+}
+
+func mockGetClientGateway(cgwId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			GetClientGateway(gomock.Any(), gomock.Eq(cgwId)).
+			Return(&osc.ClientGateway{ClientGatewayId: &cgwId, State: ptr.To("available")}, nil)
+	}
+}
+
+func mockDeleteClientGateway(cgwId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			DeleteClientGateway(gomock.Any(), gomock.Eq(cgwId)).
+			Return(nil)
+	}
+}
+
+func mockCreateVpnConnection(cgwId, vgwId string, staticRoutesOnly bool, clusterID, name string, vpn osc.VpnConnection) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			CreateVpnConnection(gomock.Any(), gomock.Eq(cgwId), gomock.Eq(vgwId), gomock.Eq(staticRoutesOnly), gomock.Eq(clusterID), gomock.Eq(name)).
+			Return(&vpn, nil)
+	}
+}
+
+func mockGetVpnConnection(vpn osc.VpnConnection) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			GetVpnConnection(gomock.Any(), gomock.Eq(vpn.GetVpnConnectionId())).
+			Return(&vpn, nil)
+	}
+}
+
+func mockDeleteVpnConnection(vpnId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			DeleteVpnConnection(gomock.Any(), gomock.Eq(vpnId)).
+			Return(nil)
+	}
+}
+
+func mockCreateVpnConnectionRoute(vpnId, dest string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			CreateVpnConnectionRoute(gomock.Any(), gomock.Eq(vpnId), gomock.Eq(dest)).
+			Return(nil)
+	}
+}
+
+func mockDeleteVpnConnectionRoute(vpnId, dest string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VpnMock.EXPECT().
+			DeleteVpnConnectionRoute(gomock.Any(), gomock.Eq(vpnId), gomock.Eq(dest)).
+			Return(nil)
+	}
+}
+
+func mockListDirectLinkInterfaces(directLinkId string, dlis []osc.DirectLinkInterfaces) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DirectLinkMock.EXPECT().
+			ListDirectLinkInterfaces(gomock.Any(), gomock.Eq(directLinkId)).
+			Return(dlis, nil)
+	}
+}
+
+func mockGetDirectLinkInterface(dli osc.DirectLinkInterfaces) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DirectLinkMock.EXPECT().
+			GetDirectLinkInterface(gomock.Any(), gomock.Eq(dli.GetDirectLinkInterfaceId())).
+			Return(&dli, nil)
+	}
+}
+
+func mockCreateDirectLinkInterface(directLinkId string, req osc.DirectLinkInterface, dli osc.DirectLinkInterfaces) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DirectLinkMock.EXPECT().
+			CreateDirectLinkInterface(gomock.Any(), gomock.Eq(directLinkId), gomock.Eq(req)).
+			Return(&dli, nil)
+	}
+}
+
+func mockDeleteDirectLinkInterface(directLinkInterfaceId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.DirectLinkMock.EXPECT().
+			DeleteDirectLinkInterface(gomock.Any(), gomock.Eq(directLinkInterfaceId)).
+			Return(nil)
+	}
+}
+
+func mockListVmsFromSubnet(subnetId string, vms []osc.Vm) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			ListVmsFromSubnet(gomock.Any(), gomock.Eq(subnetId)).
+			Return(vms, nil)
+	}
+}
+
+func mockListVmsFromSecurityGroup(securityGroupId string, vms []osc.Vm) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			ListVmsFromSecurityGroup(gomock.Any(), gomock.Eq(securityGroupId)).
+			Return(vms, nil)
+	}
+}
+
+func mockListLoadBalancers(lbs []osc.LoadBalancer) mockFunc {
+	return func(s *MockCloudServices) {
+		s.LoadBalancerMock.EXPECT().
+			ListLoadBalancers(gomock.Any()).
+			Return(lbs, nil)
+	}
+}
+
+func assertConditionTrue(cond v1beta1.ConditionType) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.True(t, conditions.IsTrue(c, cond))
+	}
+}
+
+func assertConditionFalse(cond v1beta1.ConditionType, reason string) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.True(t, conditions.IsFalse(c, cond))
+		assert.Equal(t, reason, conditions.GetReason(c, cond))
+	}
+}
+
+func assertConditionUnset(cond v1beta1.ConditionType) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.False(t, conditions.Has(c, cond))
+	}
+}
+
+func assertAcceptedNetPeerings(ids ...string) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.Equal(t, ids, c.Status.AcceptedNetPeerings)
+	}
+}
+
+func assertConditionDrift(msg string) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.True(t, conditions.IsTrue(c, infrastructurev1beta1.SecurityGroupDriftCondition))
+		assert.Equal(t, msg, conditions.GetMessage(c, infrastructurev1beta1.SecurityGroupDriftCondition))
+	}
+}
+
+func assertSubnetCapacity(capacity []infrastructurev1beta1.OscSubnetCapacity) assertOSCClusterFunc {
+	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
+		assert.Equal(t, capacity, c.Status.SubnetCapacity)
+		assert.NotNil(t, c.Status.SubnetCapacityUpdatedAt)
+	}
+}
+
 func assertStatusClusterResources(rsrcs infrastructurev1beta1.OscClusterResources) assertOSCClusterFunc {
 	return func(t *testing.T, c *infrastructurev1beta1.OscCluster) {
 		assert.Equal(t, rsrcs, c.Status.Resources)
@@ -548,6 +1138,34 @@ func assertControlPlaneEndpoint(endpoint string, port int32) assertOSCClusterFun
 	}
 }
 
+func vpnSecret(configs map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-api-test", Name: "test-cluster-api-vpn"},
+		Data:       map[string][]byte{},
+	}
+	for name, config := range configs {
+		secret.Data[name] = []byte(config)
+	}
+	return secret
+}
+
+func assertVpnSecret(configs map[string]string) assertKubeFunc {
+	return func(t *testing.T, c client.Client) {
+		var secret corev1.Secret
+		err := c.Get(context.TODO(), client.ObjectKey{Namespace: "cluster-api-test", Name: "test-cluster-api-vpn"}, &secret)
+		require.NoError(t, err)
+		assert.Equal(t, vpnSecret(configs).Data, secret.Data)
+	}
+}
+
+func assertVpnSecretDeleted() assertKubeFunc {
+	return func(t *testing.T, c client.Client) {
+		var secret corev1.Secret
+		err := c.Get(context.TODO(), client.ObjectKey{Namespace: "cluster-api-test", Name: "test-cluster-api-vpn"}, &secret)
+		assert.True(t, apierrors.IsNotFound(err), "VPN secret must have been deleted")
+	}
+}
+
 func assertHasClusterFinalizer() assertOSCClusterFunc {
 	return func(t *testing.T, m *infrastructurev1beta1.OscCluster) {
 		assert.True(t, controllerutil.ContainsFinalizer(m, controllers.OscClusterFinalizer))
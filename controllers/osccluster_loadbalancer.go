@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
@@ -57,6 +58,13 @@ func (r *OscClusterReconciler) reconcileLoadBalancer(ctx context.Context, cluste
 		}
 	}
 
+	if loadbalancer != nil {
+		err := r.reconcileLoadBalancerListeners(ctx, clusterScope, &loadBalancerSpec, loadbalancer)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	if loadbalancer == nil {
 		subnetSpec, err := clusterScope.GetSubnet(loadBalancerSpec.SubnetName, infrastructurev1beta1.RoleLoadBalancer, "")
 		if err != nil {
@@ -105,7 +113,7 @@ func (r *OscClusterReconciler) reconcileLoadBalancer(ctx context.Context, cluste
 	controlPlaneEndpoint := loadbalancer.GetDnsName()
 	log.V(4).Info("Set controlPlaneEndpoint", "endpoint", controlPlaneEndpoint)
 
-	controlPlanePort := loadBalancerSpec.Listener.LoadBalancerPort
+	controlPlanePort := loadBalancerSpec.GetListeners()[0].LoadBalancerPort
 
 	clusterScope.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 		Host: controlPlaneEndpoint,
@@ -115,6 +123,69 @@ func (r *OscClusterReconciler) reconcileLoadBalancer(ctx context.Context, cluste
 	return reconcile.Result{}, nil
 }
 
+// reconcileLoadBalancerListeners creates, updates and deletes the listeners of an existing loadBalancer to match the spec,
+// and updates its healthCheck.
+func (r *OscClusterReconciler) reconcileLoadBalancerListeners(ctx context.Context, clusterScope *scope.ClusterScope,
+	loadBalancerSpec *infrastructurev1beta1.OscLoadBalancer, loadbalancer *osc.LoadBalancer) error {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.LoadBalancer(clusterScope.Tenant)
+	specs := loadBalancerSpec.GetListeners()
+	var deletes []int32
+	var creates []infrastructurev1beta1.OscLoadBalancerListener
+	for _, listener := range loadbalancer.GetListeners() {
+		if !slices.ContainsFunc(specs, func(spec infrastructurev1beta1.OscLoadBalancerListener) bool {
+			return listenerMatches(listener, spec)
+		}) {
+			deletes = append(deletes, listener.GetLoadBalancerPort())
+		}
+	}
+	for _, spec := range specs {
+		if !slices.ContainsFunc(loadbalancer.GetListeners(), func(listener osc.Listener) bool {
+			return listenerMatches(listener, spec)
+		}) {
+			creates = append(creates, spec)
+		}
+	}
+	if len(deletes) > 0 {
+		log.V(2).Info("Deleting loadBalancer listeners", "loadBalancerName", loadBalancerSpec.LoadBalancerName, "ports", deletes)
+		err := svc.DeleteLoadBalancerListeners(ctx, loadBalancerSpec.LoadBalancerName, deletes)
+		if err != nil {
+			return fmt.Errorf("cannot delete loadBalancer listeners: %w", err)
+		}
+	}
+	if len(creates) > 0 {
+		log.V(2).Info("Creating loadBalancer listeners", "loadBalancerName", loadBalancerSpec.LoadBalancerName, "listeners", creates)
+		err := svc.CreateLoadBalancerListeners(ctx, loadBalancerSpec.LoadBalancerName, creates)
+		if err != nil {
+			return fmt.Errorf("cannot create loadBalancer listeners: %w", err)
+		}
+	}
+	if !healthCheckMatches(loadbalancer.GetHealthCheck(), loadBalancerSpec.HealthCheck) {
+		log.V(2).Info("Updating loadBalancer healthcheck", "loadBalancerName", loadBalancerSpec.LoadBalancerName)
+		_, err := svc.ConfigureHealthCheck(ctx, loadBalancerSpec)
+		if err != nil {
+			return fmt.Errorf("cannot configure healthcheck: %w", err)
+		}
+	}
+	return nil
+}
+
+func listenerMatches(listener osc.Listener, spec infrastructurev1beta1.OscLoadBalancerListener) bool {
+	return listener.GetLoadBalancerPort() == spec.LoadBalancerPort &&
+		listener.GetLoadBalancerProtocol() == spec.LoadBalancerProtocol &&
+		listener.GetBackendPort() == spec.BackendPort &&
+		listener.GetBackendProtocol() == spec.BackendProtocol
+}
+
+func healthCheckMatches(hc osc.HealthCheck, spec infrastructurev1beta1.OscLoadBalancerHealthCheck) bool {
+	return hc.CheckInterval == spec.CheckInterval &&
+		hc.HealthyThreshold == spec.HealthyThreshold &&
+		hc.Port == spec.Port &&
+		hc.Protocol == spec.Protocol &&
+		hc.Timeout == spec.Timeout &&
+		hc.UnhealthyThreshold == spec.UnhealthyThreshold
+}
+
 // reconcileDeleteLoadBalancer reconcile the destruction of the LoadBalancer of the cluster.
 
 func (r *OscClusterReconciler) reconcileDeleteLoadBalancer(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
@@ -0,0 +1,283 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// natInstanceCheckInterval is the interval between two health checks of NAT instances.
+const natInstanceCheckInterval = time.Minute
+
+// reconcileNatInstances reconciles the NAT instances of the cluster, and replaces the ones that are no longer running.
+// It is not bound to the cluster generation, as it also acts as a health check.
+func (r *OscClusterReconciler) reconcileNatInstances(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(4).Info("Reconciling natInstances")
+
+	var pending bool
+	for _, natSpec := range clusterScope.GetNatServices() {
+		if !clusterScope.IsNatInstance(natSpec) {
+			continue
+		}
+		var replacedVmId string
+		vm, err := r.Tracker.getNatInstance(ctx, natSpec, clusterScope)
+		switch {
+		case errors.Is(err, ErrNoResourceFound):
+		case errors.Is(err, ErrMissingResource):
+			replacedVmId = getResource(clusterScope.GetNatServiceClientToken(natSpec), clusterScope.GetResources().NatInstance)
+			log.V(2).Info("NAT instance not found, replacing it", "vmId", replacedVmId)
+		case err != nil:
+			return reconcile.Result{}, fmt.Errorf("get existing: %w", err)
+		case vm.GetState() == "pending":
+			log.V(3).Info("NAT instance is not yet running", "vmId", vm.GetVmId())
+			pending = true
+			continue
+		case vm.GetState() == "running":
+			if vm.GetIsSourceDestChecked() {
+				log.V(2).Info("Disabling source/dest check on NAT instance", "vmId", vm.GetVmId())
+				err := r.Cloud.VM(clusterScope.Tenant).UpdateVmSourceDestCheck(ctx, vm.GetVmId(), false)
+				if err != nil {
+					return reconcile.Result{}, fmt.Errorf("cannot disable source/dest check: %w", err)
+				}
+			}
+			log.V(4).Info("NAT instance is running", "vmId", vm.GetVmId())
+			continue
+		default:
+			replacedVmId = vm.GetVmId()
+			log.V(2).Info("NAT instance is down, replacing it", "vmId", replacedVmId, "vmState", vm.GetState())
+			if vm.GetState() != "terminated" && vm.GetState() != "shutting-down" {
+				err := r.Cloud.VM(clusterScope.Tenant).DeleteVm(ctx, replacedVmId)
+				if err != nil {
+					return reconcile.Result{}, fmt.Errorf("cannot delete NAT instance: %w", err)
+				}
+			}
+		}
+		vm, err = r.createNatInstance(ctx, clusterScope, natSpec, replacedVmId)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if replacedVmId != "" {
+			err = r.rerouteNatInstance(ctx, clusterScope, replacedVmId, vm.GetVmId())
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeWarning, infrastructurev1beta1.NatInstanceReplacedReason, "NAT instance %s replaced by %s", replacedVmId, vm.GetVmId())
+		}
+		pending = true
+	}
+	if pending {
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	// Routes are checked on each health check, a replacement having possibly been interrupted before routes were moved.
+	err := r.reconcileNatInstanceRoutes(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: natInstanceCheckInterval}, nil
+}
+
+// reconcileNatInstanceRoutes updates the existing routes to NAT instances that do not target the current instance.
+// Missing routes are created by the routeTable reconciler.
+func (r *OscClusterReconciler) reconcileNatInstanceRoutes(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	log := ctrl.LoggerFrom(ctx)
+	if clusterScope.GetNetwork().UseExisting.Net {
+		return nil
+	}
+	netId, err := r.Tracker.getNetId(ctx, clusterScope)
+	if err != nil {
+		return err
+	}
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	rtbls, err := svc.GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return fmt.Errorf("list route tables: %w", err)
+	}
+	rtblForSubnet := map[string]osc.RouteTable{}
+	for _, rtbl := range rtbls {
+		for _, link := range rtbl.GetLinkRouteTables() {
+			rtblForSubnet[link.GetSubnetId()] = rtbl
+		}
+	}
+	for _, routeTableSpec := range clusterScope.GetRouteTables() {
+		names := routeTableSpec.Subnets
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		subnetSpec, err := clusterScope.GetSubnet(names[0], routeTableSpec.Role, routeTableSpec.SubregionName)
+		if err != nil {
+			return fmt.Errorf("cannot find subnet with name %q role %q: %w", names[0], routeTableSpec.Role, err)
+		}
+		subnetId, err := r.Tracker.getSubnetId(ctx, subnetSpec, clusterScope)
+		if err != nil {
+			return fmt.Errorf("cannot get subnet: %w", err)
+		}
+		rtbl, found := rtblForSubnet[subnetId]
+		if !found {
+			continue
+		}
+		for _, routeSpec := range routeTableSpec.Routes {
+			if routeSpec.TargetType != "nat" {
+				continue
+			}
+			natSpec, err := clusterScope.GetNatService(routeSpec.TargetName, routeTableSpec.SubregionName)
+			if err != nil {
+				return fmt.Errorf("find natService for route: %w", err)
+			}
+			if !clusterScope.IsNatInstance(natSpec) {
+				continue
+			}
+			vmId, err := r.Tracker.getNatInstanceId(ctx, natSpec, clusterScope)
+			if err != nil {
+				return fmt.Errorf("find NAT instance for route: %w", err)
+			}
+			for _, route := range rtbl.GetRoutes() {
+				if route.GetDestinationIpRange() != routeSpec.Destination || route.GetVmId() == vmId {
+					continue
+				}
+				log.V(2).Info("Rerouting to NAT instance", "routeTableId", rtbl.GetRouteTableId(), "destination", route.GetDestinationIpRange(), "vmId", vmId)
+				err := svc.UpdateRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId(), vmId, "vm")
+				if err != nil {
+					return fmt.Errorf("cannot update route: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *OscClusterReconciler) createNatInstance(ctx context.Context, clusterScope *scope.ClusterScope, natSpec infrastructurev1beta1.OscNatService, replacedVmId string) (*osc.Vm, error) {
+	log := ctrl.LoggerFrom(ctx)
+	subnetSpec, err := clusterScope.GetSubnet(natSpec.SubnetName, infrastructurev1beta1.RoleNat, natSpec.SubregionName)
+	if err != nil {
+		return nil, fmt.Errorf("find subnet: %w", err)
+	}
+	subnetId, err := r.Tracker.getSubnetId(ctx, subnetSpec, clusterScope)
+	if err != nil {
+		return nil, fmt.Errorf("get subnet: %w", err)
+	}
+	_, publicIp, err := r.Tracker.IPAllocator(clusterScope).AllocateIP(ctx,
+		clusterScope.GetNatServiceClientToken(natSpec), clusterScope.GetNatInstanceName(natSpec), clusterScope.GetNetwork().NatPublicIpPool, clusterScope)
+	if err != nil {
+		return nil, fmt.Errorf("allocate IP: %w", err)
+	}
+	natSecurityGroups, err := clusterScope.GetSecurityGroupsFor(nil, infrastructurev1beta1.RoleNat)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find securityGroup: %w", err)
+	}
+	if len(natSecurityGroups) == 0 {
+		return nil, errors.New("no securityGroup found with the nat role")
+	}
+	securityGroupIds := make([]string, 0, len(natSecurityGroups))
+	for _, sgSpec := range natSecurityGroups {
+		securityGroupId, err := r.Tracker.getSecurityGroupId(ctx, sgSpec, clusterScope)
+		if err != nil {
+			return nil, err
+		}
+		securityGroupIds = append(securityGroupIds, securityGroupId)
+	}
+	instanceSpec := natSpec.Instance
+	instanceSpec.SetDefaultValue()
+	imageId := instanceSpec.ImageId
+	if imageId == "" {
+		image, err := r.Cloud.Image(clusterScope.Tenant).GetImageByName(ctx, instanceSpec.ImageName, instanceSpec.ImageAccountId)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find image %s: %w", instanceSpec.ImageName, err)
+		}
+		if image == nil {
+			return nil, fmt.Errorf("cannot find image %s", instanceSpec.ImageName)
+		}
+		imageId = image.GetImageId()
+	}
+
+	log.V(3).Info("Creating NAT instance", "vmType", instanceSpec.VmType, "subnetId", subnetId)
+	tags := map[string]string{
+		compute.AutoAttachExternapIPTag: publicIp,
+	}
+	vm, err := r.Cloud.VM(clusterScope.Tenant).CreateVmNat(ctx, &instanceSpec, subnetId, securityGroupIds,
+		clusterScope.GetNatInstanceName(natSpec), clusterScope.GetNatInstanceClientToken(natSpec, replacedVmId), imageId, clusterScope.GetNet().IpRange, tags)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create NAT instance: %w", err)
+	}
+	log.V(2).Info("Created NAT instance", "vmId", vm.GetVmId())
+	r.Tracker.setNatInstanceId(clusterScope, natSpec, vm.GetVmId())
+	r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.NatServicesCreatedReason, "NAT instance created %s", natSpec.SubregionName)
+	return vm, nil
+}
+
+// rerouteNatInstance moves the routes targeting a replaced NAT instance to its replacement.
+func (r *OscClusterReconciler) rerouteNatInstance(ctx context.Context, clusterScope *scope.ClusterScope, fromVmId, toVmId string) error {
+	log := ctrl.LoggerFrom(ctx)
+	netId, err := r.Tracker.getNetId(ctx, clusterScope)
+	if err != nil {
+		return err
+	}
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	rtbls, err := svc.GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return err
+	}
+	for _, rtbl := range rtbls {
+		for _, route := range rtbl.GetRoutes() {
+			if route.GetVmId() != fromVmId {
+				continue
+			}
+			log.V(2).Info("Rerouting to NAT instance", "routeTableId", rtbl.GetRouteTableId(), "destination", route.GetDestinationIpRange(), "vmId", toVmId)
+			err := svc.UpdateRoute(ctx, route.GetDestinationIpRange(), rtbl.GetRouteTableId(), toVmId, "vm")
+			if err != nil {
+				return fmt.Errorf("cannot update route: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileDeleteNatInstances deletes the NAT instances of the cluster, and waits for their termination.
+func (r *OscClusterReconciler) reconcileDeleteNatInstances(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	var pending bool
+	for _, natSpec := range clusterScope.GetNatServices() {
+		if !clusterScope.IsNatInstance(natSpec) {
+			continue
+		}
+		vm, err := r.Tracker.getNatInstance(ctx, natSpec, clusterScope)
+		switch {
+		case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+			log.V(4).Info("The NAT instance is already deleted")
+			continue
+		case err != nil:
+			return reconcile.Result{}, fmt.Errorf("get existing: %w", err)
+		case vm.GetState() == "terminated":
+			log.V(4).Info("The NAT instance is already deleted")
+			continue
+		case vm.GetState() == "shutting-down":
+			pending = true
+			continue
+		}
+		log.V(2).Info("Deleting NAT instance", "vmId", vm.GetVmId())
+		err = r.Cloud.VM(clusterScope.Tenant).DeleteVm(ctx, vm.GetVmId())
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot delete NAT instance: %w", err)
+		}
+		pending = true
+	}
+	if pending {
+		log.V(3).Info("Waiting for NAT instances to be terminated")
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	return reconcile.Result{}, nil
+}
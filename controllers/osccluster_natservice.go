@@ -37,6 +37,9 @@ func (r *OscClusterReconciler) reconcileNatService(ctx context.Context, clusterS
 
 	natServiceSpecs := clusterScope.GetNatServices()
 	for _, natServiceSpec := range natServiceSpecs {
+		if clusterScope.IsNatInstance(natServiceSpec) {
+			continue
+		}
 		natService, err := r.Tracker.getNatService(ctx, natServiceSpec, clusterScope)
 		switch {
 		case errors.Is(err, ErrNoResourceFound):
@@ -96,6 +99,21 @@ func (r *OscClusterReconciler) listNATPublicIPs(ctx context.Context, clusterScop
 			ips = append(ips, pip)
 		}
 	}
+	// NAT instances may not exist yet, their IPs are allocated beforehand.
+	for _, natSpec := range clusterScope.GetNatServices() {
+		if !clusterScope.IsNatInstance(natSpec) {
+			continue
+		}
+		_, pip, err := r.Tracker.IPAllocator(clusterScope).AllocateIP(ctx,
+			clusterScope.GetNatServiceClientToken(natSpec), clusterScope.GetNatInstanceName(natSpec), clusterScope.GetNetwork().NatPublicIpPool, clusterScope)
+		if err != nil {
+			return nil, fmt.Errorf("allocate IP: %w", err)
+		}
+		if cidr {
+			pip += "/32"
+		}
+		ips = append(ips, pip)
+	}
 	return ips, nil
 }
 
@@ -9,9 +9,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -33,6 +35,10 @@ func (r *OscClusterReconciler) reconcileNet(ctx context.Context, clusterScope *s
 		return reconcile.Result{}, fmt.Errorf("find existing: %w", err)
 	default:
 		log.V(4).Info("Found existing net", "netId", net.GetNetId())
+		err = r.reconcileDhcpOptions(ctx, clusterScope, net)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 		clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerNet)
 		return reconcile.Result{}, nil
 	}
@@ -44,23 +50,144 @@ func (r *OscClusterReconciler) reconcileNet(ctx context.Context, clusterScope *s
 	}
 	log.V(2).Info("Created net", "netId", net.GetNetId())
 	r.Tracker.setNetId(clusterScope, net.GetNetId())
-	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerNet)
 	r.Recorder.Event(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.NetCreatedReason, "Net created")
+	err = r.reconcileDhcpOptions(ctx, clusterScope, net)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerNet)
 	return reconcile.Result{}, nil
 }
 
+// hasDhcpOptions returns true if DHCP options are defined in the spec or still exist.
+func hasDhcpOptions(clusterScope *scope.ClusterScope) bool {
+	return !clusterScope.GetNetwork().Net.DhcpOptions.IsZero() || getResource(defaultResource, clusterScope.GetResources().DhcpOptionsSet) != ""
+}
+
+// sameDhcpOptions returns true if the DHCP options set matches the spec.
+// Server lists are compared regardless of their order, as the API does not preserve it.
+func sameDhcpOptions(dhcp *osc.DhcpOptionsSet, spec infrastructurev1beta1.OscDhcpOptions) bool {
+	return dhcp.GetDomainName() == spec.DomainName &&
+		slices.Equal(sortedCopy(dhcp.GetDomainNameServers()), sortedCopy(spec.DomainNameServers)) &&
+		slices.Equal(sortedCopy(dhcp.GetNtpServers()), sortedCopy(spec.NtpServers))
+}
+
+func sortedCopy(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+// reconcileDhcpOptions links the DHCP options set of the spec to the net.
+// As DHCP options sets cannot be updated, a new set is created and swapped with the previous one on spec change.
+func (r *OscClusterReconciler) reconcileDhcpOptions(ctx context.Context, clusterScope *scope.ClusterScope, net *osc.Net) error {
+	log := ctrl.LoggerFrom(ctx)
+	if !hasDhcpOptions(clusterScope) {
+		return nil
+	}
+	spec := clusterScope.GetNet().DhcpOptions
+	svc := r.Cloud.DhcpOptions(clusterScope.Tenant)
+	current, err := r.Tracker.getDhcpOptions(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return fmt.Errorf("get existing DHCP options: %w", err)
+	case !spec.IsZero() && sameDhcpOptions(current, spec):
+		if net.GetDhcpOptionsSetId() != current.GetDhcpOptionsSetId() {
+			log.V(3).Info("Linking DHCP options", "dhcpOptionsSetId", current.GetDhcpOptionsSetId())
+			err = svc.LinkDhcpOptions(ctx, current.GetDhcpOptionsSetId(), net.GetNetId())
+			if err != nil {
+				return fmt.Errorf("cannot link DHCP options: %w", err)
+			}
+		}
+		return nil
+	}
+	if spec.IsZero() {
+		if current != nil && net.GetDhcpOptionsSetId() == current.GetDhcpOptionsSetId() {
+			log.V(3).Info("Linking default DHCP options")
+			err = svc.LinkDhcpOptions(ctx, "default", net.GetNetId())
+			if err != nil {
+				return fmt.Errorf("cannot link default DHCP options: %w", err)
+			}
+		}
+	} else {
+		log.V(3).Info("Creating DHCP options")
+		dhcp, err := svc.CreateDhcpOptions(ctx, spec, clusterScope.GetUID(), clusterScope.GetDhcpOptionsName())
+		if err != nil {
+			return fmt.Errorf("cannot create DHCP options: %w", err)
+		}
+		log.V(2).Info("Created DHCP options", "dhcpOptionsSetId", dhcp.GetDhcpOptionsSetId())
+		r.Tracker.setDhcpOptionsId(clusterScope, dhcp.GetDhcpOptionsSetId())
+		r.Recorder.Event(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.DhcpOptionsCreatedReason, "DHCP options created")
+		err = svc.LinkDhcpOptions(ctx, dhcp.GetDhcpOptionsSetId(), net.GetNetId())
+		if err != nil {
+			return fmt.Errorf("cannot link DHCP options: %w", err)
+		}
+	}
+	if current != nil {
+		log.V(2).Info("Deleting previous DHCP options", "dhcpOptionsSetId", current.GetDhcpOptionsSetId())
+		err = svc.DeleteDhcpOptions(ctx, current.GetDhcpOptionsSetId())
+		if err != nil {
+			return fmt.Errorf("cannot delete previous DHCP options: %w", err)
+		}
+	}
+	if spec.IsZero() {
+		delete(clusterScope.GetResources().DhcpOptionsSet, defaultResource)
+	}
+	return nil
+}
+
+// reconcileDeleteDhcpOptions deletes the DHCP options set of the cluster. If the net still exists, the default set is linked back first.
+func (r *OscClusterReconciler) reconcileDeleteDhcpOptions(ctx context.Context, clusterScope *scope.ClusterScope, net *osc.Net) error {
+	log := ctrl.LoggerFrom(ctx)
+	if !hasDhcpOptions(clusterScope) {
+		return nil
+	}
+	dhcp, err := r.Tracker.getDhcpOptions(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		log.V(4).Info("The DHCP options are already deleted")
+		delete(clusterScope.GetResources().DhcpOptionsSet, defaultResource)
+		return nil
+	case err != nil:
+		return fmt.Errorf("get DHCP options: %w", err)
+	}
+	svc := r.Cloud.DhcpOptions(clusterScope.Tenant)
+	if net != nil && net.GetDhcpOptionsSetId() == dhcp.GetDhcpOptionsSetId() {
+		log.V(3).Info("Linking default DHCP options")
+		err = svc.LinkDhcpOptions(ctx, "default", net.GetNetId())
+		if err != nil {
+			return fmt.Errorf("cannot link default DHCP options: %w", err)
+		}
+	}
+	log.V(2).Info("Deleting DHCP options", "dhcpOptionsSetId", dhcp.GetDhcpOptionsSetId())
+	err = svc.DeleteDhcpOptions(ctx, dhcp.GetDhcpOptionsSetId())
+	if err != nil {
+		return fmt.Errorf("cannot delete DHCP options: %w", err)
+	}
+	delete(clusterScope.GetResources().DhcpOptionsSet, defaultResource)
+	return nil
+}
+
 // reconcileDeleteNet reconcile the destruction of the Net of the cluster.
 func (r *OscClusterReconciler) reconcileDeleteNet(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	if clusterScope.GetNetwork().UseExisting.Net {
 		log.V(4).Info("Not deleting existing net")
-		return reconcile.Result{}, nil
+		if !hasDhcpOptions(clusterScope) {
+			return reconcile.Result{}, nil
+		}
+		net, err := r.Tracker.getNet(ctx, clusterScope)
+		if err != nil && !errors.Is(err, ErrMissingResource) {
+			return reconcile.Result{}, fmt.Errorf("find existing: %w", err)
+		}
+		return reconcile.Result{}, r.reconcileDeleteDhcpOptions(ctx, clusterScope, net)
 	}
 	net, err := r.Tracker.getNet(ctx, clusterScope)
 	switch {
 	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
 		log.V(4).Info("The net is already deleted")
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.reconcileDeleteDhcpOptions(ctx, clusterScope, nil)
 	case err != nil:
 		return reconcile.Result{}, fmt.Errorf("find existing: %w", err)
 	}
@@ -69,5 +196,5 @@ func (r *OscClusterReconciler) reconcileDeleteNet(ctx context.Context, clusterSc
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("cannot delete net: %w", err)
 	}
-	return reconcile.Result{}, nil
+	return reconcile.Result{}, r.reconcileDeleteDhcpOptions(ctx, clusterScope, nil)
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
@@ -48,23 +49,45 @@ func (r *OscClusterReconciler) reconcileNetAccessPoints(ctx context.Context, clu
 		}
 	}
 	rtblIds := make([]string, 0, len(rtbls))
-	for _, subnetSpec := range clusterScope.GetSubnets() {
-		if !clusterScope.SubnetHasRole(subnetSpec, infrastructurev1beta1.RoleWorker) && !clusterScope.SubnetHasRole(subnetSpec, infrastructurev1beta1.RoleControlPlane) {
-			continue
+	if clusterScope.IsAirgapped() {
+		// In airgapped mode, all subnets need to reach the services.
+		for _, rtbl := range rtbls {
+			rtblIds = append(rtblIds, rtbl.GetRouteTableId())
 		}
-		subnetId, err := r.Tracker.getSubnetId(ctx, subnetSpec, clusterScope)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("cannot get subnet: %w", err)
+	} else {
+		for _, subnetSpec := range clusterScope.GetSubnets() {
+			if !clusterScope.SubnetHasRole(subnetSpec, infrastructurev1beta1.RoleWorker) && !clusterScope.SubnetHasRole(subnetSpec, infrastructurev1beta1.RoleControlPlane) {
+				continue
+			}
+			subnetId, err := r.Tracker.getSubnetId(ctx, subnetSpec, clusterScope)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot get subnet: %w", err)
+			}
+			rtblIds = append(rtblIds, rtblForSubnet[subnetId])
 		}
-		rtblIds = append(rtblIds, rtblForSubnet[subnetId])
 	}
 
-	for _, service := range clusterScope.GetNetwork().NetAccessPoints {
+	for _, service := range clusterScope.GetNetAccessPoints() {
 		netAccessPoint, err := r.Tracker.getNetAccessPoint(ctx, service, clusterScope)
 		switch {
 		case errors.Is(err, ErrNoResourceFound):
 		case err != nil:
 			return reconcile.Result{}, fmt.Errorf("get existing: %w", err)
+		case clusterScope.IsAirgapped():
+			var missing []string
+			for _, rtblId := range rtblIds {
+				if !slices.Contains(netAccessPoint.GetRouteTableIds(), rtblId) {
+					missing = append(missing, rtblId)
+				}
+			}
+			if len(missing) > 0 {
+				log.V(2).Info("Linking route tables to net access point", "netAccessPointId", netAccessPoint.GetNetAccessPointId(), "routeTableIds", missing)
+				err = r.Cloud.NetAccessPoint(clusterScope.Tenant).LinkNetAccessPointRouteTables(ctx, netAccessPoint.GetNetAccessPointId(), missing)
+				if err != nil {
+					return reconcile.Result{}, fmt.Errorf("cannot link route tables to netAccessPoint: %w", err)
+				}
+			}
+			continue
 		default:
 			log.V(4).Info("Found existing netAccessPoint", "netAccessPointId", netAccessPoint.GetNetAccessPointId())
 			continue
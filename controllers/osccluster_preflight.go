@@ -0,0 +1,88 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// airgapPreflightInterval is the interval between two preflight checks, while prerequisites are missing or cannot be checked.
+const airgapPreflightInterval = time.Minute
+
+// reconcileAirgapPreflight checks the prerequisites of an airgapped cluster, and reports missing ones in the AirgapPreflightReady condition.
+// Prerequisites are checked once per cluster generation, unless some are missing.
+// Missing prerequisites and check failures do not block the reconciliation.
+func (r *OscClusterReconciler) reconcileAirgapPreflight(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerAirgapPreflight) {
+		log.V(4).Info("No need for airgap preflight")
+		return reconcile.Result{}, nil
+	}
+	log.V(4).Info("Checking airgap prerequisites")
+	network := clusterScope.GetNetwork()
+	var missing []string
+	if !network.UseExisting.Net && !network.NetPeering.Enable && len(network.AdditionalNetPeerings) == 0 &&
+		!network.Vpn.Enable && network.DirectLink.DirectLinkId == "" {
+		missing = append(missing, "no net peering, VPN or DirectLink to reach the internal load balancer")
+	}
+
+	machines, oscMachines, err := clusterScope.ListMachines(ctx)
+	if err != nil {
+		return r.airgapPreflightFailed(ctx, clusterScope, fmt.Errorf("cannot list machines: %w", err))
+	}
+	checked := map[string]bool{}
+	for i, oscMachine := range oscMachines {
+		imageSpec := oscMachine.Spec.Node.Image
+		imageId := oscMachine.Spec.Node.Vm.ImageId
+		k8sVersion := ptr.Deref(machines[i].Spec.Version, "")
+		key := imageSpec.Name + "/" + imageSpec.NamePattern + "/" + k8sVersion + "/" + imageSpec.AccountId + "/" + imageId
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+		image, err := findImage(ctx, r.Cloud.Image(clusterScope.Tenant), &imageSpec, imageId, k8sVersion, clusterScope.GetRegion())
+		if err != nil {
+			return r.airgapPreflightFailed(ctx, clusterScope, fmt.Errorf("cannot get image: %w", err))
+		}
+		if image == nil {
+			name := cmp.Or(imageSpec.Name, imageSpec.NamePattern)
+			if name == "" {
+				name = imageId
+			}
+			missing = append(missing, fmt.Sprintf("image %s of machine %s not found", name, oscMachine.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		log.V(2).Info("Missing airgap prerequisites", "missing", missing)
+		conditions.MarkFalse(clusterScope.OscCluster, infrastructurev1beta1.AirgapPreflightReadyCondition, infrastructurev1beta1.AirgapPrerequisitesMissingReason,
+			clusterv1.ConditionSeverityWarning, "%s", strings.Join(missing, "; "))
+		return reconcile.Result{RequeueAfter: airgapPreflightInterval}, nil
+	}
+	conditions.MarkTrue(clusterScope.OscCluster, infrastructurev1beta1.AirgapPreflightReadyCondition)
+	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerAirgapPreflight)
+	return reconcile.Result{}, nil
+}
+
+// airgapPreflightFailed reports a failed preflight check in the AirgapPreflightReady condition, and requeues the check.
+func (r *OscClusterReconciler) airgapPreflightFailed(ctx context.Context, clusterScope *scope.ClusterScope, err error) (reconcile.Result, error) {
+	ctrl.LoggerFrom(ctx).V(1).Error(err, "unable to check airgap prerequisites")
+	conditions.MarkFalse(clusterScope.OscCluster, infrastructurev1beta1.AirgapPreflightReadyCondition, infrastructurev1beta1.AirgapPreflightFailedReason,
+		clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	return reconcile.Result{RequeueAfter: airgapPreflightInterval}, nil
+}
@@ -125,6 +125,41 @@ func (t *ClusterResourceTracker) setNetPeeringId(clusterScope *scope.ClusterScop
 	rsrc.NetPeering[defaultResource] = id
 }
 
+// getAdditionalNetPeering returns an additional net peering, a wrapped ErrNoResourceFound or ErrMissingResource error otherwise.
+// Additional net peerings are stored in the NetPeering resources, using their name as key.
+func (t *ClusterResourceTracker) getAdditionalNetPeering(ctx context.Context, np infrastructurev1beta1.OscAdditionalNetPeering, clusterScope *scope.ClusterScope) (*osc.NetPeering, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(np.Name, rsrc.NetPeering)
+	if id == "" {
+		tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadTag(ctx, tag.NetPeeringResourceType, tag.NameKey, clusterScope.GetAdditionalNetPeeringName(np))
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("get net peering: %w", err)
+		case tg.GetResourceId() == "":
+			return nil, fmt.Errorf("get net peering: %w", ErrNoResourceFound)
+		}
+		id = tg.GetResourceId()
+		t.setAdditionalNetPeeringId(clusterScope, np, id)
+	}
+	n, err := t.Cloud.NetPeering(clusterScope.Tenant).GetNetPeering(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case n == nil || n.State.GetName() == "deleted":
+		return nil, fmt.Errorf("get net peering %s: %w", id, ErrMissingResource)
+	default:
+		return n, nil
+	}
+}
+
+func (t *ClusterResourceTracker) setAdditionalNetPeeringId(clusterScope *scope.ClusterScope, np infrastructurev1beta1.OscAdditionalNetPeering, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.NetPeering == nil {
+		rsrc.NetPeering = map[string]string{}
+	}
+	rsrc.NetPeering[np.Name] = id
+}
+
 func (t *ClusterResourceTracker) _getInternetServiceOrId(ctx context.Context, clusterScope *scope.ClusterScope) (*osc.InternetService, string, error) {
 	rsrc := clusterScope.GetResources()
 	id := getResource(defaultResource, rsrc.InternetService)
@@ -367,6 +402,54 @@ func (t *ClusterResourceTracker) setNatServiceId(clusterScope *scope.ClusterScop
 	rsrc.NatService[clusterScope.GetNatServiceClientToken(nat)] = id
 }
 
+// getNatInstance returns the vm of a NAT instance, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getNatInstance(ctx context.Context, nat infrastructurev1beta1.OscNatService, clusterScope *scope.ClusterScope) (*osc.Vm, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(clusterScope.GetNatServiceClientToken(nat), rsrc.NatInstance)
+	if id == "" {
+		vm, err := t.Cloud.VM(clusterScope.Tenant).GetVmFromClientToken(ctx, clusterScope.GetNatInstanceClientToken(nat, ""))
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("get nat instance from client token: %w", err)
+		case vm == nil:
+			return nil, fmt.Errorf("get nat instance: %w", ErrNoResourceFound)
+		}
+		t.setNatInstanceId(clusterScope, nat, vm.GetVmId())
+		return vm, nil
+	}
+	vm, err := t.Cloud.VM(clusterScope.Tenant).GetVm(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case vm == nil:
+		return nil, fmt.Errorf("get nat instance %s: %w", id, ErrMissingResource)
+	default:
+		return vm, nil
+	}
+}
+
+// getNatInstanceId returns the id of the vm of a NAT instance, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getNatInstanceId(ctx context.Context, nat infrastructurev1beta1.OscNatService, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(clusterScope.GetNatServiceClientToken(nat), rsrc.NatInstance)
+	if id != "" {
+		return id, nil
+	}
+	vm, err := t.getNatInstance(ctx, nat, clusterScope)
+	if err != nil {
+		return "", err
+	}
+	return vm.GetVmId(), nil
+}
+
+func (t *ClusterResourceTracker) setNatInstanceId(clusterScope *scope.ClusterScope, nat infrastructurev1beta1.OscNatService, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.NatInstance == nil {
+		rsrc.NatInstance = map[string]string{}
+	}
+	rsrc.NatInstance[clusterScope.GetNatServiceClientToken(nat)] = id
+}
+
 func (t *ClusterResourceTracker) getPublicIps(clusterScope *scope.ClusterScope) map[string]string {
 	rsrc := clusterScope.GetResources()
 	return rsrc.PublicIPs
@@ -534,3 +617,259 @@ func (t *ClusterResourceTracker) untrackIP(clusterScope *scope.ClusterScope, nam
 	}
 	delete(rsrc.PublicIPs, name)
 }
+
+func (t *ClusterResourceTracker) _getControlPlaneNicOrId(ctx context.Context, slot infrastructurev1beta1.OscNicSlot, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(slot.Name, rsrc.Nic)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadTag(ctx, tag.NicResourceType, tag.NameKey, clusterScope.GetControlPlaneNicName(slot))
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get controlplane nic: %w", err)
+	case tg.GetResourceId() != "":
+		t.setControlPlaneNicId(clusterScope, slot, tg.GetResourceId())
+		return tg.GetResourceId(), nil
+	default:
+		return "", fmt.Errorf("get controlplane nic: %w", ErrNoResourceFound)
+	}
+}
+
+func (t *ClusterResourceTracker) getControlPlaneNic(ctx context.Context, slot infrastructurev1beta1.OscNicSlot, clusterScope *scope.ClusterScope) (*osc.Nic, error) {
+	id, err := t._getControlPlaneNicOrId(ctx, slot, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	nic, err := t.Cloud.Nic(clusterScope.Tenant).GetNic(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case nic == nil:
+		return nil, fmt.Errorf("get controlplane nic %s: %w", id, ErrMissingResource)
+	default:
+		return nic, nil
+	}
+}
+
+func (t *ClusterResourceTracker) getControlPlaneNicId(ctx context.Context, slot infrastructurev1beta1.OscNicSlot, clusterScope *scope.ClusterScope) (string, error) {
+	return t._getControlPlaneNicOrId(ctx, slot, clusterScope)
+}
+
+func (t *ClusterResourceTracker) setControlPlaneNicId(clusterScope *scope.ClusterScope, slot infrastructurev1beta1.OscNicSlot, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.Nic == nil {
+		rsrc.Nic = map[string]string{}
+	}
+	rsrc.Nic[slot.Name] = id
+}
+
+func (t *ClusterResourceTracker) getVirtualGateway(ctx context.Context, clusterScope *scope.ClusterScope) (*osc.VirtualGateway, error) {
+	id, err := t.getVirtualGatewayId(ctx, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	vgw, err := t.Cloud.VirtualGateway(clusterScope.Tenant).GetVirtualGateway(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case vgw == nil || vgw.GetState() == "deleted":
+		return nil, fmt.Errorf("get virtual gateway %s: %w", id, ErrMissingResource)
+	default:
+		return vgw, nil
+	}
+}
+
+// getVirtualGatewayId returns the id for the virtual gateway, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getVirtualGatewayId(ctx context.Context, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(defaultResource, rsrc.VirtualGateway)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadOwnedByTag(ctx, tag.VirtualGatewayResourceType, clusterScope.GetUID())
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get virtual gateway: %w", err)
+	case tg.GetResourceId() != "":
+		t.setVirtualGatewayId(clusterScope, tg.GetResourceId())
+		return tg.GetResourceId(), nil
+	default:
+		return "", fmt.Errorf("get virtual gateway: %w", ErrNoResourceFound)
+	}
+}
+
+func (t *ClusterResourceTracker) setVirtualGatewayId(clusterScope *scope.ClusterScope, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.VirtualGateway == nil {
+		rsrc.VirtualGateway = map[string]string{}
+	}
+	rsrc.VirtualGateway[defaultResource] = id
+}
+
+func (t *ClusterResourceTracker) getDhcpOptions(ctx context.Context, clusterScope *scope.ClusterScope) (*osc.DhcpOptionsSet, error) {
+	id, err := t.getDhcpOptionsId(ctx, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	dhcp, err := t.Cloud.DhcpOptions(clusterScope.Tenant).GetDhcpOptions(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case dhcp == nil:
+		return nil, fmt.Errorf("get DHCP options %s: %w", id, ErrMissingResource)
+	default:
+		return dhcp, nil
+	}
+}
+
+// getDhcpOptionsId returns the id for the DHCP options set, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getDhcpOptionsId(ctx context.Context, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(defaultResource, rsrc.DhcpOptionsSet)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadOwnedByTag(ctx, tag.DhcpOptionsResourceType, clusterScope.GetUID())
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get DHCP options: %w", err)
+	case tg.GetResourceId() != "":
+		t.setDhcpOptionsId(clusterScope, tg.GetResourceId())
+		return tg.GetResourceId(), nil
+	default:
+		return "", fmt.Errorf("get DHCP options: %w", ErrNoResourceFound)
+	}
+}
+
+func (t *ClusterResourceTracker) setDhcpOptionsId(clusterScope *scope.ClusterScope, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.DhcpOptionsSet == nil {
+		rsrc.DhcpOptionsSet = map[string]string{}
+	}
+	rsrc.DhcpOptionsSet[defaultResource] = id
+}
+
+func (t *ClusterResourceTracker) getClientGateway(ctx context.Context, conn infrastructurev1beta1.OscVpnConnection, clusterScope *scope.ClusterScope) (*osc.ClientGateway, error) {
+	id, err := t.getClientGatewayId(ctx, conn, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	cgw, err := t.Cloud.Vpn(clusterScope.Tenant).GetClientGateway(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case cgw == nil || cgw.GetState() == "deleted":
+		return nil, fmt.Errorf("get client gateway %s: %w", id, ErrMissingResource)
+	default:
+		return cgw, nil
+	}
+}
+
+// getClientGatewayId returns the id for the client gateway of a VPN connection, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getClientGatewayId(ctx context.Context, conn infrastructurev1beta1.OscVpnConnection, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(conn.Name, rsrc.ClientGateway)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadTag(ctx, tag.ClientGatewayResourceType, tag.NameKey, clusterScope.GetVpnConnectionName(conn))
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get client gateway: %w", err)
+	case tg.GetResourceId() != "":
+		t.setClientGatewayId(clusterScope, conn, tg.GetResourceId())
+		return tg.GetResourceId(), nil
+	default:
+		return "", fmt.Errorf("get client gateway: %w", ErrNoResourceFound)
+	}
+}
+
+func (t *ClusterResourceTracker) setClientGatewayId(clusterScope *scope.ClusterScope, conn infrastructurev1beta1.OscVpnConnection, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.ClientGateway == nil {
+		rsrc.ClientGateway = map[string]string{}
+	}
+	rsrc.ClientGateway[conn.Name] = id
+}
+
+func (t *ClusterResourceTracker) getVpnConnection(ctx context.Context, conn infrastructurev1beta1.OscVpnConnection, clusterScope *scope.ClusterScope) (*osc.VpnConnection, error) {
+	id, err := t.getVpnConnectionId(ctx, conn, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	vpn, err := t.Cloud.Vpn(clusterScope.Tenant).GetVpnConnection(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case vpn == nil || vpn.GetState() == "deleted":
+		return nil, fmt.Errorf("get vpn connection %s: %w", id, ErrMissingResource)
+	default:
+		return vpn, nil
+	}
+}
+
+// getVpnConnectionId returns the id for a VPN connection, a wrapped ErrNoResourceFound error otherwise.
+func (t *ClusterResourceTracker) getVpnConnectionId(ctx context.Context, conn infrastructurev1beta1.OscVpnConnection, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := clusterScope.GetResources()
+	id := getResource(conn.Name, rsrc.VpnConnection)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadTag(ctx, tag.VpnConnectionResourceType, tag.NameKey, clusterScope.GetVpnConnectionName(conn))
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get vpn connection: %w", err)
+	case tg.GetResourceId() != "":
+		t.setVpnConnectionId(clusterScope, conn, tg.GetResourceId())
+		return tg.GetResourceId(), nil
+	default:
+		return "", fmt.Errorf("get vpn connection: %w", ErrNoResourceFound)
+	}
+}
+
+func (t *ClusterResourceTracker) setVpnConnectionId(clusterScope *scope.ClusterScope, conn infrastructurev1beta1.OscVpnConnection, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.VpnConnection == nil {
+		rsrc.VpnConnection = map[string]string{}
+	}
+	rsrc.VpnConnection[conn.Name] = id
+}
+
+// getDirectLinkInterface returns the DirectLink interface of the cluster, a wrapped ErrNoResourceFound error otherwise.
+// DirectLink interfaces cannot be tagged, they are found by name.
+func (t *ClusterResourceTracker) getDirectLinkInterface(ctx context.Context, clusterScope *scope.ClusterScope) (*osc.DirectLinkInterfaces, error) {
+	svc := t.Cloud.DirectLink(clusterScope.Tenant)
+	rsrc := clusterScope.GetResources()
+	id := getResource(defaultResource, rsrc.DirectLinkInterface)
+	if id != "" {
+		dli, err := svc.GetDirectLinkInterface(ctx, id)
+		switch {
+		case err != nil:
+			return nil, err
+		case dli == nil || dli.GetState() == "deleted":
+			return nil, fmt.Errorf("get directLink interface %s: %w", id, ErrMissingResource)
+		default:
+			return dli, nil
+		}
+	}
+	dlis, err := svc.ListDirectLinkInterfaces(ctx, clusterScope.GetNetwork().DirectLink.DirectLinkId)
+	if err != nil {
+		return nil, fmt.Errorf("list directLink interfaces: %w", err)
+	}
+	for _, dli := range dlis {
+		if dli.GetDirectLinkInterfaceName() == clusterScope.GetDirectLinkInterfaceName() && dli.GetState() != "deleted" {
+			t.setDirectLinkInterfaceId(clusterScope, dli.GetDirectLinkInterfaceId())
+			return &dli, nil
+		}
+	}
+	return nil, fmt.Errorf("get directLink interface: %w", ErrNoResourceFound)
+}
+
+func (t *ClusterResourceTracker) setDirectLinkInterfaceId(clusterScope *scope.ClusterScope, id string) {
+	rsrc := clusterScope.GetResources()
+	if rsrc.DirectLinkInterface == nil {
+		rsrc.DirectLinkInterface = map[string]string{}
+	}
+	rsrc.DirectLinkInterface[defaultResource] = id
+}
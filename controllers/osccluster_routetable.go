@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
@@ -30,7 +31,8 @@ func (r *OscClusterReconciler) reconcileRoute(ctx context.Context, clusterScope
 	}
 	var resourceId string
 	var err error
-	switch routeSpec.TargetType {
+	targetType := routeSpec.TargetType
+	switch targetType {
 	case "gateway":
 		resourceId, err = r.Tracker.getInternetServiceId(ctx, clusterScope)
 		if err != nil {
@@ -41,6 +43,18 @@ func (r *OscClusterReconciler) reconcileRoute(ctx context.Context, clusterScope
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("find natService for route: %w", err)
 		}
+		if clusterScope.IsNatInstance(natSpec) {
+			resourceId, err = r.Tracker.getNatInstanceId(ctx, natSpec, clusterScope)
+			switch {
+			case errors.Is(err, ErrNoResourceFound):
+				log.V(3).Info("NAT instance is not yet created, route is delayed", "destinationIpRange", destinationIpRange)
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			case err != nil:
+				return reconcile.Result{}, fmt.Errorf("find NAT instance for route: %w", err)
+			}
+			targetType = "vm"
+			break
+		}
 		resourceId, err = r.Tracker.getNatServiceId(ctx, natSpec, clusterScope)
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("find natService for route: %w", err)
@@ -50,7 +64,7 @@ func (r *OscClusterReconciler) reconcileRoute(ctx context.Context, clusterScope
 		return reconcile.Result{}, nil
 	}
 	log.V(2).Info("Creating route", "destination", destinationIpRange, "resourceId", resourceId)
-	_, err = r.Cloud.RouteTable(clusterScope.Tenant).CreateRoute(ctx, destinationIpRange, routeTable.GetRouteTableId(), resourceId, routeSpec.TargetType)
+	_, err = r.Cloud.RouteTable(clusterScope.Tenant).CreateRoute(ctx, destinationIpRange, routeTable.GetRouteTableId(), resourceId, targetType)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("cannot create route: %w", err)
 	}
@@ -87,6 +101,7 @@ func (r *OscClusterReconciler) reconcileRouteTable(ctx context.Context, clusterS
 			rtblForSubnet[link.GetSubnetId()] = &rtbl
 		}
 	}
+	var res reconcile.Result
 	routeTablesSpec := clusterScope.GetRouteTables()
 	for _, routeTableSpec := range routeTablesSpec {
 		var rtbl *osc.RouteTable
@@ -132,16 +147,20 @@ func (r *OscClusterReconciler) reconcileRouteTable(ctx context.Context, clusterS
 			continue
 		}
 		for _, routeSpec := range routeTableSpec.Routes {
-			_, err = r.reconcileRoute(ctx, clusterScope, routeTableSpec, routeSpec, rtbl)
+			routeRes, err := r.reconcileRoute(ctx, clusterScope, routeTableSpec, routeSpec, rtbl)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			if !routeRes.IsZero() {
+				res = routeRes
+			}
 		}
 	}
-	if len(roles) == 0 {
+	// Routes to NAT instances may be pending, until the instances are created.
+	if len(roles) == 0 && res.IsZero() {
 		clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerRouteTable)
 	}
-	return reconcile.Result{}, nil
+	return res, nil
 }
 
 // reconcileDeleteRouteTable reconcile the destruction of the RouteTable of the cluster.
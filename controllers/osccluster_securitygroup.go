@@ -9,21 +9,97 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/security"
 	"github.com/outscale/osc-sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-// reconcileSecurityGroupAddRules reconciles rules for a securityGroup.
-func (r *OscClusterReconciler) reconcileSecurityGroupAddRules(ctx context.Context, clusterScope *scope.ClusterScope, securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) (reconcile.Result, error) {
-	log := ctrl.LoggerFrom(ctx)
+// securityGroupAuditInterval is the interval between two audits of securityGroups in audit mode.
+const securityGroupAuditInterval = 5 * time.Minute
 
+// getSecurityGroupMemberIds resolves the ids of the security groups referenced by a rule.
+func getSecurityGroupMemberIds(ctx context.Context, tracker *ClusterResourceTracker, clusterScope *scope.ClusterScope, members []infrastructurev1beta1.OscSecurityGroupMember) ([]string, error) {
+	var ids []string
+	for _, member := range members {
+		if member.SecurityGroupId != "" {
+			ids = append(ids, member.SecurityGroupId)
+			continue
+		}
+		found := false
+		for _, sg := range clusterScope.GetSecurityGroups() {
+			if (member.Name != "" && sg.Name != member.Name) || (member.Role != "" && !sg.HasRole(member.Role)) {
+				continue
+			}
+			id, err := tracker.getSecurityGroupId(ctx, sg, clusterScope)
+			if err != nil {
+				return nil, fmt.Errorf("get securityGroup member: %w", err)
+			}
+			found = true
+			ids = append(ids, id)
+		}
+		if !found {
+			return nil, fmt.Errorf("no securityGroup found for member name=%q role=%q", member.Name, member.Role)
+		}
+	}
+	return ids, nil
+}
+
+// isOwnSecurityGroupMember returns true if a member of an existing rule has been created from the spec, and not by the CCM.
+// Only rules referencing a securityGroup of the cluster, or a securityGroup id found in the spec, are considered.
+func isOwnSecurityGroupMember(clusterScope *scope.ClusterScope, memberId string) bool {
+	if slices.Contains(slices.Collect(maps.Values(clusterScope.GetResources().SecurityGroup)), memberId) {
+		return true
+	}
+	for _, sg := range clusterScope.GetSecurityGroups() {
+		for _, rule := range sg.SecurityGroupRules {
+			if slices.ContainsFunc(rule.SecurityGroupMembers, func(m infrastructurev1beta1.OscSecurityGroupMember) bool {
+				return m.SecurityGroupId == memberId
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// securityGroupRule is a single rule of a securityGroup, either for an ip range or a securityGroup member.
+type securityGroupRule struct {
+	flow, protocol    string
+	ipRange, memberId string
+	fromPort, toPort  int32
+}
+
+func (sgr securityGroupRule) String() string {
+	target := sgr.ipRange
+	if sgr.memberId != "" {
+		target = sgr.memberId
+	}
+	return fmt.Sprintf("%s %s %d-%d %s", sgr.flow, sgr.protocol, sgr.fromPort, sgr.toPort, target)
+}
+
+func (sgr securityGroupRule) logValues() []any {
+	if sgr.memberId != "" {
+		return []any{"flow", sgr.flow, "securityGroupMemberId", sgr.memberId, "protocol", sgr.protocol, "fromPort", sgr.fromPort, "toPort", sgr.toPort}
+	}
+	return []any{"flow", sgr.flow, "ipRange", sgr.ipRange, "protocol", sgr.protocol, "fromPort", sgr.fromPort, "toPort", sgr.toPort}
+}
+
+// getMissingSecurityGroupRules returns the rules of the spec not found in a securityGroup.
+func getMissingSecurityGroupRules(ctx context.Context, tracker *ClusterResourceTracker, clusterScope *scope.ClusterScope, securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) ([]securityGroupRule, error) {
+	var missing []securityGroupRule
 	for _, securityGroupRuleSpec := range securityGroupRulesSpec {
 		var rules []osc.SecurityGroupRule
 		switch strings.ToLower(securityGroupRuleSpec.Flow) {
@@ -36,40 +112,48 @@ func (r *OscClusterReconciler) reconcileSecurityGroupAddRules(ctx context.Contex
 		protocol := securityGroupRuleSpec.IpProtocol
 		fromPort := securityGroupRuleSpec.GetFromPortRange()
 		toPort := securityGroupRuleSpec.GetToPortRange()
-		var existingRanges []string
+		var existingRanges, existingMembers []string
 		for _, rule := range rules {
 			if rule.GetFromPortRange() != fromPort || rule.GetToPortRange() != toPort || rule.GetIpProtocol() != protocol {
 				continue
 			}
 			existingRanges = append(existingRanges, rule.GetIpRanges()...)
+			for _, member := range rule.GetSecurityGroupsMembers() {
+				existingMembers = append(existingMembers, member.GetSecurityGroupId())
+			}
 		}
-		ipRanges := securityGroupRuleSpec.GetIpRanges()
-		for _, ipRange := range ipRanges {
-			if slices.Contains(existingRanges, ipRange) {
-				continue
+		memberIds, err := getSecurityGroupMemberIds(ctx, tracker, clusterScope, securityGroupRuleSpec.SecurityGroupMembers)
+		if err != nil {
+			return nil, err
+		}
+		var toAdd []securityGroupRule
+		for _, ipRange := range securityGroupRuleSpec.GetIpRanges() {
+			if !slices.Contains(existingRanges, ipRange) {
+				toAdd = append(toAdd, securityGroupRule{flow: flow, protocol: protocol, ipRange: ipRange, fromPort: fromPort, toPort: toPort})
 			}
-			log.V(2).Info("Creating securityGroupRule", "flow", flow, "ipRange", ipRange, "protocol", protocol, "fromPort", fromPort, "toPort", toPort)
-			_, err := r.Cloud.SecurityGroup(clusterScope.Tenant).CreateSecurityGroupRule(ctx, sg.GetSecurityGroupId(), flow, protocol, ipRange, "", fromPort, toPort)
-			if err != nil {
-				return reconcile.Result{}, fmt.Errorf("cannot create securityGroupRule: %w", err)
+		}
+		for _, memberId := range memberIds {
+			if !slices.Contains(existingMembers, memberId) {
+				toAdd = append(toAdd, securityGroupRule{flow: flow, protocol: protocol, memberId: memberId, fromPort: fromPort, toPort: toPort})
+			}
+		}
+		for _, rule := range toAdd {
+			if !slices.Contains(missing, rule) {
+				missing = append(missing, rule)
 			}
 		}
 	}
-	return reconcile.Result{}, nil
+	return missing, nil
 }
 
-// reconcileSecurityGroupDeleteRules deletes all rules not in spec for a securityGroup.
-func (r *OscClusterReconciler) reconcileSecurityGroupDeleteRules(ctx context.Context, clusterScope *scope.ClusterScope, securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) (reconcile.Result, error) {
+// getExtraSecurityGroupRules returns the rules of a securityGroup not found in the spec.
+// Rules created by the CCM, referencing the securityGroups of service load-balancers, are ignored.
+func getExtraSecurityGroupRules(ctx context.Context, tracker *ClusterResourceTracker, clusterScope *scope.ClusterScope, securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) ([]securityGroupRule, error) {
 	log := ctrl.LoggerFrom(ctx)
+	var extra []securityGroupRule
 	var checkRules = func(flow string, rules []osc.SecurityGroupRule) error {
 		for _, rule := range rules {
-			// Skipping rule created by CCM. There is no way to be sure this comes from the CCM,
-			// but the CCM creates rules with an associated SG, and the config from the CRD only uses ipRanges.
-			if len(rule.GetSecurityGroupsMembers()) > 0 {
-				log.V(5).Info("Skipping rule associated with another SG")
-				continue
-			}
-			var okRanges []string
+			var okRanges, okMembers []string
 			for _, spec := range securityGroupRulesSpec {
 				if flow != spec.Flow ||
 					rule.GetFromPortRange() != spec.GetFromPortRange() || rule.GetToPortRange() != spec.GetToPortRange() ||
@@ -77,37 +161,145 @@ func (r *OscClusterReconciler) reconcileSecurityGroupDeleteRules(ctx context.Con
 					continue
 				}
 				okRanges = append(okRanges, spec.GetIpRanges()...)
+				memberIds, err := getSecurityGroupMemberIds(ctx, tracker, clusterScope, spec.SecurityGroupMembers)
+				if err != nil {
+					return err
+				}
+				okMembers = append(okMembers, memberIds...)
 			}
-			ipRanges := rule.GetIpRanges()
-			for _, ipRange := range ipRanges {
-				if slices.Contains(okRanges, ipRange) {
+			for _, ipRange := range rule.GetIpRanges() {
+				if !slices.Contains(okRanges, ipRange) {
+					extra = append(extra, securityGroupRule{flow: flow, protocol: rule.GetIpProtocol(), ipRange: ipRange, fromPort: rule.GetFromPortRange(), toPort: rule.GetToPortRange()})
+				}
+			}
+			for _, member := range rule.GetSecurityGroupsMembers() {
+				memberId := member.GetSecurityGroupId()
+				if slices.Contains(okMembers, memberId) {
 					continue
 				}
-				log.V(2).Info("Deleting securityGroupRule", "flow", flow, "ipRange", ipRange, "protocol", rule.GetIpProtocol(), "fromPort", rule.GetFromPortRange(), "toPort", rule.GetToPortRange())
-				err := r.Cloud.SecurityGroup(clusterScope.Tenant).DeleteSecurityGroupRule(ctx, sg.GetSecurityGroupId(), flow, rule.GetIpProtocol(), ipRange, "", rule.GetFromPortRange(), rule.GetToPortRange())
-				if err != nil {
-					return fmt.Errorf("cannot create securityGroupRule: %w", err)
+				if !isOwnSecurityGroupMember(clusterScope, memberId) {
+					log.V(5).Info("Skipping rule associated with another SG", "securityGroupMemberId", memberId)
+					continue
 				}
+				extra = append(extra, securityGroupRule{flow: flow, protocol: rule.GetIpProtocol(), memberId: memberId, fromPort: rule.GetFromPortRange(), toPort: rule.GetToPortRange()})
 			}
 		}
 		return nil
 	}
 	err := checkRules("Inbound", sg.GetInboundRules())
 	if err != nil {
-		return reconcile.Result{}, err
+		return nil, err
 	}
 	err = checkRules("Outbound", sg.GetOutboundRules())
 	if err != nil {
-		return reconcile.Result{}, err
+		return nil, err
 	}
-	return reconcile.Result{}, nil
+	return extra, nil
+}
+
+// reconcileSecurityGroupAddRules creates the rules of the spec missing from a securityGroup.
+// It is shared by cluster securityGroups and machine dedicated securityGroups.
+func reconcileSecurityGroupAddRules(ctx context.Context, svc security.OscSecurityGroupInterface, tracker *ClusterResourceTracker, clusterScope *scope.ClusterScope,
+	securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) error {
+	log := ctrl.LoggerFrom(ctx)
+	missing, err := getMissingSecurityGroupRules(ctx, tracker, clusterScope, securityGroupRulesSpec, sg)
+	if err != nil {
+		return err
+	}
+	// Rules are created in a single call per flow.
+	for _, flow := range []string{"Inbound", "Outbound"} {
+		var rules []osc.SecurityGroupRule
+		for _, rule := range missing {
+			if rule.flow != flow {
+				continue
+			}
+			log.V(2).Info("Creating securityGroupRule", rule.logValues()...)
+			rules = appendOAPISecurityGroupRule(rules, rule)
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		err := svc.CreateSecurityGroupRules(ctx, sg.GetSecurityGroupId(), flow, rules)
+		if err != nil {
+			return fmt.Errorf("cannot create securityGroupRules: %w", err)
+		}
+	}
+	return nil
+}
+
+// appendOAPISecurityGroupRule adds a rule to a list of OAPI rules, grouping IP ranges and members having the same protocol and ports.
+func appendOAPISecurityGroupRule(rules []osc.SecurityGroupRule, rule securityGroupRule) []osc.SecurityGroupRule {
+	for i := range rules {
+		r := &rules[i]
+		if r.GetIpProtocol() != rule.protocol || r.GetFromPortRange() != rule.fromPort || r.GetToPortRange() != rule.toPort {
+			continue
+		}
+		switch {
+		case rule.ipRange != "" && r.HasIpRanges():
+			r.SetIpRanges(append(r.GetIpRanges(), rule.ipRange))
+			return rules
+		case rule.memberId != "" && r.HasSecurityGroupsMembers():
+			r.SetSecurityGroupsMembers(append(r.GetSecurityGroupsMembers(), osc.SecurityGroupsMember{SecurityGroupId: &rule.memberId}))
+			return rules
+		}
+	}
+	r := osc.SecurityGroupRule{
+		IpProtocol:    &rule.protocol,
+		FromPortRange: &rule.fromPort,
+		ToPortRange:   &rule.toPort,
+	}
+	if rule.memberId != "" {
+		r.SecurityGroupsMembers = &[]osc.SecurityGroupsMember{{SecurityGroupId: &rule.memberId}}
+	} else {
+		r.IpRanges = &[]string{rule.ipRange}
+	}
+	return append(rules, r)
+}
+
+// reconcileSecurityGroupDeleteRules deletes all rules not in spec for a securityGroup.
+// It is shared by cluster securityGroups and machine dedicated securityGroups.
+func reconcileSecurityGroupDeleteRules(ctx context.Context, svc security.OscSecurityGroupInterface, tracker *ClusterResourceTracker, clusterScope *scope.ClusterScope,
+	securityGroupRulesSpec []infrastructurev1beta1.OscSecurityGroupRule, sg *osc.SecurityGroup) error {
+	log := ctrl.LoggerFrom(ctx)
+	extra, err := getExtraSecurityGroupRules(ctx, tracker, clusterScope, securityGroupRulesSpec, sg)
+	if err != nil {
+		return err
+	}
+	for _, rule := range extra {
+		log.V(2).Info("Deleting securityGroupRule", rule.logValues()...)
+		err := svc.DeleteSecurityGroupRule(ctx, sg.GetSecurityGroupId(), rule.flow, rule.protocol, rule.ipRange, rule.memberId, rule.fromPort, rule.toPort)
+		if err != nil {
+			return fmt.Errorf("cannot delete securityGroupRule: %w", err)
+		}
+	}
+	return nil
+}
+
+// getSecurityGroupRules returns the rules of a securityGroup, including the rules computed from the cluster config.
+func (r *OscClusterReconciler) getSecurityGroupRules(ctx context.Context, clusterScope *scope.ClusterScope, securityGroupSpec infrastructurev1beta1.OscSecurityGroup) ([]infrastructurev1beta1.OscSecurityGroupRule, error) {
+	securityGroupRulesSpec := securityGroupSpec.SecurityGroupRules
+	if securityGroupSpec.HasRole(infrastructurev1beta1.RoleLoadBalancer) && clusterScope.HasIPRestriction() {
+		ips, err := r.listNATPublicIPs(ctx, clusterScope, true)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list NAT public IPs: %w", err)
+		}
+		securityGroupRulesSpec = append(slices.Clone(securityGroupRulesSpec), infrastructurev1beta1.OscSecurityGroupRule{
+			Flow:          "Inbound",
+			IpProtocol:    "tcp",
+			FromPortRange: infrastructurev1beta1.APIPort,
+			ToPortRange:   infrastructurev1beta1.APIPort,
+			IpRanges:      ips,
+		})
+	}
+	return securityGroupRulesSpec, nil
 }
 
 // reconcileSecurityGroup reconcile the securityGroup of the cluster.
 func (r *OscClusterReconciler) reconcileSecurityGroup(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerSecurityGroup) {
+	// Rules are also checked after an upgrade changing the CNI presets.
+	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerSecurityGroup) && !clusterScope.NeedCNIPresetUpdate() {
 		log.V(4).Info("No need for securityGroup reconciliation")
 		return reconcile.Result{}, nil
 	}
@@ -123,7 +315,9 @@ func (r *OscClusterReconciler) reconcileSecurityGroup(ctx context.Context, clust
 	}
 	securityGroupSvc := r.Cloud.SecurityGroup(clusterScope.Tenant)
 	securityGroupsSpec := clusterScope.GetSecurityGroups()
-	for _, securityGroupSpec := range securityGroupsSpec {
+	// All securityGroups are created before rules are checked, as rules may reference other securityGroups.
+	securityGroups := make([]*osc.SecurityGroup, len(securityGroupsSpec))
+	for i, securityGroupSpec := range securityGroupsSpec {
 		securityGroup, err := r.Tracker.getSecurityGroup(ctx, securityGroupSpec, clusterScope)
 		switch {
 		case errors.Is(err, ErrNoResourceFound):
@@ -139,33 +333,134 @@ func (r *OscClusterReconciler) reconcileSecurityGroup(ctx context.Context, clust
 		case err != nil:
 			return reconcile.Result{}, fmt.Errorf("get existing: %w", err)
 		}
-		securityGroupRulesSpec := securityGroupSpec.SecurityGroupRules
-		if securityGroupSpec.HasRole(infrastructurev1beta1.RoleLoadBalancer) && clusterScope.HasIPRestriction() {
-			ips, err := r.listNATPublicIPs(ctx, clusterScope, true)
-			if err != nil {
-				return reconcile.Result{}, fmt.Errorf("cannot list NAT public IPs: %w", err)
-			}
-			securityGroupRulesSpec = append(securityGroupRulesSpec, infrastructurev1beta1.OscSecurityGroupRule{
-				Flow:          "Inbound",
-				IpProtocol:    "tcp",
-				FromPortRange: infrastructurev1beta1.APIPort,
-				ToPortRange:   infrastructurev1beta1.APIPort,
-				IpRanges:      ips,
-			})
+		securityGroups[i] = securityGroup
+	}
+	err = r.attachOverflowSecurityGroups(ctx, clusterScope, securityGroupsSpec, securityGroups)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i, securityGroupSpec := range securityGroupsSpec {
+		if securityGroupSpec.Audit {
+			log.V(4).Info("SecurityGroup is in audit mode, not checking rules", "securityGroupId", securityGroups[i].GetSecurityGroupId())
+			continue
+		}
+		securityGroup := securityGroups[i]
+		securityGroupRulesSpec, err := r.getSecurityGroupRules(ctx, clusterScope, securityGroupSpec)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
 		log.V(4).Info("Checking securityGroup rules", "securityGroupId", securityGroup.GetSecurityGroupId())
-		_, err = r.reconcileSecurityGroupAddRules(ctx, clusterScope, securityGroupRulesSpec, securityGroup)
+		err = reconcileSecurityGroupAddRules(ctx, securityGroupSvc, r.Tracker, clusterScope, securityGroupRulesSpec, securityGroup)
 		if err == nil && securityGroupSpec.Authoritative {
-			_, err = r.reconcileSecurityGroupDeleteRules(ctx, clusterScope, securityGroupRulesSpec, securityGroup)
+			err = reconcileSecurityGroupDeleteRules(ctx, securityGroupSvc, r.Tracker, clusterScope, securityGroupRulesSpec, securityGroup)
 		}
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("check rules: %w", err)
 		}
 	}
 	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerSecurityGroup)
+	clusterScope.SetCNIPresetVersion()
 	return reconcile.Result{}, nil
 }
 
+// attachOverflowSecurityGroups attaches overflow securityGroups to the VMs having their base securityGroup.
+// This is done before rules are moved from the base securityGroup, as existing VMs would otherwise lose them.
+func (r *OscClusterReconciler) attachOverflowSecurityGroups(ctx context.Context, clusterScope *scope.ClusterScope,
+	securityGroupsSpec []infrastructurev1beta1.OscSecurityGroup, securityGroups []*osc.SecurityGroup) error {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.VM(clusterScope.Tenant)
+	base := -1
+	for i, securityGroupSpec := range securityGroupsSpec {
+		if !clusterScope.IsOverflowSecurityGroup(securityGroupSpec) {
+			base = i
+			continue
+		}
+		if base < 0 {
+			continue
+		}
+		overflowId := securityGroups[i].GetSecurityGroupId()
+		vms, err := svc.ListVmsFromSecurityGroup(ctx, securityGroups[base].GetSecurityGroupId())
+		if err != nil {
+			return fmt.Errorf("cannot list vms: %w", err)
+		}
+		for _, vm := range vms {
+			current := make([]string, 0, len(vm.GetSecurityGroups())+1)
+			for _, sg := range vm.GetSecurityGroups() {
+				current = append(current, sg.GetSecurityGroupId())
+			}
+			if slices.Contains(current, overflowId) {
+				continue
+			}
+			log.V(2).Info("Attaching overflow securityGroup to VM", "vmId", vm.GetVmId(), "securityGroupId", overflowId)
+			err := svc.UpdateVmSecurityGroups(ctx, vm.GetVmId(), append(current, overflowId))
+			if err != nil {
+				return fmt.Errorf("cannot attach overflow securityGroup: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileSecurityGroupAudit compares the rules of the securityGroups in audit mode with the spec, and reports any drift
+// in the SecurityGroupDrift condition. Rules are never changed.
+func (r *OscClusterReconciler) reconcileSecurityGroupAudit(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	var audited []infrastructurev1beta1.OscSecurityGroup
+	for _, securityGroupSpec := range clusterScope.GetSecurityGroups() {
+		if securityGroupSpec.Audit {
+			audited = append(audited, securityGroupSpec)
+		}
+	}
+	status := &clusterScope.OscCluster.Status
+	if len(audited) == 0 {
+		conditions.Delete(clusterScope.OscCluster, infrastructurev1beta1.SecurityGroupDriftCondition)
+		status.SecurityGroupAuditedAt = nil
+		return reconcile.Result{}, nil
+	}
+	if status.SecurityGroupAuditedAt != nil && time.Since(status.SecurityGroupAuditedAt.Time) < securityGroupAuditInterval {
+		return reconcile.Result{RequeueAfter: securityGroupAuditInterval - time.Since(status.SecurityGroupAuditedAt.Time)}, nil
+	}
+	log.V(4).Info("Auditing securityGroups")
+	var drifts []string
+	for _, securityGroupSpec := range audited {
+		securityGroup, err := r.Tracker.getSecurityGroup(ctx, securityGroupSpec, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("get securityGroup: %w", err)
+		}
+		securityGroupRulesSpec, err := r.getSecurityGroupRules(ctx, clusterScope, securityGroupSpec)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		missing, err := getMissingSecurityGroupRules(ctx, r.Tracker, clusterScope, securityGroupRulesSpec, securityGroup)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		extra, err := getExtraSecurityGroupRules(ctx, r.Tracker, clusterScope, securityGroupRulesSpec, securityGroup)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(missing) == 0 && len(extra) == 0 {
+			continue
+		}
+		drift := fmt.Sprintf("%s: missing %v, extra %v", securityGroup.GetSecurityGroupId(), missing, extra)
+		log.V(2).Info("SecurityGroup drift detected", "securityGroupId", securityGroup.GetSecurityGroupId(), "missing", len(missing), "extra", len(extra))
+		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeWarning, infrastructurev1beta1.SecurityGroupDriftDetectedReason, "Security group %s", drift)
+		drifts = append(drifts, drift)
+	}
+	if len(drifts) > 0 {
+		conditions.Set(clusterScope.OscCluster, &clusterv1.Condition{
+			Type:    infrastructurev1beta1.SecurityGroupDriftCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  infrastructurev1beta1.SecurityGroupDriftDetectedReason,
+			Message: strings.Join(drifts, "; "),
+		})
+	} else {
+		conditions.Delete(clusterScope.OscCluster, infrastructurev1beta1.SecurityGroupDriftCondition)
+	}
+	status.SecurityGroupAuditedAt = ptr.To(metav1.Now())
+	return reconcile.Result{RequeueAfter: securityGroupAuditInterval}, nil
+}
+
 // reconcileDeleteSecurityGroup reconcile the deletetion of securityGroup of the cluster.
 func (r *OscClusterReconciler) reconcileDeleteSecurityGroup(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -9,11 +9,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -31,8 +39,12 @@ func (r *OscClusterReconciler) reconcileSubnets(ctx context.Context, clusterScop
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	subnetSpecs, err := clusterScope.ListSubnets()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	svc := r.Cloud.Subnet(clusterScope.Tenant)
-	for _, subnetSpec := range clusterScope.GetSubnets() {
+	for _, subnetSpec := range subnetSpecs {
 		subnet, err := r.Tracker.getSubnet(ctx, subnetSpec, clusterScope)
 		switch {
 		case errors.Is(err, ErrNoResourceFound):
@@ -53,19 +65,207 @@ func (r *OscClusterReconciler) reconcileSubnets(ctx context.Context, clusterScop
 		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.SubnetCreatedReason, "Subnet created %v %s", subnetSpec.Roles, subnetSpec.SubregionName)
 	}
 
-	// add failureDomains
+	// set failureDomains, removing those without controlplane subnets
+	failureDomains := clusterv1.FailureDomains{}
 	for _, subnetSpec := range clusterScope.GetSubnets() {
 		if clusterScope.SubnetHasRole(subnetSpec, infrastructurev1beta1.RoleControlPlane) {
-			clusterScope.SetFailureDomain(clusterScope.GetSubnetSubregion(subnetSpec), clusterv1.FailureDomainSpec{
+			failureDomains[clusterScope.GetSubnetSubregion(subnetSpec)] = clusterv1.FailureDomainSpec{
 				ControlPlane: true,
-			})
+			}
 		}
 	}
+	clusterScope.SetFailureDomains(failureDomains)
+
+	res, err := r.reconcileRemovedSubnets(ctx, clusterScope, netId)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
 
 	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerSubnet)
 	return reconcile.Result{}, nil
 }
 
+const (
+	subnetCapacityRefreshInterval = 5 * time.Minute
+	// below this number of available IPs, a warning event is sent.
+	lowSubnetCapacity = 16
+)
+
+// reconcileSubnetCapacity refreshes the number of available IPs of each subnet in status.
+// A requeue is returned for the next refresh.
+func (r *OscClusterReconciler) reconcileSubnetCapacity(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	status := &clusterScope.OscCluster.Status
+	if status.SubnetCapacityUpdatedAt != nil && time.Since(status.SubnetCapacityUpdatedAt.Time) < subnetCapacityRefreshInterval {
+		return reconcile.Result{RequeueAfter: subnetCapacityRefreshInterval - time.Since(status.SubnetCapacityUpdatedAt.Time)}, nil
+	}
+	log.V(4).Info("Refreshing subnet capacity")
+	specs := clusterScope.GetSubnets()
+	ids := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		id, err := r.Tracker.getSubnetId(ctx, spec, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("get subnet: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	subnets, err := r.Cloud.Subnet(clusterScope.Tenant).ListSubnets(ctx, ids)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot list subnets: %w", err)
+	}
+	available := make(map[string]int32, len(subnets))
+	for _, subnet := range subnets {
+		available[subnet.GetSubnetId()] = subnet.GetAvailableIpsCount()
+	}
+	capacity := make([]infrastructurev1beta1.OscSubnetCapacity, 0, len(specs))
+	for i, spec := range specs {
+		capacity = append(capacity, infrastructurev1beta1.OscSubnetCapacity{
+			SubnetId:      ids[i],
+			IpSubnetRange: spec.IpSubnetRange,
+			SubregionName: clusterScope.GetSubnetSubregion(spec),
+			Roles:         spec.Roles,
+			AvailableIps:  available[ids[i]],
+		})
+		if available[ids[i]] < lowSubnetCapacity {
+			r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeWarning, infrastructurev1beta1.SubnetLowCapacityReason, "Subnet %s has only %d available IPs", spec.IpSubnetRange, available[ids[i]])
+		}
+	}
+	status.SubnetCapacity = capacity
+	status.SubnetCapacityUpdatedAt = ptr.To(metav1.Now())
+	return reconcile.Result{RequeueAfter: subnetCapacityRefreshInterval}, nil
+}
+
+// reconcileRemovedSubnets deletes the subnets that have been removed from the spec.
+// Subnets still used by a VM, a NAT service or a load balancer are kept, and reported in the SubnetsRemoved condition.
+func (r *OscClusterReconciler) reconcileRemovedSubnets(ctx context.Context, clusterScope *scope.ClusterScope, netId string) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if clusterScope.GetNetwork().UseExisting.Net {
+		return reconcile.Result{}, nil
+	}
+	subnetSpecs, err := clusterScope.ListSubnets()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// An empty list would delete all subnets, it is never expected.
+	if len(subnetSpecs) == 0 {
+		return reconcile.Result{}, errors.New("no subnet in spec, refusing to delete subnets")
+	}
+	rsrc := clusterScope.GetResources()
+	inSpec := map[string]bool{}
+	for _, subnetSpec := range subnetSpecs {
+		inSpec[subnetSpec.IpSubnetRange] = true
+	}
+	users := subnetUsers{}
+	var blocked []string
+	for _, ipRange := range slices.Sorted(maps.Keys(rsrc.Subnet)) {
+		if inSpec[ipRange] {
+			continue
+		}
+		subnetId := rsrc.Subnet[ipRange]
+		used, err := users.list(ctx, r, clusterScope, netId, subnetId)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot check subnet usage: %w", err)
+		}
+		if len(used) > 0 {
+			log.V(3).Info("Subnet has been removed but is still in use", "subnetId", subnetId, "usedBy", used)
+			blocked = append(blocked, fmt.Sprintf("%s is used by %s", ipRange, strings.Join(used, ", ")))
+			continue
+		}
+		err = r.unlinkSubnetRouteTable(ctx, clusterScope, netId, subnetId)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		log.V(2).Info("Deleting removed subnet", "subnetId", subnetId)
+		err = r.Cloud.Subnet(clusterScope.Tenant).DeleteSubnet(ctx, subnetId)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot delete subnet: %w", err)
+		}
+		delete(rsrc.Subnet, ipRange)
+		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.SubnetDeletedReason, "Subnet deleted %s", ipRange)
+	}
+	switch {
+	case len(blocked) > 0:
+		conditions.MarkFalse(clusterScope.OscCluster, infrastructurev1beta1.SubnetsRemovedCondition, infrastructurev1beta1.SubnetInUseReason, clusterv1.ConditionSeverityWarning,
+			"%s", strings.Join(blocked, "; "))
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	case conditions.Has(clusterScope.OscCluster, infrastructurev1beta1.SubnetsRemovedCondition):
+		conditions.MarkTrue(clusterScope.OscCluster, infrastructurev1beta1.SubnetsRemovedCondition)
+	}
+	return reconcile.Result{}, nil
+}
+
+// subnetUsers lists the resources using a subnet, NAT services and load balancers being only fetched once.
+type subnetUsers struct {
+	nats []osc.NatService
+	lbs  []osc.LoadBalancer
+}
+
+func (u *subnetUsers) list(ctx context.Context, r *OscClusterReconciler, clusterScope *scope.ClusterScope, netId, subnetId string) ([]string, error) {
+	var used []string
+	vms, err := r.Cloud.VM(clusterScope.Tenant).ListVmsFromSubnet(ctx, subnetId)
+	if err != nil {
+		return nil, fmt.Errorf("list vms: %w", err)
+	}
+	for _, vm := range vms {
+		used = append(used, "vm "+vm.GetVmId())
+	}
+	if u.nats == nil {
+		u.nats, err = r.Cloud.NatService(clusterScope.Tenant).ListNatServices(ctx, netId)
+		if err != nil {
+			return nil, fmt.Errorf("list natServices: %w", err)
+		}
+	}
+	for _, nat := range u.nats {
+		if nat.GetSubnetId() == subnetId && nat.GetState() != "deleting" && nat.GetState() != "deleted" {
+			used = append(used, "natService "+nat.GetNatServiceId())
+		}
+	}
+	if u.lbs == nil {
+		u.lbs, err = r.Cloud.LoadBalancer(clusterScope.Tenant).ListLoadBalancers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list loadBalancers: %w", err)
+		}
+	}
+	for _, lb := range u.lbs {
+		if slices.Contains(lb.GetSubnets(), subnetId) {
+			used = append(used, "loadBalancer "+lb.GetLoadBalancerName())
+		}
+	}
+	return used, nil
+}
+
+// unlinkSubnetRouteTable unlinks the route table of a subnet, and deletes it if no other subnet is linked.
+func (r *OscClusterReconciler) unlinkSubnetRouteTable(ctx context.Context, clusterScope *scope.ClusterScope, netId, subnetId string) error {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.RouteTable(clusterScope.Tenant)
+	rtbls, err := svc.GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return fmt.Errorf("cannot list routeTables: %w", err)
+	}
+	for _, rtbl := range rtbls {
+		links := rtbl.GetLinkRouteTables()
+		idx := slices.IndexFunc(links, func(link osc.LinkRouteTable) bool { return link.GetSubnetId() == subnetId })
+		if idx < 0 {
+			continue
+		}
+		log.V(2).Info("Unlinking routeTable", "routeTableId", rtbl.GetRouteTableId(), "subnetId", subnetId)
+		err = svc.UnlinkRouteTable(ctx, links[idx].GetLinkRouteTableId())
+		if err != nil {
+			return fmt.Errorf("cannot unlink routeTable: %w", err)
+		}
+		if len(links) > 1 || slices.ContainsFunc(links, func(link osc.LinkRouteTable) bool { return link.GetMain() }) {
+			return nil
+		}
+		log.V(2).Info("Deleting routeTable", "routeTableId", rtbl.GetRouteTableId())
+		err = svc.DeleteRouteTable(ctx, rtbl.GetRouteTableId())
+		if err != nil {
+			return fmt.Errorf("cannot delete routeTable: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
+
 // reconcileDeleteSubnet reconcile the destruction of the Subnet of the cluster.
 func (r *OscClusterReconciler) reconcileDeleteSubnets(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -90,5 +290,24 @@ func (r *OscClusterReconciler) reconcileDeleteSubnets(ctx context.Context, clust
 			return reconcile.Result{}, fmt.Errorf("cannot delete subnet: %w", err)
 		}
 	}
+	// Delete subnets removed from the spec, whose deletion had been blocked.
+	rsrc := clusterScope.GetResources()
+	for _, ipRange := range slices.Sorted(maps.Keys(rsrc.Subnet)) {
+		if slices.ContainsFunc(subnetsSpec, func(spec infrastructurev1beta1.OscSubnet) bool { return spec.IpSubnetRange == ipRange }) {
+			continue
+		}
+		subnet, err := svc.GetSubnet(ctx, rsrc.Subnet[ipRange])
+		switch {
+		case err != nil:
+			return reconcile.Result{}, fmt.Errorf("find existing: %w", err)
+		case subnet != nil:
+			log.V(2).Info("Deleting removed subnet", "subnetId", subnet.GetSubnetId())
+			err = svc.DeleteSubnet(ctx, subnet.GetSubnetId())
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot delete subnet: %w", err)
+			}
+		}
+		delete(rsrc.Subnet, ipRange)
+	}
 	return reconcile.Result{}, nil
 }
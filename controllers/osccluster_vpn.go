@@ -0,0 +1,404 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const defaultVpnBgpAsn = 65000
+
+// hasVpn returns true if the VPN is enabled, or if VPN resources remain after the VPN has been disabled.
+func hasVpn(clusterScope *scope.ClusterScope) bool {
+	if clusterScope.GetNetwork().Vpn.Enable {
+		return true
+	}
+	rsrc := clusterScope.GetResources()
+	return len(rsrc.VpnConnection) > 0 || len(rsrc.ClientGateway) > 0
+}
+
+// hasVirtualGateway returns true if a virtual gateway is required by the spec or remains in the status.
+func hasVirtualGateway(clusterScope *scope.ClusterScope) bool {
+	network := clusterScope.GetNetwork()
+	return network.Vpn.Enable || network.DirectLink.DirectLinkId != "" || getResource(defaultResource, clusterScope.GetResources().VirtualGateway) != ""
+}
+
+// reconcileVirtualGateway creates the virtual gateway, links it to the net and enables route propagation to all route tables.
+func (r *OscClusterReconciler) reconcileVirtualGateway(ctx context.Context, clusterScope *scope.ClusterScope, netId string) (*osc.VirtualGateway, error) {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.VirtualGateway(clusterScope.Tenant)
+	vgw, err := r.Tracker.getVirtualGateway(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		log.V(3).Info("Creating virtual gateway")
+		vgw, err = svc.CreateVirtualGateway(ctx, clusterScope.GetUID(), clusterScope.GetVirtualGatewayName())
+		if err != nil {
+			return nil, fmt.Errorf("cannot create virtual gateway: %w", err)
+		}
+		log.V(2).Info("Created virtual gateway", "virtualGatewayId", vgw.GetVirtualGatewayId())
+		r.Tracker.setVirtualGatewayId(clusterScope, vgw.GetVirtualGatewayId())
+		r.Recorder.Event(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.VpnCreatedReason, "Virtual gateway created")
+	case err != nil:
+		return nil, fmt.Errorf("get existing virtual gateway: %w", err)
+	}
+	if !slices.ContainsFunc(vgw.GetNetToVirtualGatewayLinks(), func(l osc.NetToVirtualGatewayLink) bool {
+		return l.GetNetId() == netId && (l.GetState() == "attached" || l.GetState() == "attaching")
+	}) {
+		log.V(2).Info("Linking virtual gateway", "virtualGatewayId", vgw.GetVirtualGatewayId(), "netId", netId)
+		err = svc.LinkVirtualGateway(ctx, vgw.GetVirtualGatewayId(), netId)
+		if err != nil {
+			return nil, fmt.Errorf("cannot link virtual gateway: %w", err)
+		}
+	}
+	rtbls, err := r.Cloud.RouteTable(clusterScope.Tenant).GetRouteTablesFromNet(ctx, netId)
+	if err != nil {
+		return nil, fmt.Errorf("list route tables: %w", err)
+	}
+	for _, rtbl := range rtbls {
+		if slices.ContainsFunc(rtbl.GetRoutePropagatingVirtualGateways(), func(p osc.RoutePropagatingVirtualGateway) bool {
+			return p.GetVirtualGatewayId() == vgw.GetVirtualGatewayId()
+		}) {
+			continue
+		}
+		log.V(3).Info("Enabling route propagation", "routeTableId", rtbl.GetRouteTableId())
+		err = svc.UpdateRoutePropagation(ctx, vgw.GetVirtualGatewayId(), rtbl.GetRouteTableId(), true)
+		if err != nil {
+			return nil, fmt.Errorf("cannot enable route propagation: %w", err)
+		}
+	}
+	return vgw, nil
+}
+
+// reconcileVpnConnection creates the client gateway and VPN connection, and syncs its static routes.
+func (r *OscClusterReconciler) reconcileVpnConnection(ctx context.Context, clusterScope *scope.ClusterScope, conn infrastructurev1beta1.OscVpnConnection, vgwId string) (*osc.VpnConnection, error) {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.Vpn(clusterScope.Tenant)
+	cgw, err := r.Tracker.getClientGateway(ctx, conn, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		asn := conn.BgpAsn
+		if asn == 0 {
+			asn = defaultVpnBgpAsn
+		}
+		log.V(3).Info("Creating client gateway", "connection", conn.Name, "publicIp", conn.PublicIp)
+		cgw, err = svc.CreateClientGateway(ctx, conn.PublicIp, asn, clusterScope.GetUID(), clusterScope.GetVpnConnectionName(conn))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create client gateway: %w", err)
+		}
+		log.V(2).Info("Created client gateway", "clientGatewayId", cgw.GetClientGatewayId())
+		r.Tracker.setClientGatewayId(clusterScope, conn, cgw.GetClientGatewayId())
+	case err != nil:
+		return nil, fmt.Errorf("get existing client gateway: %w", err)
+	}
+	vpn, err := r.Tracker.getVpnConnection(ctx, conn, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		log.V(3).Info("Creating VPN connection", "connection", conn.Name)
+		vpn, err = svc.CreateVpnConnection(ctx, cgw.GetClientGatewayId(), vgwId, len(conn.StaticRoutes) > 0, clusterScope.GetUID(), clusterScope.GetVpnConnectionName(conn))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create vpn connection: %w", err)
+		}
+		log.V(2).Info("Created VPN connection", "vpnConnectionId", vpn.GetVpnConnectionId())
+		r.Tracker.setVpnConnectionId(clusterScope, conn, vpn.GetVpnConnectionId())
+		r.Recorder.Eventf(clusterScope.OscCluster, corev1.EventTypeNormal, infrastructurev1beta1.VpnCreatedReason, "VPN connection %s created", conn.Name)
+	case err != nil:
+		return nil, fmt.Errorf("get existing vpn connection: %w", err)
+	}
+	existing := map[string]bool{}
+	for _, route := range vpn.GetRoutes() {
+		existing[route.GetDestinationIpRange()] = true
+	}
+	for _, dest := range conn.StaticRoutes {
+		if existing[dest] {
+			delete(existing, dest)
+			continue
+		}
+		log.V(3).Info("Creating VPN static route", "vpnConnectionId", vpn.GetVpnConnectionId(), "destination", dest)
+		err = svc.CreateVpnConnectionRoute(ctx, vpn.GetVpnConnectionId(), dest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create vpn static route: %w", err)
+		}
+	}
+	for _, dest := range slices.Sorted(maps.Keys(existing)) {
+		log.V(3).Info("Deleting VPN static route", "vpnConnectionId", vpn.GetVpnConnectionId(), "destination", dest)
+		err = svc.DeleteVpnConnectionRoute(ctx, vpn.GetVpnConnectionId(), dest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot delete vpn static route: %w", err)
+		}
+	}
+	return vpn, nil
+}
+
+// reconcileVpnSecret stores the tunnel configuration of each VPN connection in a secret.
+func (r *OscClusterReconciler) reconcileVpnSecret(ctx context.Context, clusterScope *scope.ClusterScope, configs map[string][]byte) error {
+	log := ctrl.LoggerFrom(ctx)
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: clusterScope.GetNamespace(), Name: clusterScope.GetVpnSecretName()}
+	err := r.Client.Get(ctx, key, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: clusterScope.GetName(),
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: infrastructurev1beta1.GroupVersion.String(),
+					Kind:       "OscCluster",
+					Name:       clusterScope.OscCluster.Name,
+					UID:        clusterScope.OscCluster.UID,
+					Controller: ptr.To(true),
+				}},
+			},
+			Data: configs,
+		}
+		log.V(2).Info("Creating VPN secret", "secret", key.Name)
+		if err := r.Client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("cannot create vpn secret: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot get vpn secret: %w", err)
+	case maps.EqualFunc(secret.Data, configs, func(a, b []byte) bool { return string(a) == string(b) }):
+		return nil
+	}
+	secret.Data = configs
+	log.V(2).Info("Updating VPN secret", "secret", key.Name)
+	if err := r.Client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("cannot update vpn secret: %w", err)
+	}
+	return nil
+}
+
+// deleteVpnSecret deletes the secret storing the tunnel configurations, if any.
+func (r *OscClusterReconciler) deleteVpnSecret(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	log := ctrl.LoggerFrom(ctx)
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: clusterScope.GetNamespace(), Name: clusterScope.GetVpnSecretName()}
+	err := r.Client.Get(ctx, key, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot get vpn secret: %w", err)
+	}
+	log.V(2).Info("Deleting VPN secret", "secret", key.Name)
+	if err := r.Client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("cannot delete vpn secret: %w", err)
+	}
+	return nil
+}
+
+// reconcileVpn reconciles the virtual gateway and VPN connections of the cluster.
+func (r *OscClusterReconciler) reconcileVpn(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !clusterScope.NeedReconciliation(infrastructurev1beta1.ReconcilerVpn) {
+		log.V(4).Info("No need for vpn reconciliation")
+		return reconcile.Result{}, nil
+	}
+	log.V(4).Info("Reconciling vpn")
+
+	netId, err := r.Tracker.getNetId(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	vgw, err := r.reconcileVirtualGateway(ctx, clusterScope, netId)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	spec := clusterScope.GetNetwork().Vpn
+	configs := make(map[string][]byte, len(spec.Connections))
+	var pending bool
+	for _, conn := range spec.Connections {
+		vpn, err := r.reconcileVpnConnection(ctx, clusterScope, conn, vgw.GetVirtualGatewayId())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if vpn.GetClientGatewayConfiguration() == "" {
+			log.V(3).Info("VPN connection has no tunnel configuration yet", "vpnConnectionId", vpn.GetVpnConnectionId())
+			pending = true
+			continue
+		}
+		configs[conn.Name] = []byte(vpn.GetClientGatewayConfiguration())
+	}
+	deleted, err := r.deleteRemovedVpnConnections(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !deleted {
+		pending = true
+	}
+	if len(spec.Connections) > 0 {
+		err = r.reconcileVpnSecret(ctx, clusterScope, configs)
+	} else {
+		err = r.deleteVpnSecret(ctx, clusterScope)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if pending {
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	clusterScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerVpn)
+	return reconcile.Result{}, nil
+}
+
+// deleteVpnConnection deletes a VPN connection and its client gateway.
+// It returns false if the VPN connection is still being deleted.
+func (r *OscClusterReconciler) deleteVpnConnection(ctx context.Context, clusterScope *scope.ClusterScope, conn infrastructurev1beta1.OscVpnConnection) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.Vpn(clusterScope.Tenant)
+	rsrc := clusterScope.GetResources()
+	vpn, err := r.Tracker.getVpnConnection(ctx, conn, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return false, fmt.Errorf("get vpn connection: %w", err)
+	case vpn.GetState() == "deleting":
+		log.V(3).Info("VPN connection is being deleted", "vpnConnectionId", vpn.GetVpnConnectionId())
+		return false, nil
+	default:
+		log.V(2).Info("Deleting VPN connection", "vpnConnectionId", vpn.GetVpnConnectionId())
+		err = svc.DeleteVpnConnection(ctx, vpn.GetVpnConnectionId())
+		if err != nil {
+			return false, fmt.Errorf("cannot delete vpn connection: %w", err)
+		}
+		return false, nil
+	}
+	delete(rsrc.VpnConnection, conn.Name)
+	cgw, err := r.Tracker.getClientGateway(ctx, conn, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return false, fmt.Errorf("get client gateway: %w", err)
+	default:
+		log.V(2).Info("Deleting client gateway", "clientGatewayId", cgw.GetClientGatewayId())
+		err = svc.DeleteClientGateway(ctx, cgw.GetClientGatewayId())
+		if err != nil {
+			return false, fmt.Errorf("cannot delete client gateway: %w", err)
+		}
+	}
+	delete(rsrc.ClientGateway, conn.Name)
+	return true, nil
+}
+
+// deleteRemovedVpnConnections deletes the VPN connections having been removed from the spec, or all of them if the VPN is disabled.
+func (r *OscClusterReconciler) deleteRemovedVpnConnections(ctx context.Context, clusterScope *scope.ClusterScope) (bool, error) {
+	inSpec := map[string]bool{}
+	if clusterScope.GetNetwork().Vpn.Enable {
+		for _, conn := range clusterScope.GetNetwork().Vpn.Connections {
+			inSpec[conn.Name] = true
+		}
+	}
+	rsrc := clusterScope.GetResources()
+	// a client gateway may exist without its VPN connection if the connection could not be created.
+	names := slices.AppendSeq(slices.Collect(maps.Keys(rsrc.VpnConnection)), maps.Keys(rsrc.ClientGateway))
+	slices.Sort(names)
+	done := true
+	for _, name := range slices.Compact(names) {
+		if inSpec[name] {
+			continue
+		}
+		deleted, err := r.deleteVpnConnection(ctx, clusterScope, infrastructurev1beta1.OscVpnConnection{Name: name})
+		if err != nil {
+			return false, err
+		}
+		done = done && deleted
+	}
+	return done, nil
+}
+
+// reconcileDeleteVpn deletes the VPN connections and client gateways of the cluster.
+func (r *OscClusterReconciler) reconcileDeleteVpn(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	done := true
+	for _, conn := range clusterScope.GetNetwork().Vpn.Connections {
+		deleted, err := r.deleteVpnConnection(ctx, clusterScope, conn)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		done = done && deleted
+	}
+	deleted, err := r.deleteRemovedVpnConnections(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !done || !deleted {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	return reconcile.Result{}, r.deleteVpnSecret(ctx, clusterScope)
+}
+
+// reconcileDisabledVpn deletes the VPN connections, client gateways and secret remaining after the VPN has been disabled.
+func (r *OscClusterReconciler) reconcileDisabledVpn(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(3).Info("VPN is disabled, deleting VPN connections")
+	deleted, err := r.deleteRemovedVpnConnections(ctx, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !deleted {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	return reconcile.Result{}, r.deleteVpnSecret(ctx, clusterScope)
+}
+
+// reconcileDeleteVirtualGateway unlinks and deletes the virtual gateway of the cluster.
+func (r *OscClusterReconciler) reconcileDeleteVirtualGateway(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	svc := r.Cloud.VirtualGateway(clusterScope.Tenant)
+	vgw, err := r.Tracker.getVirtualGateway(ctx, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+		log.V(4).Info("The virtual gateway is already deleted")
+		return reconcile.Result{}, nil
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("get virtual gateway: %w", err)
+	}
+	var detaching bool
+	for _, link := range vgw.GetNetToVirtualGatewayLinks() {
+		switch link.GetState() {
+		case "attaching", "attached":
+			log.V(2).Info("Unlinking virtual gateway", "virtualGatewayId", vgw.GetVirtualGatewayId(), "netId", link.GetNetId())
+			err = svc.UnlinkVirtualGateway(ctx, vgw.GetVirtualGatewayId(), link.GetNetId())
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("cannot unlink virtual gateway: %w", err)
+			}
+			detaching = true
+		case "detaching":
+			detaching = true
+		}
+	}
+	if detaching {
+		log.V(3).Info("Waiting for virtual gateway to be unlinked", "virtualGatewayId", vgw.GetVirtualGatewayId())
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	log.V(2).Info("Deleting virtual gateway", "virtualGatewayId", vgw.GetVirtualGatewayId())
+	err = svc.DeleteVirtualGateway(ctx, vgw.GetVirtualGatewayId())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot delete virtual gateway: %w", err)
+	}
+	delete(clusterScope.GetResources().VirtualGateway, defaultResource)
+	return reconcile.Result{}, nil
+}
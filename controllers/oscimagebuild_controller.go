@@ -0,0 +1,243 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
+	"github.com/outscale/cluster-api-provider-outscale/util/reconciler"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// OscImageBuildReconciler builds images from VMs.
+type OscImageBuildReconciler struct {
+	client.Client
+	Cloud            services.Servicer
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscimagebuilds,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscimagebuilds/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscmachines,verbs=get;list;watch
+
+// Reconcile manages the lifecycle of an OscImageBuild object.
+func (r *OscImageBuildReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	imageBuild := &infrastructurev1beta1.OscImageBuild{}
+	if err := r.Get(ctx, req.NamespacedName, imageBuild); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	// Built images are kept when the OscImageBuild is deleted.
+	if !imageBuild.ObjectMeta.DeletionTimestamp.IsZero() || imageBuild.Status.Ready {
+		return reconcile.Result{}, nil
+	}
+
+	imageBuildScope, err := scope.NewImageBuildScope(scope.ImageBuildScopeParams{
+		Client:        r.Client,
+		OscImageBuild: imageBuild,
+	})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+	defer func() {
+		if err := imageBuildScope.Close(ctx); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	res, err := r.reconcile(ctx, imageBuildScope)
+	if err != nil {
+		conditions.MarkFalse(imageBuild, infrastructurev1beta1.ImageReadyCondition, infrastructurev1beta1.ImageNotReadyReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	}
+	return res, err
+}
+
+// reconcile creates the image and waits for it to be available.
+func (r *OscImageBuildReconciler) reconcile(ctx context.Context, imageBuildScope *scope.ImageBuildScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	imageBuild := imageBuildScope.OscImageBuild
+	errs := infrastructurev1beta1.ValidateOscImageBuildSpec(imageBuild.Spec)
+	if len(errs) > 0 {
+		return reconcile.Result{}, errs.ToAggregate()
+	}
+
+	t, vmId, err := r.getSource(ctx, imageBuildScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if vmId == "" {
+		log.V(3).Info("Source machine has no VM yet")
+		conditions.MarkFalse(imageBuild, infrastructurev1beta1.ImageReadyCondition, infrastructurev1beta1.WaitingForSourceVmReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	svc := r.Cloud.Image(t)
+	imageId := imageBuildScope.GetImageId()
+	if imageId == "" {
+		imageId, err = r.createImage(ctx, imageBuildScope, t, vmId)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		imageBuildScope.SetImageId(imageId)
+	}
+
+	image, err := svc.GetImage(ctx, imageId)
+	switch {
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("cannot get image: %w", err)
+	case image == nil:
+		return reconcile.Result{}, fmt.Errorf("image %s not found", imageId)
+	}
+	// A failed image would block the image name, it is deleted and built again.
+	if image.GetState() == "failed" {
+		log.V(2).Info("Image creation has failed, deleting image", "imageId", imageId)
+		err = svc.DeleteImage(ctx, imageId)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot delete failed image: %w", err)
+		}
+		r.Recorder.Eventf(imageBuild, corev1.EventTypeWarning, infrastructurev1beta1.ImageFailedReason, "Image %s has failed, building it again", imageId)
+		imageBuildScope.SetImageId("")
+		conditions.MarkFalse(imageBuild, infrastructurev1beta1.ImageReadyCondition, infrastructurev1beta1.ImageFailedReason, clusterv1.ConditionSeverityWarning, "image %s has failed, building it again", imageId)
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	// The image is tagged once recorded, so that it is not lost if tagging fails.
+	if !isImageBuildImage(image, string(imageBuild.UID)) {
+		err = svc.AddImageTags(ctx, imageId, map[string]string{compute.TagKeyImageBuild: string(imageBuild.UID)})
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot tag image: %w", err)
+		}
+	}
+	if image.GetState() != "available" {
+		log.V(3).Info("Image is not available yet", "imageId", imageId, "state", image.GetState())
+		conditions.MarkFalse(imageBuild, infrastructurev1beta1.ImageReadyCondition, infrastructurev1beta1.ImageNotReadyReason, clusterv1.ConditionSeverityInfo, "image is %s", image.GetState())
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if len(imageBuild.Spec.Tags) > 0 {
+		err := svc.AddImageTags(ctx, imageId, imageBuild.Spec.Tags)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot tag image: %w", err)
+		}
+	}
+	log.V(2).Info("Image is available", "imageId", imageId)
+	imageBuildScope.SetReady()
+	conditions.MarkTrue(imageBuild, infrastructurev1beta1.ImageReadyCondition)
+	return reconcile.Result{}, nil
+}
+
+// createImage creates the image, or reuses an image with the same name built by the OscImageBuild in a previous reconciliation.
+func (r *OscImageBuildReconciler) createImage(ctx context.Context, imageBuildScope *scope.ImageBuildScope, t tenant.Tenant, vmId string) (string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := imageBuildScope.OscImageBuild.Spec
+	vm, err := r.Cloud.VM(t).GetVm(ctx, vmId)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("cannot get source vm: %w", err)
+	case vm == nil:
+		return "", fmt.Errorf("get source vm %s: %w", vmId, ErrMissingResource)
+	}
+	var accountId string
+	if len(vm.GetNics()) > 0 {
+		accountId = vm.GetNics()[0].GetAccountId()
+	}
+	// Without an account, images of any account having the same name would be found.
+	if accountId == "" {
+		return "", fmt.Errorf("cannot find the account of source vm %s", vmId)
+	}
+	image, err := r.Cloud.Image(t).GetImageByName(ctx, spec.ImageName, accountId)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("cannot get image: %w", err)
+	case image != nil && isImageBuildImage(image, string(imageBuildScope.OscImageBuild.UID)):
+		log.V(3).Info("Reusing existing image", "imageId", image.GetImageId())
+		return image.GetImageId(), nil
+	case image != nil:
+		return "", fmt.Errorf("image %s already exists, and was not built by this OscImageBuild", image.GetImageId())
+	}
+	log.V(2).Info("Creating image", "vmId", vmId, "imageName", spec.ImageName)
+	image, err = r.Cloud.Image(t).CreateImageFromVm(ctx, vmId, spec.ImageName, spec.Description, !spec.Reboot)
+	if err != nil {
+		r.Recorder.Eventf(imageBuildScope.OscImageBuild, corev1.EventTypeWarning, infrastructurev1beta1.ImageFailedReason, "Image creation failed: %v", err)
+		return "", fmt.Errorf("cannot create image: %w", err)
+	}
+	log.V(2).Info("Image created", "imageId", image.GetImageId())
+	r.Recorder.Eventf(imageBuildScope.OscImageBuild, corev1.EventTypeNormal, infrastructurev1beta1.ImageCreatedReason, "Image created: %s", image.GetImageId())
+	return image.GetImageId(), nil
+}
+
+// isImageBuildImage checks if an image has been built by the OscImageBuild having uid.
+func isImageBuildImage(image *osc.Image, uid string) bool {
+	return slices.ContainsFunc(image.GetTags(), func(tag osc.ResourceTag) bool {
+		return tag.GetKey() == compute.TagKeyImageBuild && tag.GetValue() == uid
+	})
+}
+
+// getSource returns the tenant and the ID of the source VM.
+func (r *OscImageBuildReconciler) getSource(ctx context.Context, imageBuildScope *scope.ImageBuildScope) (tenant.Tenant, string, error) {
+	imageBuild := imageBuildScope.OscImageBuild
+	if imageBuild.Spec.MachineRef == nil {
+		t, err := getTenantFromCredentials(ctx, r.Client, r.Cloud, imageBuild.Spec.Credentials, imageBuild.Namespace)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to fetch tenant: %w", err)
+		}
+		return t, imageBuild.Spec.VmId, nil
+	}
+
+	oscMachine := &infrastructurev1beta1.OscMachine{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: imageBuild.Namespace, Name: imageBuild.Spec.MachineRef.Name}, oscMachine)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot get source machine: %w", err)
+	}
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, oscMachine.ObjectMeta)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot get cluster of source machine: %w", err)
+	}
+	oscCluster := &infrastructurev1beta1.OscCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: oscMachine.Namespace, Name: cluster.Spec.InfrastructureRef.Name}, oscCluster)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot get OscCluster of source machine: %w", err)
+	}
+	t, err := getTenant(ctx, r.Client, r.Cloud, oscCluster)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to fetch tenant: %w", err)
+	}
+	return t, oscMachine.Status.Resources.Vm[defaultResource], nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OscImageBuildReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrastructurev1beta1.OscImageBuild{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+}
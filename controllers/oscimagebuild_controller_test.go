@@ -0,0 +1,331 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/cluster-api-provider-outscale/controllers"
+	"github.com/outscale/osc-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const buildUID = "5e4e3f9a-1c2b-4d8e-9f0a-7b6c5d4e3f2a"
+
+var buildTags = map[string]string{compute.TagKeyImageBuild: buildUID}
+
+type assertOSCImageBuildFunc func(t *testing.T, b *infrastructurev1beta1.OscImageBuild)
+
+type imageBuildTestcase struct {
+	name             string
+	spec             infrastructurev1beta1.OscImageBuildSpec
+	machinePatches   []patchOSCMachineFunc
+	mockFuncs        []mockFunc
+	hasError         bool
+	requeue          bool
+	imageBuildAssert []assertOSCImageBuildFunc
+	next             *imageBuildTestcase
+}
+
+func runImageBuildTest(t *testing.T, tc imageBuildTestcase) {
+	c, oc := loadClusterSpecs(t, "ready-1.0", "")
+	m, om := loadMachineSpecs(t, "ready-worker-1.0", "ready-worker")
+	for _, fn := range tc.machinePatches {
+		fn(om)
+	}
+	ib := &infrastructurev1beta1.OscImageBuild{
+		ObjectMeta: metav1.ObjectMeta{Namespace: om.Namespace, Name: "test-image", UID: buildUID},
+		Spec:       tc.spec,
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: om.Namespace, Name: "source-creds"},
+		Data: map[string][]byte{
+			"access_key": []byte("foo"),
+			"secret_key": []byte("bar"),
+			"region":     []byte("eu-west-2"),
+		},
+	}
+	fakeScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(fakeScheme)
+	_ = clusterv1.AddToScheme(fakeScheme)
+	_ = infrastructurev1beta1.AddToScheme(fakeScheme)
+	client := fake.NewClientBuilder().WithScheme(fakeScheme).
+		WithStatusSubresource(ib).WithObjects(c, oc, m, om, ib, secret).Build()
+	mockCtrl := gomock.NewController(t)
+	cs := newMockCloudServices(mockCtrl, "eu-west-2")
+	rec := controllers.OscImageBuildReconciler{
+		Client:   client,
+		Recorder: record.NewFakeRecorder(100),
+		Cloud:    cs,
+	}
+	nsn := types.NamespacedName{
+		Namespace: ib.Namespace,
+		Name:      ib.Name,
+	}
+	step := &tc
+	for step != nil {
+		for _, fn := range step.mockFuncs {
+			fn(cs)
+		}
+		res, err := rec.Reconcile(context.TODO(), controllerruntime.Request{NamespacedName: nsn})
+		if step.hasError {
+			require.Error(t, err)
+			assert.Zero(t, res)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, step.requeue, res.RequeueAfter > 0 || res.Requeue)
+		}
+		var out infrastructurev1beta1.OscImageBuild
+		err = client.Get(context.TODO(), nsn, &out)
+		require.NoError(t, err, "resource was not found")
+		for _, fn := range step.imageBuildAssert {
+			fn(t, &out)
+		}
+		step = step.next
+	}
+}
+
+func mockGetVmForImage(vmId, accountId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().GetVm(gomock.Any(), gomock.Eq(vmId)).
+			Return(&osc.Vm{
+				VmId: &vmId,
+				Nics: &[]osc.NicLight{{AccountId: &accountId}},
+			}, nil)
+	}
+}
+
+func mockGetImageByName(name, accountId string, image *osc.Image) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().GetImageByName(gomock.Any(), gomock.Eq(name), gomock.Eq(accountId)).
+			Return(image, nil)
+	}
+}
+
+func mockCreateImageFromVm(vmId, name, description string, noReboot bool, imageId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().CreateImageFromVm(gomock.Any(), gomock.Eq(vmId), gomock.Eq(name), gomock.Eq(description), gomock.Eq(noReboot)).
+			Return(&osc.Image{ImageId: &imageId, State: ptr.To("pending")}, nil)
+	}
+}
+
+func mockGetImageState(imageId, state string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().GetImage(gomock.Any(), gomock.Eq(imageId)).
+			Return(&osc.Image{ImageId: &imageId, State: &state}, nil)
+	}
+}
+
+func mockGetBuiltImage(imageId, state string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().GetImage(gomock.Any(), gomock.Eq(imageId)).
+			Return(&osc.Image{ImageId: &imageId, State: &state, Tags: &[]osc.ResourceTag{{Key: compute.TagKeyImageBuild, Value: buildUID}}}, nil)
+	}
+}
+
+func mockAddImageTags(imageId string, tags map[string]string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().AddImageTags(gomock.Any(), gomock.Eq(imageId), gomock.Eq(tags)).
+			Return(nil)
+	}
+}
+
+func assertImageBuildStatus(imageId string, ready bool) assertOSCImageBuildFunc {
+	return func(t *testing.T, b *infrastructurev1beta1.OscImageBuild) {
+		assert.Equal(t, imageId, b.Status.ImageId)
+		assert.Equal(t, ready, b.Status.Ready)
+	}
+}
+
+func assertImageBuildReason(reason string) assertOSCImageBuildFunc {
+	return func(t *testing.T, b *infrastructurev1beta1.OscImageBuild) {
+		assert.Equal(t, reason, conditions.GetReason(b, infrastructurev1beta1.ImageReadyCondition))
+	}
+}
+
+func TestReconcileOscImageBuild(t *testing.T) {
+	tcs := []imageBuildTestcase{
+		{
+			name: "building an image from a machine",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				MachineRef:  &corev1.LocalObjectReference{Name: "test-cluster-api-md-0-6p8qk-qgvhr"},
+				ImageName:   "golden-image",
+				Description: "built from a worker",
+				Tags:        map[string]string{"team": "platform"},
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmForImage("i-046f4bd0", "123456789012"),
+				mockGetImageByName("golden-image", "123456789012", nil),
+				mockCreateImageFromVm("i-046f4bd0", "golden-image", "built from a worker", true, "ami-foo"),
+				mockGetImageState("ami-foo", "pending"),
+				mockAddImageTags("ami-foo", buildTags),
+			},
+			requeue: true,
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("ami-foo", false),
+				assertImageBuildReason(infrastructurev1beta1.ImageNotReadyReason),
+			},
+			next: &imageBuildTestcase{
+				mockFuncs: []mockFunc{
+					mockGetBuiltImage("ami-foo", "available"),
+					mockAddImageTags("ami-foo", map[string]string{"team": "platform"}),
+				},
+				imageBuildAssert: []assertOSCImageBuildFunc{
+					assertImageBuildStatus("ami-foo", true),
+					func(t *testing.T, b *infrastructurev1beta1.OscImageBuild) {
+						assert.True(t, conditions.IsTrue(b, infrastructurev1beta1.ImageReadyCondition))
+					},
+				},
+				next: &imageBuildTestcase{
+					imageBuildAssert: []assertOSCImageBuildFunc{
+						assertImageBuildStatus("ami-foo", true),
+					},
+				},
+			},
+		},
+		{
+			name: "building an image from a vm, with a reboot",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				VmId:        "i-foo",
+				Credentials: infrastructurev1beta1.OscCredentials{FromSecret: "source-creds"},
+				ImageName:   "golden-image",
+				Reboot:      true,
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmForImage("i-foo", "123456789012"),
+				mockGetImageByName("golden-image", "123456789012", nil),
+				mockCreateImageFromVm("i-foo", "golden-image", "", false, "ami-foo"),
+				mockGetImageState("ami-foo", "available"),
+				mockAddImageTags("ami-foo", buildTags),
+			},
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("ami-foo", true),
+			},
+		},
+		{
+			name: "an image created by a previous reconciliation is reused",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				VmId:        "i-foo",
+				Credentials: infrastructurev1beta1.OscCredentials{FromSecret: "source-creds"},
+				ImageName:   "golden-image",
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmForImage("i-foo", "123456789012"),
+				mockGetImageByName("golden-image", "123456789012", &osc.Image{
+					ImageId: ptr.To("ami-foo"),
+					Tags:    &[]osc.ResourceTag{{Key: compute.TagKeyImageBuild, Value: buildUID}},
+				}),
+				mockGetBuiltImage("ami-foo", "available"),
+			},
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("ami-foo", true),
+			},
+		},
+		{
+			name: "an image with the same name, not built by the OscImageBuild, is not reused",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				VmId:        "i-foo",
+				Credentials: infrastructurev1beta1.OscCredentials{FromSecret: "source-creds"},
+				ImageName:   "golden-image",
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmForImage("i-foo", "123456789012"),
+				mockGetImageByName("golden-image", "123456789012", &osc.Image{ImageId: ptr.To("ami-other")}),
+			},
+			hasError: true,
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("", false),
+			},
+		},
+		{
+			name: "a failed image is deleted and built again",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				VmId:        "i-foo",
+				Credentials: infrastructurev1beta1.OscCredentials{FromSecret: "source-creds"},
+				ImageName:   "golden-image",
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmForImage("i-foo", "123456789012"),
+				mockGetImageByName("golden-image", "123456789012", nil),
+				mockCreateImageFromVm("i-foo", "golden-image", "", true, "ami-foo"),
+				mockGetImageState("ami-foo", "failed"),
+				mockDeleteImage("ami-foo"),
+			},
+			requeue: true,
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("", false),
+				assertImageBuildReason(infrastructurev1beta1.ImageFailedReason),
+			},
+			next: &imageBuildTestcase{
+				mockFuncs: []mockFunc{
+					mockGetVmForImage("i-foo", "123456789012"),
+					mockGetImageByName("golden-image", "123456789012", nil),
+					mockCreateImageFromVm("i-foo", "golden-image", "", true, "ami-bar"),
+					mockGetImageState("ami-bar", "pending"),
+					mockAddImageTags("ami-bar", buildTags),
+				},
+				requeue: true,
+				imageBuildAssert: []assertOSCImageBuildFunc{
+					assertImageBuildStatus("ami-bar", false),
+					assertImageBuildReason(infrastructurev1beta1.ImageNotReadyReason),
+				},
+			},
+		},
+		{
+			name: "waiting for the machine to have a vm",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				MachineRef: &corev1.LocalObjectReference{Name: "test-cluster-api-md-0-6p8qk-qgvhr"},
+				ImageName:  "golden-image",
+			},
+			machinePatches: []patchOSCMachineFunc{
+				func(m *infrastructurev1beta1.OscMachine) {
+					m.Status.Resources.Vm = nil
+				},
+			},
+			requeue: true,
+			imageBuildAssert: []assertOSCImageBuildFunc{
+				assertImageBuildStatus("", false),
+				assertImageBuildReason(infrastructurev1beta1.WaitingForSourceVmReason),
+			},
+		},
+		{
+			name: "a vm without credentials is rejected",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				VmId:      "i-foo",
+				ImageName: "golden-image",
+			},
+			hasError: true,
+		},
+		{
+			name: "an invalid spec is rejected",
+			spec: infrastructurev1beta1.OscImageBuildSpec{
+				ImageName: "golden-image",
+			},
+			hasError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			runImageBuildTest(t, tc)
+		})
+	}
+}
@@ -0,0 +1,478 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
+	"github.com/outscale/cluster-api-provider-outscale/util/reconciler"
+	osc "github.com/outscale/osc-sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// OscImageDistributionReconciler shares and copies images to the tenants using them.
+type OscImageDistributionReconciler struct {
+	client.Client
+	Cloud            services.Servicer
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// imageConsumer is a region/account where an image is used by OscMachineTemplates.
+type imageConsumer struct {
+	tenant      tenant.Tenant
+	region      string
+	accountId   string
+	clusterName string
+	credentials infrastructurev1beta1.OscCredentials
+	// byId is true if the consumer only references the source image by ID, a copy being of no use.
+	byId bool
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscimagedistributions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscimagedistributions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscmachinetemplates,verbs=get;list;watch
+
+// Reconcile manages the lifecycle of an OscImageDistribution object.
+func (r *OscImageDistributionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	distribution := &infrastructurev1beta1.OscImageDistribution{}
+	if err := r.Get(ctx, req.NamespacedName, distribution); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	// Shares and copies are kept when the OscImageDistribution is deleted.
+	if !distribution.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	distributionScope, err := scope.NewImageDistributionScope(scope.ImageDistributionScopeParams{
+		Client:               r.Client,
+		OscImageDistribution: distribution,
+	})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+	defer func() {
+		if err := distributionScope.Close(ctx); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	res, err := r.reconcile(ctx, distributionScope)
+	if err != nil {
+		distributionScope.SetReady(false)
+		conditions.MarkFalse(distribution, infrastructurev1beta1.ImageDistributedCondition, infrastructurev1beta1.ImageDistributionFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	}
+	return res, err
+}
+
+// reconcile shares the source image, copies it to other regions, and deletes the copies no longer used.
+func (r *OscImageDistributionReconciler) reconcile(ctx context.Context, distributionScope *scope.ImageDistributionScope) (reconcile.Result, error) {
+	distribution := distributionScope.OscImageDistribution
+	errs := infrastructurev1beta1.ValidateOscImageDistributionSpec(distribution.Spec)
+	if len(errs) > 0 {
+		return reconcile.Result{}, errs.ToAggregate()
+	}
+
+	t, err := getTenantFromCredentials(ctx, r.Client, r.Cloud, distribution.Spec.Credentials, distribution.Namespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to fetch tenant: %w", err)
+	}
+	image, err := r.Cloud.Image(t).GetImage(ctx, distribution.Spec.ImageId)
+	switch {
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("cannot get image: %w", err)
+	case image == nil:
+		return reconcile.Result{}, fmt.Errorf("get image %s: %w", distribution.Spec.ImageId, ErrMissingResource)
+	}
+
+	consumers, err := r.getImageConsumers(ctx, distributionScope, image)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	consumers = slices.DeleteFunc(consumers, func(c imageConsumer) bool {
+		return c.region == t.Region() && c.accountId == image.GetAccountId()
+	})
+
+	err = r.reconcileLaunchPermissions(ctx, distributionScope, t, image, consumers)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	err = r.reconcileDeleteCopies(ctx, distributionScope, consumers)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	ready, err := r.reconcileCopies(ctx, distributionScope, t, image, consumers)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	distributionScope.SetReady(ready)
+	if !ready {
+		conditions.MarkFalse(distribution, infrastructurev1beta1.ImageDistributedCondition, infrastructurev1beta1.ImageCopyNotReadyReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	conditions.MarkTrue(distribution, infrastructurev1beta1.ImageDistributedCondition)
+	return reconcile.Result{}, nil
+}
+
+// getImageConsumers returns the regions/accounts of the OscClusters whose OscMachineTemplates use image.
+func (r *OscImageDistributionReconciler) getImageConsumers(ctx context.Context, distributionScope *scope.ImageDistributionScope, image *osc.Image) ([]imageConsumer, error) {
+	log := ctrl.LoggerFrom(ctx)
+	var templates infrastructurev1beta1.OscMachineTemplateList
+	if err := r.List(ctx, &templates, client.InNamespace(distributionScope.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("cannot list OscMachineTemplates: %w", err)
+	}
+	slices.SortFunc(templates.Items, func(a, b infrastructurev1beta1.OscMachineTemplate) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	var consumers []imageConsumer
+	seen := map[string]int{}
+	for _, template := range templates.Items {
+		uses, byId := templateUsesImage(ctx, &template, image)
+		if !uses {
+			continue
+		}
+		oscCluster, err := r.getTemplateOscCluster(ctx, &template)
+		if err != nil {
+			return nil, err
+		}
+		if oscCluster == nil {
+			log.V(3).Info("OscMachineTemplate has no cluster, skipping", "template", template.Name)
+			continue
+		}
+		// A cluster using the image by name needs a copy, even if some of its templates use the image by ID.
+		if i, found := seen[oscCluster.Name]; found {
+			if !byId {
+				consumers[i].byId = false
+			}
+			continue
+		}
+		t, err := getTenant(ctx, r.Client, r.Cloud, oscCluster)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch tenant of %s: %w", oscCluster.Name, err)
+		}
+		accountId, err := r.Cloud.Account(t).GetAccountId(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get account of %s: %w", oscCluster.Name, err)
+		}
+		if i := slices.IndexFunc(consumers, func(c imageConsumer) bool {
+			return c.region == t.Region() && c.accountId == accountId
+		}); i >= 0 {
+			seen[oscCluster.Name] = i
+			if !byId {
+				consumers[i].byId = false
+			}
+			continue
+		}
+		seen[oscCluster.Name] = len(consumers)
+		consumers = append(consumers, imageConsumer{
+			tenant:      t,
+			region:      t.Region(),
+			accountId:   accountId,
+			clusterName: oscCluster.Name,
+			credentials: oscCluster.Spec.Credentials,
+			byId:        byId,
+		})
+	}
+	return consumers, nil
+}
+
+// templateUsesImage checks if an OscMachineTemplate uses image, by name, name pattern or ID.
+// byId is true if the template only references the ID of image.
+// A name pattern is matched against the name of image, whatever the Kubernetes version.
+func templateUsesImage(ctx context.Context, template *infrastructurev1beta1.OscMachineTemplate, image *osc.Image) (uses, byId bool) {
+	node := template.Spec.Template.Spec.Node
+	switch {
+	case node.Image.Name != "":
+		return node.Image.Name == image.GetImageName(), false
+	case node.Image.NamePattern != "":
+		pattern, err := node.Image.ResolveNamePattern("*")
+		if err != nil {
+			ctrl.LoggerFrom(ctx).V(3).Info("Invalid image name pattern, skipping", "template", template.Name, "error", err.Error())
+			return false, false
+		}
+		ok, _ := path.Match(pattern, image.GetImageName())
+		return ok, false
+	default:
+		return node.Vm.ImageId != "" && node.Vm.ImageId == image.GetImageId(), true
+	}
+}
+
+// getTemplateOscCluster returns the OscCluster of an OscMachineTemplate, from its cluster label or its owner cluster.
+func (r *OscImageDistributionReconciler) getTemplateOscCluster(ctx context.Context, template *infrastructurev1beta1.OscMachineTemplate) (*infrastructurev1beta1.OscCluster, error) {
+	var cluster *clusterv1.Cluster
+	var err error
+	if _, ok := template.Labels[clusterv1.ClusterNameLabel]; ok {
+		cluster, err = util.GetClusterFromMetadata(ctx, r.Client, template.ObjectMeta)
+	} else {
+		cluster, err = util.GetOwnerCluster(ctx, r.Client, template.ObjectMeta)
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot get cluster of %s: %w", template.Name, err)
+	case cluster == nil || cluster.Spec.InfrastructureRef == nil:
+		return nil, nil
+	}
+	oscCluster := &infrastructurev1beta1.OscCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.InfrastructureRef.Name}, oscCluster)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot get OscCluster of %s: %w", template.Name, err)
+	}
+	return oscCluster, nil
+}
+
+// reconcileLaunchPermissions shares the source image with the consumer accounts, and unshares it from former consumers.
+func (r *OscImageDistributionReconciler) reconcileLaunchPermissions(ctx context.Context, distributionScope *scope.ImageDistributionScope,
+	t tenant.Tenant, image *osc.Image, consumers []imageConsumer) error {
+	log := ctrl.LoggerFrom(ctx)
+	var desired []string
+	for _, c := range consumers {
+		if c.accountId != image.GetAccountId() && !slices.Contains(desired, c.accountId) {
+			desired = append(desired, c.accountId)
+		}
+	}
+	slices.Sort(desired)
+	permissions := image.GetPermissionsToLaunch()
+	current := permissions.GetAccountIds()
+	var additions, removals []string
+	for _, accountId := range desired {
+		if !slices.Contains(current, accountId) {
+			additions = append(additions, accountId)
+		}
+	}
+	for _, accountId := range distributionScope.GetSharedAccountIds() {
+		if !slices.Contains(desired, accountId) && slices.Contains(current, accountId) {
+			removals = append(removals, accountId)
+		}
+	}
+	if len(additions) > 0 || len(removals) > 0 {
+		log.V(2).Info("Updating launch permissions", "imageId", image.GetImageId(), "additions", additions, "removals", removals)
+		err := r.Cloud.Image(t).UpdateLaunchPermissions(ctx, image.GetImageId(), additions, removals)
+		if err != nil {
+			return fmt.Errorf("cannot update launch permissions: %w", err)
+		}
+	}
+	distributionScope.SetSharedAccountIds(desired)
+	return nil
+}
+
+// reconcileCopies copies the source image to the consumers in other regions, and returns true once all copies are available.
+// Copies are tagged with the UID of the distribution; an image having the same name but not the tag is used as is, and is never deleted.
+func (r *OscImageDistributionReconciler) reconcileCopies(ctx context.Context, distributionScope *scope.ImageDistributionScope,
+	t tenant.Tenant, image *osc.Image, consumers []imageConsumer) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	uid := string(distributionScope.OscImageDistribution.UID)
+	ready := true
+	for _, c := range consumers {
+		if c.region == t.Region() || c.byId {
+			continue
+		}
+		svc := r.Cloud.Image(c.tenant)
+		cpy := distributionScope.GetCopy(c.region, c.accountId)
+		if cpy == nil {
+			copied, err := svc.GetImageByName(ctx, image.GetImageName(), c.accountId)
+			if err != nil {
+				return false, fmt.Errorf("cannot get image copy in %s: %w", c.region, err)
+			}
+			if copied != nil && !isImageDistributionCopy(copied, uid) {
+				log.V(3).Info("Image with the same name already exists and is not a copy, skipping", "imageId", copied.GetImageId(), "region", c.region, "accountId", c.accountId)
+				continue
+			}
+			if copied == nil {
+				log.V(2).Info("Copying image", "imageId", image.GetImageId(), "region", c.region, "accountId", c.accountId)
+				copied, err = svc.CopyImage(ctx, image.GetImageId(), t.Region(), image.GetImageName(), image.GetDescription())
+				if err != nil {
+					return false, fmt.Errorf("cannot copy image to %s: %w", c.region, err)
+				}
+				r.Recorder.Eventf(distributionScope.OscImageDistribution, corev1.EventTypeNormal, infrastructurev1beta1.ImageCopiedReason,
+					"Image copied to %s/%s: %s", c.region, c.accountId, copied.GetImageId())
+			}
+			distributionScope.AddCopy(infrastructurev1beta1.OscImageCopy{
+				Region:      c.region,
+				AccountId:   c.accountId,
+				ImageId:     copied.GetImageId(),
+				ClusterName: c.clusterName,
+				Credentials: &c.credentials,
+			})
+			cpy = distributionScope.GetCopy(c.region, c.accountId)
+		}
+		if cpy.Ready {
+			continue
+		}
+		copied, err := svc.GetImage(ctx, cpy.ImageId)
+		switch {
+		case err != nil:
+			return false, fmt.Errorf("cannot get image copy in %s: %w", c.region, err)
+		case copied == nil:
+			distributionScope.RemoveCopy(cpy.ImageId)
+			return false, fmt.Errorf("get image copy %s in %s: %w", cpy.ImageId, c.region, ErrMissingResource)
+		}
+		// The copy is tagged once recorded, so that it is not lost if tagging fails.
+		if !isImageDistributionCopy(copied, uid) {
+			err = svc.AddImageTags(ctx, cpy.ImageId, map[string]string{compute.TagKeyImageDistribution: uid})
+			if err != nil {
+				return false, fmt.Errorf("cannot tag image copy %s in %s: %w", cpy.ImageId, c.region, err)
+			}
+		}
+		switch copied.GetState() {
+		case "available":
+			cpy.Ready = true
+		case "failed":
+			imageId := cpy.ImageId
+			log.V(2).Info("Deleting failed image copy", "imageId", imageId, "region", c.region)
+			err = svc.DeleteImage(ctx, imageId)
+			if err != nil {
+				return false, fmt.Errorf("cannot delete failed image copy %s in %s: %w", imageId, c.region, err)
+			}
+			distributionScope.RemoveCopy(imageId)
+			return false, fmt.Errorf("image copy %s in %s has failed and has been deleted, it will be copied again", imageId, c.region)
+		default:
+			log.V(3).Info("Image copy is not available yet", "imageId", cpy.ImageId, "region", c.region, "state", copied.GetState())
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+// isImageDistributionCopy checks if an image has been copied by the distribution having uid.
+func isImageDistributionCopy(image *osc.Image, uid string) bool {
+	return slices.ContainsFunc(image.GetTags(), func(tag osc.ResourceTag) bool {
+		return tag.GetKey() == compute.TagKeyImageDistribution && tag.GetValue() == uid
+	})
+}
+
+// reconcileDeleteCopies deletes the copies no longer used by any consumer.
+// Only images tagged as copies of the distribution are deleted.
+func (r *OscImageDistributionReconciler) reconcileDeleteCopies(ctx context.Context, distributionScope *scope.ImageDistributionScope, consumers []imageConsumer) error {
+	log := ctrl.LoggerFrom(ctx)
+	for _, cpy := range slices.Clone(distributionScope.GetCopies()) {
+		if slices.ContainsFunc(consumers, func(c imageConsumer) bool {
+			return c.region == cpy.Region && c.accountId == cpy.AccountId && !c.byId
+		}) {
+			continue
+		}
+		t, err := r.getCopyTenant(ctx, distributionScope, cpy)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			log.V(2).Info("Credentials of image copy are gone, the copy is not deleted", "imageId", cpy.ImageId, "region", cpy.Region, "cluster", cpy.ClusterName)
+			r.Recorder.Eventf(distributionScope.OscImageDistribution, corev1.EventTypeWarning, infrastructurev1beta1.ImageCopyNotDeletedReason,
+				"Credentials of cluster %s are gone, image copy %s/%s: %s needs to be deleted manually", cpy.ClusterName, cpy.Region, cpy.AccountId, cpy.ImageId)
+			distributionScope.RemoveCopy(cpy.ImageId)
+			continue
+		}
+		svc := r.Cloud.Image(t)
+		copied, err := svc.GetImage(ctx, cpy.ImageId)
+		switch {
+		case err != nil:
+			return fmt.Errorf("cannot get image copy in %s: %w", cpy.Region, err)
+		case copied == nil:
+			distributionScope.RemoveCopy(cpy.ImageId)
+			continue
+		case !isImageDistributionCopy(copied, string(distributionScope.OscImageDistribution.UID)):
+			log.V(2).Info("Image is not a copy made by the distribution, it is not deleted", "imageId", cpy.ImageId, "region", cpy.Region)
+			distributionScope.RemoveCopy(cpy.ImageId)
+			continue
+		}
+		log.V(2).Info("Deleting unused image copy", "imageId", cpy.ImageId, "region", cpy.Region)
+		err = svc.DeleteImage(ctx, cpy.ImageId)
+		if err != nil {
+			return fmt.Errorf("cannot delete image copy %s in %s: %w", cpy.ImageId, cpy.Region, err)
+		}
+		r.Recorder.Eventf(distributionScope.OscImageDistribution, corev1.EventTypeNormal, infrastructurev1beta1.ImageCopyDeletedReason,
+			"Unused image copy deleted from %s/%s: %s", cpy.Region, cpy.AccountId, cpy.ImageId)
+		distributionScope.RemoveCopy(cpy.ImageId)
+	}
+	return nil
+}
+
+// getCopyTenant returns the tenant managing an image copy, from its OscCluster or, once the OscCluster is deleted, from the credentials recorded with the copy.
+// A nil tenant is returned if the credentials are gone.
+func (r *OscImageDistributionReconciler) getCopyTenant(ctx context.Context, distributionScope *scope.ImageDistributionScope, cpy infrastructurev1beta1.OscImageCopy) (tenant.Tenant, error) {
+	oscCluster := &infrastructurev1beta1.OscCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: distributionScope.GetNamespace(), Name: cpy.ClusterName}, oscCluster)
+	switch {
+	case err == nil:
+		t, err := getTenant(ctx, r.Client, r.Cloud, oscCluster)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch tenant of %s: %w", oscCluster.Name, err)
+		}
+		return t, nil
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("cannot get OscCluster of image copy: %w", err)
+	case cpy.Credentials == nil:
+		return nil, nil
+	}
+	t, err := getTenantFromCredentials(ctx, r.Client, r.Cloud, *cpy.Credentials, distributionScope.GetNamespace())
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to fetch tenant of image copy: %w", err)
+	}
+	return t, nil
+}
+
+// OscMachineTemplateToOscImageDistributions maps an OscMachineTemplate to the OscImageDistributions of its namespace.
+func (r *OscImageDistributionReconciler) OscMachineTemplateToOscImageDistributions(ctx context.Context, o client.Object) []ctrl.Request {
+	log := ctrl.LoggerFrom(ctx)
+	var distributions infrastructurev1beta1.OscImageDistributionList
+	if err := r.List(ctx, &distributions, client.InNamespace(o.GetNamespace())); err != nil {
+		log.V(1).Error(err, "failed to list OscImageDistributions, skipping mapping.")
+		return nil
+	}
+	result := make([]ctrl.Request, 0, len(distributions.Items))
+	for _, d := range distributions.Items {
+		result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: d.Namespace, Name: d.Name}})
+	}
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OscImageDistributionReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrastructurev1beta1.OscImageDistribution{}).
+		Watches(
+			&infrastructurev1beta1.OscMachineTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.OscMachineTemplateToOscImageDistributions),
+		).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+}
@@ -0,0 +1,449 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/cluster-api-provider-outscale/controllers"
+	"github.com/outscale/osc-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type assertOSCImageDistributionFunc func(t *testing.T, d *infrastructurev1beta1.OscImageDistribution)
+
+type imageDistributionTestcase struct {
+	name               string
+	consumerRegion     string
+	templates          []string
+	templateImage      *infrastructurev1beta1.OscImage
+	templateImageId    string
+	status             infrastructurev1beta1.OscImageDistributionStatus
+	mockFuncs          []mockFunc
+	hasError           bool
+	requeue            bool
+	distributionAssert []assertOSCImageDistributionFunc
+	next               *imageDistributionTestcase
+}
+
+func runImageDistributionTest(t *testing.T, tc imageDistributionTestcase) {
+	c, oc := loadClusterSpecs(t, "ready-1.0", "")
+	oc.Spec.Credentials.FromSecret = "consumer-creds"
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: oc.Namespace, Name: "consumer-creds"},
+		Data: map[string][]byte{
+			"access_key": []byte("foo"),
+			"secret_key": []byte("bar"),
+			"region":     []byte(tc.consumerRegion),
+		},
+	}
+	d := &infrastructurev1beta1.OscImageDistribution{
+		ObjectMeta: metav1.ObjectMeta{Namespace: oc.Namespace, Name: "golden-image", UID: distributionUID},
+		Spec:       infrastructurev1beta1.OscImageDistributionSpec{ImageId: "ami-source"},
+		Status:     tc.status,
+	}
+	objs := []client.Object{c, oc, secret, d}
+	for _, name := range tc.templates {
+		tpl := &infrastructurev1beta1.OscMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: oc.Namespace, Name: name},
+		}
+		switch {
+		case tc.templateImage != nil:
+			tpl.Spec.Template.Spec.Node.Image = *tc.templateImage
+		case tc.templateImageId != "":
+			tpl.Spec.Template.Spec.Node.Vm.ImageId = tc.templateImageId
+		default:
+			tpl.Spec.Template.Spec.Node.Image.Name = "golden-image"
+		}
+		if name != "orphan" {
+			tpl.Labels = map[string]string{clusterv1.ClusterNameLabel: c.Name}
+		}
+		objs = append(objs, tpl)
+	}
+	fakeScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(fakeScheme)
+	_ = clusterv1.AddToScheme(fakeScheme)
+	_ = infrastructurev1beta1.AddToScheme(fakeScheme)
+	client := fake.NewClientBuilder().WithScheme(fakeScheme).
+		WithStatusSubresource(d).WithObjects(objs...).Build()
+	mockCtrl := gomock.NewController(t)
+	cs := newMockCloudServices(mockCtrl, "eu-west-2")
+	rec := controllers.OscImageDistributionReconciler{
+		Client:   client,
+		Recorder: record.NewFakeRecorder(100),
+		Cloud:    cs,
+	}
+	nsn := types.NamespacedName{
+		Namespace: d.Namespace,
+		Name:      d.Name,
+	}
+	step := &tc
+	for step != nil {
+		for _, fn := range step.mockFuncs {
+			fn(cs)
+		}
+		res, err := rec.Reconcile(context.TODO(), controllerruntime.Request{NamespacedName: nsn})
+		if step.hasError {
+			require.Error(t, err)
+			assert.Zero(t, res)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, step.requeue, res.RequeueAfter > 0 || res.Requeue)
+		}
+		var out infrastructurev1beta1.OscImageDistribution
+		err = client.Get(context.TODO(), nsn, &out)
+		require.NoError(t, err, "resource was not found")
+		for _, fn := range step.distributionAssert {
+			fn(t, &out)
+		}
+		step = step.next
+	}
+}
+
+const distributionUID = "5c4b9a4e-1d2f-4e8a-9d3b-0a1b2c3d4e5f"
+
+func imageCopy(imageId, state string, tagged bool) *osc.Image {
+	image := &osc.Image{ImageId: &imageId, ImageName: ptr.To("golden-image"), State: &state}
+	if tagged {
+		image.Tags = &[]osc.ResourceTag{{Key: compute.TagKeyImageDistribution, Value: distributionUID}}
+	}
+	return image
+}
+
+func mockGetImageCopy(imageId, state string, tagged bool) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().GetImage(gomock.Any(), gomock.Eq(imageId)).
+			Return(imageCopy(imageId, state, tagged), nil)
+	}
+}
+
+func mockGetSourceImage(sharedWith ...string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().GetImage(gomock.Any(), gomock.Eq("ami-source")).
+			Return(&osc.Image{
+				ImageId:             ptr.To("ami-source"),
+				ImageName:           ptr.To("golden-image"),
+				AccountId:           ptr.To("111111111111"),
+				State:               ptr.To("available"),
+				PermissionsToLaunch: &osc.PermissionsOnResource{AccountIds: &sharedWith},
+			}, nil)
+	}
+}
+
+func mockGetAccountId(accountId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.AccountMock.EXPECT().GetAccountId(gomock.Any()).
+			Return(accountId, nil)
+	}
+}
+
+func mockUpdateLaunchPermissions(additions, removals []string) mockFunc {
+	return func(s *MockCloudServices) {
+		addMatcher, removeMatcher := gomock.Nil(), gomock.Nil()
+		if additions != nil {
+			addMatcher = gomock.Eq(additions)
+		}
+		if removals != nil {
+			removeMatcher = gomock.Eq(removals)
+		}
+		s.ImageMock.EXPECT().UpdateLaunchPermissions(gomock.Any(), gomock.Eq("ami-source"), addMatcher, removeMatcher).
+			Return(nil)
+	}
+}
+
+func mockCopyImage(imageId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().CopyImage(gomock.Any(), gomock.Eq("ami-source"), gomock.Eq("eu-west-2"), gomock.Eq("golden-image"), gomock.Eq("")).
+			Return(&osc.Image{ImageId: &imageId, State: ptr.To("pending")}, nil)
+	}
+}
+
+func mockDeleteImage(imageId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.EXPECT().DeleteImage(gomock.Any(), gomock.Eq(imageId)).
+			Return(nil)
+	}
+}
+
+func assertImageDistributionStatus(ready bool, sharedWith []string, copies ...infrastructurev1beta1.OscImageCopy) assertOSCImageDistributionFunc {
+	return func(t *testing.T, d *infrastructurev1beta1.OscImageDistribution) {
+		assert.Equal(t, ready, d.Status.Ready)
+		assert.Equal(t, sharedWith, d.Status.SharedAccountIds)
+		assert.Equal(t, copies, d.Status.Copies)
+		assert.Equal(t, ready, conditions.IsTrue(d, infrastructurev1beta1.ImageDistributedCondition))
+	}
+}
+
+func TestReconcileOscImageDistribution(t *testing.T) {
+	copyNotReady := infrastructurev1beta1.OscImageCopy{
+		Region:      "us-east-2",
+		AccountId:   "222222222222",
+		ImageId:     "ami-copy",
+		ClusterName: "test-cluster-api",
+		Credentials: &infrastructurev1beta1.OscCredentials{FromSecret: "consumer-creds"},
+	}
+	copyReady := copyNotReady
+	copyReady.Ready = true
+	copyOfDeletedCluster := copyReady
+	copyOfDeletedCluster.ClusterName = "deleted-cluster"
+	tcs := []imageDistributionTestcase{
+		{
+			name:           "the image is shared and copied to another account and region",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0", "test-cluster-api-md-1"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("222222222222"),
+				mockUpdateLaunchPermissions([]string{"222222222222"}, nil),
+				mockGetImageByName("golden-image", "222222222222", nil),
+				mockCopyImage("ami-copy"),
+				mockGetImageCopy("ami-copy", "pending", false),
+				mockAddImageTags("ami-copy", map[string]string{compute.TagKeyImageDistribution: distributionUID}),
+			},
+			requeue: true,
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(false, []string{"222222222222"}, copyNotReady),
+			},
+			next: &imageDistributionTestcase{
+				mockFuncs: []mockFunc{
+					mockGetSourceImage("222222222222"),
+					mockGetAccountId("222222222222"),
+					mockGetImageCopy("ami-copy", "available", true),
+				},
+				distributionAssert: []assertOSCImageDistributionFunc{
+					assertImageDistributionStatus(true, []string{"222222222222"}, copyReady),
+				},
+				next: &imageDistributionTestcase{
+					mockFuncs: []mockFunc{
+						mockGetSourceImage("222222222222"),
+						mockGetAccountId("222222222222"),
+					},
+					distributionAssert: []assertOSCImageDistributionFunc{
+						assertImageDistributionStatus(true, []string{"222222222222"}, copyReady),
+					},
+				},
+			},
+		},
+		{
+			name:           "the image is shared and copied to clusters using a matching name pattern",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			templateImage:  &infrastructurev1beta1.OscImage{NamePattern: "{{.K8sVersion}}-image"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("222222222222"),
+				mockUpdateLaunchPermissions([]string{"222222222222"}, nil),
+				mockGetImageByName("golden-image", "222222222222", nil),
+				mockCopyImage("ami-copy"),
+				mockGetImageCopy("ami-copy", "pending", false),
+				mockAddImageTags("ami-copy", map[string]string{compute.TagKeyImageDistribution: distributionUID}),
+			},
+			requeue: true,
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(false, []string{"222222222222"}, copyNotReady),
+			},
+		},
+		{
+			name:           "templates with a name pattern not matching the image are ignored",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			templateImage:  &infrastructurev1beta1.OscImage{NamePattern: "other-{{.K8sVersion}}-*"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:            "the image is shared but not copied to clusters using its ID",
+			consumerRegion:  "us-east-2",
+			templates:       []string{"test-cluster-api-md-0"},
+			templateImageId: "ami-source",
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("222222222222"),
+				mockUpdateLaunchPermissions([]string{"222222222222"}, nil),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, []string{"222222222222"}),
+			},
+		},
+		{
+			name:           "the image is only shared to another account in the same region",
+			consumerRegion: "eu-west-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("222222222222"),
+				mockUpdateLaunchPermissions([]string{"222222222222"}, nil),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, []string{"222222222222"}),
+			},
+		},
+		{
+			name:           "the image is only copied to another region of the same account",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("111111111111"),
+				mockGetImageByName("golden-image", "111111111111", imageCopy("ami-copy", "available", true)),
+				mockGetImageCopy("ami-copy", "available", true),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil, infrastructurev1beta1.OscImageCopy{
+					Region:      "us-east-2",
+					AccountId:   "111111111111",
+					ImageId:     "ami-copy",
+					ClusterName: "test-cluster-api",
+					Credentials: &infrastructurev1beta1.OscCredentials{FromSecret: "consumer-creds"},
+					Ready:       true,
+				}),
+			},
+		},
+		{
+			name:           "unused copies are deleted and the image is unshared",
+			consumerRegion: "us-east-2",
+			templates:      []string{"orphan"},
+			status: infrastructurev1beta1.OscImageDistributionStatus{
+				SharedAccountIds: []string{"222222222222"},
+				Copies:           []infrastructurev1beta1.OscImageCopy{copyReady},
+				Ready:            true,
+			},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage("222222222222"),
+				mockUpdateLaunchPermissions(nil, []string{"222222222222"}),
+				mockGetImageCopy("ami-copy", "available", true),
+				mockDeleteImage("ami-copy"),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:           "copies of a deleted cluster are deleted with the recorded credentials",
+			consumerRegion: "us-east-2",
+			status: infrastructurev1beta1.OscImageDistributionStatus{
+				Copies: []infrastructurev1beta1.OscImageCopy{copyOfDeletedCluster},
+				Ready:  true,
+			},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetImageCopy("ami-copy", "available", true),
+				mockDeleteImage("ami-copy"),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:           "copies of a deleted cluster without recorded credentials are forgotten",
+			consumerRegion: "us-east-2",
+			status: infrastructurev1beta1.OscImageDistributionStatus{
+				Copies: []infrastructurev1beta1.OscImageCopy{{
+					Region:      "us-east-2",
+					AccountId:   "222222222222",
+					ImageId:     "ami-copy",
+					ClusterName: "deleted-cluster",
+					Ready:       true,
+				}},
+				Ready: true,
+			},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:           "an image with the same name that is not a copy is neither copied over nor deleted",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetAccountId("111111111111"),
+				mockGetImageByName("golden-image", "111111111111", imageCopy("ami-other", "available", false)),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:           "an unused image that is not a copy is forgotten but not deleted",
+			consumerRegion: "us-east-2",
+			templates:      []string{"orphan"},
+			status: infrastructurev1beta1.OscImageDistributionStatus{
+				Copies: []infrastructurev1beta1.OscImageCopy{copyReady},
+				Ready:  true,
+			},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage(),
+				mockGetImageCopy("ami-copy", "available", false),
+			},
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(true, nil),
+			},
+		},
+		{
+			name:           "a failed copy is deleted and copied again",
+			consumerRegion: "us-east-2",
+			templates:      []string{"test-cluster-api-md-0"},
+			status: infrastructurev1beta1.OscImageDistributionStatus{
+				SharedAccountIds: []string{"222222222222"},
+				Copies:           []infrastructurev1beta1.OscImageCopy{copyNotReady},
+			},
+			mockFuncs: []mockFunc{
+				mockGetSourceImage("222222222222"),
+				mockGetAccountId("222222222222"),
+				mockGetImageCopy("ami-copy", "failed", true),
+				mockDeleteImage("ami-copy"),
+			},
+			hasError: true,
+			distributionAssert: []assertOSCImageDistributionFunc{
+				assertImageDistributionStatus(false, []string{"222222222222"}),
+			},
+			next: &imageDistributionTestcase{
+				mockFuncs: []mockFunc{
+					mockGetSourceImage("222222222222"),
+					mockGetAccountId("222222222222"),
+					mockGetImageByName("golden-image", "222222222222", nil),
+					mockCopyImage("ami-copy"),
+					mockGetImageCopy("ami-copy", "pending", false),
+					mockAddImageTags("ami-copy", map[string]string{compute.TagKeyImageDistribution: distributionUID}),
+				},
+				requeue: true,
+				distributionAssert: []assertOSCImageDistributionFunc{
+					assertImageDistributionStatus(false, []string{"222222222222"}, copyNotReady),
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			runImageDistributionTest(t, tc)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// uploadBootstrapData uploads the user data of a VM to OOS, and returns a user data fetching it with a presigned URL.
+func (r *OscMachineReconciler) uploadBootstrapData(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope, userData, format string) (string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	storageSpec := clusterScope.OscCluster.Spec.BootstrapStorage
+	svc := r.Cloud.ObjectStorage(clusterScope.Tenant, storageSpec.Endpoint)
+	key := machineScope.GetBootstrapDataKey(clusterScope)
+	log.V(3).Info("Uploading bootstrap data", "bucket", storageSpec.Bucket, "key", key)
+	err := svc.PutObject(ctx, storageSpec.Bucket, key, []byte(userData))
+	if err != nil {
+		return "", fmt.Errorf("cannot upload bootstrap data: %w", err)
+	}
+	r.Tracker.setBootstrapDataKey(machineScope, key)
+	url, err := svc.PresignGetObject(ctx, storageSpec.Bucket, key, storageSpec.GetURLExpiration())
+	if err != nil {
+		return "", fmt.Errorf("cannot presign bootstrap data: %w", err)
+	}
+	switch format {
+	case scope.BootstrapFormatIgnition:
+		return ignitionFetcher(userData, url)
+	default:
+		return "#include\n" + url + "\n", nil
+	}
+}
+
+// reconcileDeleteBootstrapData deletes the bootstrap data uploaded to OOS.
+func (r *OscMachineReconciler) reconcileDeleteBootstrapData(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) error {
+	log := ctrl.LoggerFrom(ctx)
+	key := getResource(defaultResource, machineScope.GetResources().BootstrapData)
+	if key == "" {
+		return nil
+	}
+	storageSpec := clusterScope.OscCluster.Spec.BootstrapStorage
+	if storageSpec.Bucket != "" {
+		log.V(3).Info("Deleting bootstrap data", "bucket", storageSpec.Bucket, "key", key)
+		err := r.Cloud.ObjectStorage(clusterScope.Tenant, storageSpec.Endpoint).DeleteObject(ctx, storageSpec.Bucket, key)
+		if err != nil {
+			return fmt.Errorf("cannot delete bootstrap data: %w", err)
+		}
+	}
+	r.Tracker.setBootstrapDataKey(machineScope, "")
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -49,6 +50,8 @@ type OscMachineReconciler struct {
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=oscmachines/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -166,6 +169,14 @@ func (r *OscMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		return reconcile.Result{}, errs.ToAggregate()
 	}
 
+	if machineScope.GetVm().DedicatedSecurityGroup.Enable {
+		_, err := r.reconcileDedicatedSecurityGroup(ctx, clusterScope, machineScope)
+		if err != nil {
+			conditions.MarkFalse(oscmachine, infrastructurev1beta1.VmReadyCondition, infrastructurev1beta1.VmNotReadyReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
 	reconcileVm, err := r.reconcileVm(ctx, clusterScope, machineScope)
 	switch {
 	case err != nil:
@@ -193,6 +204,20 @@ func (r *OscMachineReconciler) reconcileDelete(ctx context.Context, machineScope
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	err = r.reconcileDeleteBootstrapData(ctx, clusterScope, machineScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	err = r.reconcileDeleteIPAddressClaims(ctx, machineScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if machineScope.GetVm().DedicatedSecurityGroup.Enable {
+		res, err := r.reconcileDeleteDedicatedSecurityGroup(ctx, clusterScope, machineScope)
+		if err != nil || !res.IsZero() {
+			return res, err
+		}
+	}
 	controllerutil.RemoveFinalizer(oscmachine, OscMachineFinalizer)
 	return reconcile.Result{}, nil
 }
@@ -207,6 +232,7 @@ func (r *OscMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetScheme(), ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		For(&infrastructurev1beta1.OscMachine{}).
+		Owns(&ipamv1.IPAddressClaim{}).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(util.MachineToInfrastructureMapFunc(infrastructurev1beta1.GroupVersion.WithKind("OscMachine"))),
@@ -6,7 +6,9 @@ SPDX-License-Identifier: BSD-3-Clause
 package controllers_test
 
 import (
+	"cmp"
 	"context"
+	"strings"
 	"testing"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
@@ -17,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,7 +29,9 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -39,6 +44,9 @@ func runMachineTest(t *testing.T, tc testcase) {
 		Kind:       "Machine",
 		Name:       m.Name,
 	}}
+	for _, fn := range tc.clusterPatches {
+		fn(oc)
+	}
 	for _, fn := range tc.machinePatches {
 		fn(om)
 	}
@@ -48,8 +56,20 @@ func runMachineTest(t *testing.T, tc testcase) {
 	_ = clusterv1.AddToScheme(fakeScheme)
 	_ = apiextensionsv1.AddToScheme(fakeScheme)
 	_ = infrastructurev1beta1.AddToScheme(fakeScheme)
+	_ = ipamv1.AddToScheme(fakeScheme)
+	objs := []client.Object{c, oc, m, om}
+	if m.Spec.Bootstrap.DataSecretName != nil {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: m.Namespace, Name: *m.Spec.Bootstrap.DataSecretName},
+			Data:       map[string][]byte{"value": []byte(cmp.Or(tc.bootstrapData, "#cloud-config\n"))},
+		}
+		if tc.bootstrapFormat != "" {
+			secret.Data["format"] = []byte(tc.bootstrapFormat)
+		}
+		objs = append(objs, secret)
+	}
 	client := fake.NewClientBuilder().WithScheme(fakeScheme).
-		WithStatusSubresource(om).WithObjects(c, oc, m, om).Build()
+		WithStatusSubresource(om).WithObjects(objs...).WithObjects(tc.kubeObjects...).Build()
 	mockCtrl := gomock.NewController(t)
 	region := tc.region
 	if region == "" {
@@ -95,6 +115,9 @@ func runMachineTest(t *testing.T, tc testcase) {
 				fn(t, &out)
 			}
 		}
+		for _, fn := range step.kubeAsserts {
+			fn(t, client)
+		}
 		step = step.next
 	}
 }
@@ -130,7 +153,7 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 					clusterSpec: "ready-0.4", machineSpec: "base-worker",
 					machinePatches: []patchOSCMachineFunc{patchVmExists("i-foo", infrastructurev1beta1.VmStatePending, false)},
 					mockFuncs: []mockFunc{
-						mockGetVm("i-foo", "running", false),
+						mockGetVm("i-foo", "running", false, workerSecurityGroupIds...),
 						mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
 					},
 					machineAsserts: []assertOSCMachineFunc{
@@ -140,6 +163,246 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "Creating a worker in a cluster with a proxy, the proxy is configured in the user data",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchProxy(infrastructurev1beta1.OscProxy{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    []string{".example.com"},
+				}),
+			},
+			bootstrapData: "#cloud-config\nruncmd:\n- kubeadm join\n",
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmWithUserData("i-foo", "Content-Type: multipart/mixed", "- kubeadm join",
+					"HTTPS_PROXY=http://proxy.example.com:3128", "NO_PROXY=localhost,127.0.0.1,169.254.169.254,10.0.0.0/16", ",.example.com"),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+		},
+		{
+			name:        "Creating a worker with additional user data, the parts are merged with the bootstrap data",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAdditionalUserData(
+					infrastructurev1beta1.OscUserDataPart{Content: "#!/bin/sh\necho inline\n"},
+					infrastructurev1beta1.OscUserDataPart{FromSecret: "hardening", SecretKey: "script", MergeType: "list(append)"},
+				),
+			},
+			kubeObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-api-test", Name: "hardening"},
+					Data:       map[string][]byte{"script": []byte("#cloud-config\nruncmd:\n- echo secret\n")},
+				},
+			},
+			bootstrapData: "#cloud-config\nruncmd:\n- kubeadm join\n",
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmWithUserData("i-foo", "Content-Type: multipart/mixed", "- kubeadm join",
+					"Content-Type: text/x-shellscript", "echo inline", "Merge-Type: list(append)", "- echo secret"),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+		},
+		{
+			name:        "Creating a worker with a missing user data secret, an error is returned",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAdditionalUserData(infrastructurev1beta1.OscUserDataPart{FromSecret: "hardening"}),
+			},
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			hasError: true,
+		},
+		{
+			name:        "Creating a worker with a too large user data, an error is returned",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAdditionalUserData(infrastructurev1beta1.OscUserDataPart{Content: "#!/bin/sh\n" + strings.Repeat("#", compute.MaxUserDataSize)}),
+			},
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			hasError: true,
+		},
+		{
+			name:        "Creating a worker with an Ignition bootstrap, tags are added and the public IP is linked by the API",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchUsePublicIP(),
+				patchVmTags(map[string]string{"env": "test"}),
+			},
+			bootstrapData:   `{"ignition":{"version":"3.4.0"}}`,
+			bootstrapFormat: "ignition",
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmWithUserData("i-foo", `{"ignition":{"version":"3.4.0"}}`),
+				mockAddVmTags("i-foo", map[string]string{"env": "test"}),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetVmWithTags("i-foo", "running", map[string]string{"env": "test"}, workerSecurityGroupIds...),
+					mockCreatePublicIp("cluster-api-test-worker", "9e1db9c4-bf0a-4583-8999-203ec002c520", "ipalloc-worker", "1.2.3.4"),
+					mockLinkPublicIp("ipalloc-worker", "i-foo"),
+					mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				},
+				machineAsserts: []assertOSCMachineFunc{
+					assertVmExists("i-foo", infrastructurev1beta1.VmStateRunning, true),
+					assertStatusMachineResources(infrastructurev1beta1.OscMachineResources{
+						Image:     map[string]string{"default": "ami-foo"},
+						Vm:        map[string]string{"default": "i-foo"},
+						PublicIPs: map[string]string{"default": "ipalloc-worker"},
+						Volumes:   map[string]string{"/dev/sda1": "vol-foo"},
+					}),
+				},
+			},
+		},
+		{
+			name:        "Creating a worker with an Ignition bootstrap and a proxy, the bootstrap config is merged",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchProxy(infrastructurev1beta1.OscProxy{HTTPSProxy: "http://proxy.example.com:3128"}),
+			},
+			machinePatches: []patchOSCMachineFunc{
+				patchAdditionalUserData(infrastructurev1beta1.OscUserDataPart{Content: `{"ignition":{"version":"3.4.0"},"storage":{}}`}),
+			},
+			bootstrapData:   `{"ignition":{"version":"3.4.0"}}`,
+			bootstrapFormat: "ignition",
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmWithUserData("i-foo", `{"ignition":{"version":"3.4.0","config":{"merge":[{"source":"data:;base64,eyJpZ25pdGlvbiI6eyJ2ZXJzaW9uIjoiMy40LjAifX0="},{"source":"data:;base64,`,
+					`{"name":"containerd.service","dropins":[{"name":"http-proxy.conf","contents":"[Service]\nEnvironment=\"HTTPS_PROXY=http://proxy.example.com:3128\"`),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+		},
+		{
+			name:        "Creating a worker with bootstrap storage, the bootstrap data is uploaded to OOS",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchBootstrapStorage("bootstrap"),
+			},
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockPutObject("bootstrap", "cluster-api-test/cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", "#cloud-config\n"),
+				mockPresignGetObject("bootstrap", "cluster-api-test/cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", "https://oos.example.com/bootstrap/foo?X-Amz-Signature=bar"),
+				mockCreateVmWithUserData("i-foo", "#include\nhttps://oos.example.com/bootstrap/foo?X-Amz-Signature=bar\n"),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+				assertBootstrapDataKey("cluster-api-test/cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+		},
+		{
+			name:        "Creating a worker with bootstrap storage and an Ignition bootstrap, the Ignition config is replaced",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchBootstrapStorage("bootstrap"),
+			},
+			bootstrapData:   `{"ignition":{"version":"3.4.0"}}`,
+			bootstrapFormat: "ignition",
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockPutObject("bootstrap", "cluster-api-test/cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", `{"ignition":{"version":"3.4.0"}}`),
+				mockPresignGetObject("bootstrap", "cluster-api-test/cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", "https://oos.example.com/bootstrap/foo?X-Amz-Signature=bar"),
+				mockCreateVmWithUserData("i-foo", `{"ignition":{"version":"3.4.0","config":{"replace":{"source":"https://oos.example.com/bootstrap/foo?X-Amz-Signature=bar"}}}}`),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+		},
+		{
+			name:        "Creating a worker with multiple worker subnets, the subnet with the most available IPs is used",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchAddSubnet(infrastructurev1beta1.OscSubnet{
+					Name:          "test-cluster-api-subnet-kw-2",
+					IpSubnetRange: "10.0.5.0/24",
+					ResourceId:    "subnet-kw2",
+					SubregionName: "eu-west-2a",
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockListSubnets([]string{"subnet-1555ea91", "subnet-kw2"}, []osc.Subnet{
+					{SubnetId: ptr.To("subnet-1555ea91"), AvailableIpsCount: ptr.To[int32](3)},
+					{SubnetId: ptr.To("subnet-kw2"), AvailableIpsCount: ptr.To[int32](250)},
+				}),
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmNoVolumes("i-foo", "ami-foo", "subnet-kw2", []string{"sg-a093d014", "sg-0cd1f87e"}, []string{}, "cluster-api-test-worker", "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+			},
+			requeue: true,
+		},
+		{
+			name:        "Using an image name pattern, the newest image matching the Kubernetes version is used",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchImageNamePattern("ubuntu-2204-kubernetes-{{.K8sVersion}}-*", "01234", "56789"),
+			},
+			mockFuncs: []mockFunc{
+				mockListImagesByName("ubuntu-2204-kubernetes-v1.25.9-*", []string{"01234", "56789"},
+					osc.Image{ImageId: ptr.To("ami-old"), ImageName: ptr.To("ubuntu-2204-kubernetes-v1.25.9-2025-01-10"), CreationDate: ptr.To("2025-01-10T10:00:00.000Z")},
+					osc.Image{ImageId: ptr.To("ami-new"), ImageName: ptr.To("ubuntu-2204-kubernetes-v1.25.9-2025-03-02"), CreationDate: ptr.To("2025-03-02T10:00:00.000Z")},
+					osc.Image{ImageId: ptr.To("ami-other"), ImageName: ptr.To("ubuntu-2204-kubernetes-v1.25.10-2025-04-01"), CreationDate: ptr.To("2025-04-01T10:00:00.000Z")},
+				),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmNoVolumes("i-foo", "ami-new", "subnet-1555ea91", []string{"sg-a093d014", "sg-0cd1f87e"}, []string{}, "cluster-api-test-worker", "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertStatusMachineResources(infrastructurev1beta1.OscMachineResources{
+					Image:   map[string]string{"default": "ami-new"},
+					Vm:      map[string]string{"default": "i-foo"},
+					Volumes: map[string]string{"/dev/sda1": "vol-foo"},
+				}),
+			},
+		},
+		{
+			name:        "Using an image name pattern, no image matches",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchImageNamePattern("ubuntu-2204-kubernetes-{{.K8sVersion}}-*", "01234"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockListImagesByName("ubuntu-2204-kubernetes-v1.25.9-*", []string{"01234"}),
+			},
+			hasError: true,
+		},
 		{
 			name:        "Using an opensource image (eu-west-2)",
 			region:      "eu-west-2",
@@ -203,7 +466,7 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			requeue: true,
 			next: &testcase{
 				mockFuncs: []mockFunc{
-					mockGetVm("i-foo", "running", false),
+					mockGetVm("i-foo", "running", false, controlPlaneSecurityGroupIds...),
 					mockLoadBalancerFound("test-cluster-api-k8s", "test-cluster-api-9e1db9c4-bf0a-4583-8999-203ec002c520"),
 					mockLinkLoadBalancer("i-foo", "test-cluster-api-k8s"),
 					mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
@@ -230,12 +493,45 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 			next: &testcase{
 				mockFuncs: []mockFunc{
-					mockGetVm("i-foo", "running", false),
+					mockGetVm("i-foo", "running", false, controlPlaneSecurityGroupIds...),
 					mockGetLoadBalancer("test-cluster-api-k8s", nil),
 				},
 				hasError: true,
 			},
 		},
+		{
+			name:        "Creating a controlplane with a pre-created nic",
+			clusterSpec: "ready-0.4", machineSpec: "base-controlplane",
+			clusterPatches: []patchOSCClusterFunc{
+				patchControlPlaneNics(infrastructurev1beta1.OscNicSlot{Name: "kcp"}),
+				patchControlPlaneNicStatus("kcp", "eni-kcp"),
+			},
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("uster-api-test-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockGetNic("eni-kcp", "available", ""),
+				mockCreateVmWithNic("i-foo", "eni-kcp"),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+			},
+			requeue: true,
+		},
+		{
+			name:        "Creating a controlplane waits for the pre-created nic to be released",
+			clusterSpec: "ready-0.4", machineSpec: "base-controlplane",
+			clusterPatches: []patchOSCClusterFunc{
+				patchControlPlaneNics(infrastructurev1beta1.OscNicSlot{Name: "kcp"}),
+				patchControlPlaneNicStatus("kcp", "eni-kcp"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmFromClientToken("uster-api-test-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-controlplane-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockGetNic("eni-kcp", "in-use", "i-old"),
+			},
+			requeue: true,
+		},
 
 		// Volumes
 		{
@@ -266,7 +562,7 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 			next: &testcase{
 				mockFuncs: []mockFunc{
-					mockGetVm("i-foo", "running", false),
+					mockGetVm("i-foo", "running", false, workerSecurityGroupIds...),
 					mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
 				},
 				machineAsserts: []assertOSCMachineFunc{
@@ -277,6 +573,49 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 		},
 
+		// Dedicated security group
+		{
+			name:        "Creating a vm with a dedicated securityGroup",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchDedicatedSecurityGroup(infrastructurev1beta1.OscSecurityGroupRule{
+					Flow:          "Inbound",
+					IpProtocol:    "tcp",
+					IpRange:       "10.0.0.0/16",
+					FromPortRange: 9100,
+					ToPortRange:   9100,
+				}),
+			},
+			mockFuncs: []mockFunc{
+				mockReadDedicatedSecurityGroupTagNoneFound(),
+				mockCreateMachineSecurityGroup("vpc-24ba90ce", "9e1db9c4-bf0a-4583-8999-203ec002c520", "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", "sg-dedicated"),
+				mockCreateSecurityGroupRule("sg-dedicated", "Inbound", "tcp", "10.0.0.0/16", 9100, 9100),
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmNoVolumes("i-foo", "ami-foo", "subnet-1555ea91", []string{"sg-a093d014", "sg-0cd1f87e", "sg-dedicated"}, []string{}, "cluster-api-test-worker", "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertAttachedSecurityGroups("sg-a093d014", "sg-0cd1f87e", "sg-dedicated"),
+				assertHasMachineFinalizer(),
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+				assertStatusMachineResources(infrastructurev1beta1.OscMachineResources{
+					Image: map[string]string{
+						"default": "ami-foo",
+					},
+					Vm: map[string]string{
+						"default": "i-foo",
+					},
+					Volumes: map[string]string{
+						"/dev/sda1": "vol-foo",
+					},
+					SecurityGroup: map[string]string{
+						"default": "sg-dedicated",
+					},
+				}),
+			},
+		},
 		// Public IPs
 		{
 			name:        "Creating a vm with a dynamic public IP",
@@ -300,7 +639,7 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 			next: &testcase{
 				mockFuncs: []mockFunc{
-					mockGetVm("i-foo", "running", false),
+					mockGetVm("i-foo", "running", false, workerSecurityGroupIds...),
 					mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
 				},
 				machineAsserts: []assertOSCMachineFunc{
@@ -345,7 +684,7 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 			next: &testcase{
 				mockFuncs: []mockFunc{
-					mockGetVm("i-foo", "running", false),
+					mockGetVm("i-foo", "running", false, workerSecurityGroupIds...),
 					mockVmSetCCMTag("i-foo", "9e1db9c4-bf0a-4583-8999-203ec002c520"),
 				},
 				machineAsserts: []assertOSCMachineFunc{
@@ -396,6 +735,44 @@ func TestReconcileOSCMachine_Create(t *testing.T) {
 			},
 			hasError: true,
 		},
+
+		// IPAM
+		{
+			name:        "Creating a vm with addresses from pools, claims are created and the vm waits for addresses",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAddressesFromPools("pool-foo"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertHasMachineFinalizer(),
+				assertIPAddressClaimed(false),
+			},
+		},
+		{
+			name:        "Creating a vm with addresses from pools, addresses have been allocated",
+			clusterSpec: "ready-0.4", machineSpec: "base-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAddressesFromPools("pool-foo"),
+			},
+			kubeObjects: ipAddressClaimed("cluster-api-test-worker-0", "pool-foo", "10.0.3.42"),
+			mockFuncs: []mockFunc{
+				mockImageFoundByName("ubuntu-2004-2004-kubernetes-v1.25.9-2023-04-14", "01234", "ami-foo"),
+				mockGetVmFromClientToken("cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+				mockReadTagByNameNoneFound(tag.VmResourceType, "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				mockCreateVmNoVolumes("i-foo", "ami-foo", "subnet-1555ea91", []string{"sg-a093d014", "sg-0cd1f87e"}, []string{"10.0.3.42"}, "cluster-api-test-worker", "cluster-api-test-worker-9e1db9c4-bf0a-4583-8999-203ec002c520", nil),
+			},
+			requeue: true,
+			machineAsserts: []assertOSCMachineFunc{
+				assertHasMachineFinalizer(),
+				assertVmExists("i-foo", infrastructurev1beta1.VmStatePending, false),
+				assertIPAddressClaimed(true),
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -412,7 +789,7 @@ func TestReconcileOSCMachine_Update(t *testing.T) {
 			machineBaseSpec: "ready-worker",
 			machinePatches:  []patchOSCMachineFunc{patchMoveMachine()},
 			mockFuncs: []mockFunc{
-				mockGetVm("i-046f4bd0", "running", true),
+				mockGetVm("i-046f4bd0", "running", true, workerSecurityGroupIds...),
 			},
 			machineAsserts: []assertOSCMachineFunc{
 				assertStatusMachineResources(infrastructurev1beta1.OscMachineResources{
@@ -432,8 +809,9 @@ func TestReconcileOSCMachine_Update(t *testing.T) {
 			machinePatches:  []patchOSCMachineFunc{patchMoveMachine()},
 			mockFuncs: []mockFunc{
 				mockGetVmFromClientToken("luster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Vm{
-					VmId:  ptr.To("i-worker"),
-					State: ptr.To("running"),
+					VmId:           ptr.To("i-worker"),
+					State:          ptr.To("running"),
+					SecurityGroups: vmSecurityGroups(workerSecurityGroupIds...),
 					Tags: &[]osc.ResourceTag{
 						{Key: compute.TagKeyNodeName, Value: defaultPrivateDnsName},
 						{Key: compute.TagKeyClusterIDPrefix + "foo", Value: "owned"},
@@ -461,9 +839,10 @@ func TestReconcileOSCMachine_Update(t *testing.T) {
 			},
 			mockFuncs: []mockFunc{
 				mockGetVmFromClientToken("luster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520", &osc.Vm{
-					VmId:     ptr.To("i-worker"),
-					PublicIp: ptr.To("1.2.3.4"),
-					State:    ptr.To("running"),
+					VmId:           ptr.To("i-worker"),
+					PublicIp:       ptr.To("1.2.3.4"),
+					State:          ptr.To("running"),
+					SecurityGroups: vmSecurityGroups(workerSecurityGroupIds...),
 					Tags: &[]osc.ResourceTag{
 						{Key: compute.TagKeyNodeName, Value: defaultPrivateDnsName},
 						{Key: compute.TagKeyClusterIDPrefix + "foo", Value: "owned"},
@@ -485,6 +864,143 @@ func TestReconcileOSCMachine_Update(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:        "The node of a worker has joined, the bootstrap data is deleted from OOS",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchBootstrapStorage("bootstrap"),
+			},
+			machinePatches: []patchOSCMachineFunc{
+				patchBootstrapDataStatus("cluster-api-test/test-cluster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true, workerSecurityGroupIds...),
+				mockDeleteObject("bootstrap", "cluster-api-test/test-cluster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertBootstrapDataKey(""),
+			},
+		},
+		{
+			name:        "The node of a reconciled worker has joined, the bootstrap data is deleted from OOS",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			clusterPatches: []patchOSCClusterFunc{
+				patchBootstrapStorage("bootstrap"),
+			},
+			machinePatches: []patchOSCMachineFunc{
+				patchBootstrapDataStatus("cluster-api-test/test-cluster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+				patchMachineReconciled(infrastructurev1beta1.ReconcilerVm),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true, workerSecurityGroupIds...),
+				mockDeleteObject("bootstrap", "cluster-api-test/test-cluster-api-md-0-6p8qk-qgvhr-9e1db9c4-bf0a-4583-8999-203ec002c520"),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertBootstrapDataKey(""),
+			},
+		},
+		{
+			name:        "A securityGroup is added to a running worker",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true, "sg-a093d014"),
+				mockUpdateVmSecurityGroups("i-046f4bd0", workerSecurityGroupIds...),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertAttachedSecurityGroups(workerSecurityGroupIds...),
+			},
+		},
+		{
+			name:        "A securityGroup removed from the spec is detached from a running worker",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchAttachedSecurityGroups("sg-a093d014", "sg-0cd1f87e", "sg-old"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true, "sg-a093d014", "sg-0cd1f87e", "sg-old", "sg-foo"),
+				mockUpdateVmSecurityGroups("i-046f4bd0", "sg-a093d014", "sg-0cd1f87e", "sg-foo"),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertAttachedSecurityGroups(workerSecurityGroupIds...),
+			},
+		},
+		{
+			name:        "A securityGroup attached out of band to a running worker is kept",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true, "sg-a093d014", "sg-0cd1f87e", "sg-foo"),
+			},
+			machineAsserts: []assertOSCMachineFunc{
+				assertAttachedSecurityGroups(workerSecurityGroupIds...),
+			},
+		},
+		{
+			name:        "Missing or changed tags are added to a running worker",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchVmTags(map[string]string{"env": "test", "team": "foo", "owner": "bar"}),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmWithTags("i-046f4bd0", "running", map[string]string{
+					compute.TagKeyNodeName:                defaultPrivateDnsName,
+					compute.TagKeyClusterIDPrefix + "foo": "owned",
+					"env":                                 "test",
+					"team":                                "other",
+				}, workerSecurityGroupIds...),
+				mockAddVmTags("i-046f4bd0", map[string]string{"team": "foo", "owner": "bar"}),
+			},
+		},
+		{
+			name:        "Tags changed out of band are repaired on a reconciled worker",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchVmTags(map[string]string{"env": "test"}),
+				patchMachineReconciled(infrastructurev1beta1.ReconcilerVm),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVmWithTags("i-046f4bd0", "running", map[string]string{
+					compute.TagKeyNodeName:                defaultPrivateDnsName,
+					compute.TagKeyClusterIDPrefix + "foo": "owned",
+					"env":                                 "prod",
+				}, workerSecurityGroupIds...),
+				mockAddVmTags("i-046f4bd0", map[string]string{"env": "test"}),
+			},
+		},
+		{
+			name:        "Rules of the dedicated securityGroup are updated",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchDedicatedSecurityGroup(infrastructurev1beta1.OscSecurityGroupRule{
+					Flow:          "Inbound",
+					IpProtocol:    "tcp",
+					IpRange:       "10.0.0.0/16",
+					FromPortRange: 9100,
+					ToPortRange:   9100,
+				}),
+				patchDedicatedSecurityGroupStatus("sg-dedicated"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetSecurityGroup("sg-dedicated", &osc.SecurityGroup{
+					InboundRules: &[]osc.SecurityGroupRule{{
+						IpProtocol:    ptr.To("tcp"),
+						IpRanges:      &[]string{"10.0.0.0/16"},
+						FromPortRange: ptr.To[int32](9090),
+						ToPortRange:   ptr.To[int32](9090),
+					}},
+				}),
+				mockCreateSecurityGroupRule("sg-dedicated", "Inbound", "tcp", "10.0.0.0/16", 9100, 9100),
+				mockDeleteSecurityGroupRule("sg-dedicated", "Inbound", "tcp", "10.0.0.0/16", "", 9090, 9090),
+				mockGetVm("i-046f4bd0", "running", true, "sg-a093d014", "sg-0cd1f87e", "sg-dedicated"),
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -549,6 +1065,48 @@ func TestReconcileOSCMachine_Delete(t *testing.T) {
 			},
 			assertDeleted: true,
 		},
+		{
+			name:        "deleting a 0.5 machine with addresses from pools",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchDeleteMachine(),
+				patchAddressesFromPools("pool-foo"),
+			},
+			kubeObjects: ipAddressClaimed("test-cluster-api-md-0-6p8qk-qgvhr-0", "pool-foo", "10.0.3.42"),
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true),
+				mockDeleteVm("i-046f4bd0"),
+			},
+			assertDeleted: true,
+			kubeAsserts: []assertKubeFunc{
+				assertIPAddressClaimDeleted("test-cluster-api-md-0-6p8qk-qgvhr-0"),
+			},
+		},
+		{
+			name:        "deleting a machine with a dedicated securityGroup waits for the vm to be terminated",
+			clusterSpec: "ready-0.4", machineSpec: "ready-worker-1.0",
+			machineBaseSpec: "ready-worker",
+			machinePatches: []patchOSCMachineFunc{
+				patchDeleteMachine(),
+				patchDedicatedSecurityGroup(),
+				patchDedicatedSecurityGroupStatus("sg-dedicated"),
+			},
+			mockFuncs: []mockFunc{
+				mockGetVm("i-046f4bd0", "running", true),
+				mockDeleteVm("i-046f4bd0"),
+				mockGetVm("i-046f4bd0", "shutting-down", true),
+			},
+			requeue: true,
+			next: &testcase{
+				mockFuncs: []mockFunc{
+					mockGetVm("i-046f4bd0", "terminated", true),
+					mockGetVm("i-046f4bd0", "terminated", true),
+					mockDeleteSecurityGroup("sg-dedicated", nil),
+				},
+				assertDeleted: true,
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,39 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// getFreeControlPlaneNic returns the id of an unattached controlplane NIC in a subregion.
+// An empty id is returned if all NICs of the subregion are still attached to VMs.
+func (r *OscMachineReconciler) getFreeControlPlaneNic(ctx context.Context, clusterScope *scope.ClusterScope, subregion string) (string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	for _, slot := range clusterScope.GetControlPlaneNicSlots() {
+		if slot.SubregionName != subregion {
+			continue
+		}
+		nic, err := r.ClusterTracker.getControlPlaneNic(ctx, slot, clusterScope)
+		switch {
+		case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+			log.V(3).Info("Controlplane nic is not created yet", "slot", slot.Name)
+			continue
+		case err != nil:
+			return "", err
+		}
+		if nic.GetState() == "available" {
+			log.V(3).Info("Found free controlplane nic", "slot", slot.Name, "nicId", nic.GetNicId())
+			return nic.GetNicId(), nil
+		}
+		log.V(4).Info("Controlplane nic is in use", "slot", slot.Name, "nicId", nic.GetNicId(), "vmId", nic.GetLinkNic().VmId)
+	}
+	return "", nil
+}
@@ -6,20 +6,33 @@ SPDX-License-Identifier: BSD-3-Clause
 package controllers_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	"github.com/outscale/cluster-api-provider-outscale/controllers"
 	"github.com/outscale/osc-sdk-go/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+var (
+	workerSecurityGroupIds       = []string{"sg-a093d014", "sg-0cd1f87e"}
+	controlPlaneSecurityGroupIds = []string{"sg-750ae810", "sg-0cd1f87e"}
+)
+
 const (
 	defaultPrivateDnsName = "ip-10-0-3-144.eu-west-2.compute.internal"
 	defaultPrivateIp      = "10.0.3.144"
@@ -66,6 +79,19 @@ func patchPublicIPStatus(publicIpId string) patchOSCMachineFunc {
 	}
 }
 
+func patchDedicatedSecurityGroup(rules ...infrastructurev1beta1.OscSecurityGroupRule) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Spec.Node.Vm.DedicatedSecurityGroup.Enable = true
+		m.Spec.Node.Vm.DedicatedSecurityGroup.SecurityGroupRules = rules
+	}
+}
+
+func patchDedicatedSecurityGroupStatus(sgId string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Status.Resources.SecurityGroup = map[string]string{"default": sgId}
+	}
+}
+
 func patchDeleteMachine() patchOSCMachineFunc {
 	return func(m *infrastructurev1beta1.OscMachine) {
 		m.DeletionTimestamp = ptr.To(metav1.Now())
@@ -81,6 +107,112 @@ func patchUseOpenSourceOMI() patchOSCMachineFunc {
 	}
 }
 
+func patchBootstrapDataStatus(key string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Status.Resources.BootstrapData = map[string]string{"default": key}
+	}
+}
+
+func patchAttachedSecurityGroups(securityGroupIds ...string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Status.AttachedSecurityGroups = securityGroupIds
+	}
+}
+
+func patchImageNamePattern(pattern string, accountIds ...string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Spec.Node.Image = infrastructurev1beta1.OscImage{NamePattern: pattern, AccountIds: accountIds}
+	}
+}
+
+func patchMachineReconciled(reconcilers ...infrastructurev1beta1.Reconciler) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		if m.Status.ReconcilerGeneration == nil {
+			m.Status.ReconcilerGeneration = map[infrastructurev1beta1.Reconciler]int64{}
+		}
+		for _, reconciler := range reconcilers {
+			m.Status.ReconcilerGeneration[reconciler] = m.Generation
+		}
+	}
+}
+
+func patchVmTags(tags map[string]string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Spec.Node.Vm.Tags = tags
+	}
+}
+
+func patchAdditionalUserData(parts ...infrastructurev1beta1.OscUserDataPart) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		m.Spec.Node.Vm.AdditionalUserData = parts
+	}
+}
+
+func patchAddressesFromPools(pools ...string) patchOSCMachineFunc {
+	return func(m *infrastructurev1beta1.OscMachine) {
+		for _, pool := range pools {
+			m.Spec.Node.Vm.AddressesFromPools = append(m.Spec.Node.Vm.AddressesFromPools, corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+				Kind:     "InClusterIPPool",
+				Name:     pool,
+			})
+		}
+	}
+}
+
+// ipAddressClaimed returns a fulfilled IPAddressClaim and its IPAddress.
+func ipAddressClaimed(claimName, pool, ip string) []client.Object {
+	poolRef := corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     pool,
+	}
+	return []client.Object{
+		&ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-api-test", Name: claimName},
+			Spec:       ipamv1.IPAddressClaimSpec{PoolRef: poolRef},
+			Status: ipamv1.IPAddressClaimStatus{
+				AddressRef: corev1.LocalObjectReference{Name: claimName},
+			},
+		},
+		&ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-api-test", Name: claimName},
+			Spec: ipamv1.IPAddressSpec{
+				ClaimRef: corev1.LocalObjectReference{Name: claimName},
+				PoolRef:  poolRef,
+				Address:  ip,
+				Prefix:   24,
+			},
+		},
+	}
+}
+
+func assertIPAddressClaimDeleted(claimName string) assertKubeFunc {
+	return func(t *testing.T, c client.Client) {
+		var claim ipamv1.IPAddressClaim
+		err := c.Get(context.TODO(), client.ObjectKey{Namespace: "cluster-api-test", Name: claimName}, &claim)
+		assert.True(t, apierrors.IsNotFound(err), "IPAddressClaim %s must have been deleted", claimName)
+	}
+}
+
+func mockImageNotFoundByName(name, account string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.
+			EXPECT().
+			GetImageByName(gomock.Any(), gomock.Eq(name), gomock.Eq(account)).
+			Return(nil, nil)
+	}
+}
+
+func mockImageByNameError(name, account string, err error) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.
+			EXPECT().
+			GetImageByName(gomock.Any(), gomock.Eq(name), gomock.Eq(account)).
+			Return(nil, err)
+	}
+}
+
 func mockImageFoundByName(name, account, imageId string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.ImageMock.
@@ -90,6 +222,15 @@ func mockImageFoundByName(name, account, imageId string) mockFunc {
 	}
 }
 
+func mockListImagesByName(pattern string, accountIds []string, images ...osc.Image) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ImageMock.
+			EXPECT().
+			ListImagesByName(gomock.Any(), gomock.Eq(pattern), gomock.Eq(accountIds)).
+			Return(images, nil)
+	}
+}
+
 func mockOpenSourceImageFound(name, region, imageId string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.ImageMock.
@@ -99,13 +240,14 @@ func mockOpenSourceImageFound(name, region, imageId string) mockFunc {
 	}
 }
 
-func mockGetVm(vmId, state string, ccmtags bool) mockFunc {
+func mockGetVm(vmId, state string, ccmtags bool, securityGroupIds ...string) mockFunc {
 	vm := &osc.Vm{
 		VmId:                &vmId,
 		PrivateDnsName:      ptr.To(defaultPrivateDnsName),
 		PrivateIp:           ptr.To(defaultPrivateIp),
 		State:               &state,
 		BlockDeviceMappings: &defaultVolumes,
+		SecurityGroups:      vmSecurityGroups(securityGroupIds...),
 	}
 	if ccmtags {
 		vm.Tags = &[]osc.ResourceTag{
@@ -121,6 +263,44 @@ func mockGetVm(vmId, state string, ccmtags bool) mockFunc {
 	}
 }
 
+func mockGetVmWithTags(vmId, state string, tags map[string]string, securityGroupIds ...string) mockFunc {
+	vm := &osc.Vm{
+		VmId:                &vmId,
+		PrivateDnsName:      ptr.To(defaultPrivateDnsName),
+		PrivateIp:           ptr.To(defaultPrivateIp),
+		State:               &state,
+		BlockDeviceMappings: &defaultVolumes,
+		SecurityGroups:      vmSecurityGroups(securityGroupIds...),
+		Tags:                &[]osc.ResourceTag{},
+	}
+	for k, v := range tags {
+		*vm.Tags = append(*vm.Tags, osc.ResourceTag{Key: k, Value: v})
+	}
+	return func(s *MockCloudServices) {
+		s.VMMock.
+			EXPECT().
+			GetVm(gomock.Any(), gomock.Eq(vmId)).
+			Return(vm, nil)
+	}
+}
+
+func vmSecurityGroups(securityGroupIds ...string) *[]osc.SecurityGroupLight {
+	sgs := make([]osc.SecurityGroupLight, 0, len(securityGroupIds))
+	for _, id := range securityGroupIds {
+		sgs = append(sgs, osc.SecurityGroupLight{SecurityGroupId: ptr.To(id)})
+	}
+	return &sgs
+}
+
+func mockUpdateVmSecurityGroups(vmId string, securityGroupIds ...string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.
+			EXPECT().
+			UpdateVmSecurityGroups(gomock.Any(), gomock.Eq(vmId), gomock.Eq(securityGroupIds)).
+			Return(nil)
+	}
+}
+
 func mockGetVmFromClientToken(token string, vm *osc.Vm) mockFunc {
 	if vm != nil {
 		vm.PrivateDnsName = ptr.To(defaultPrivateDnsName)
@@ -137,12 +317,52 @@ func mockGetVmFromClientToken(token string, vm *osc.Vm) mockFunc {
 	}
 }
 
+func mockReadDedicatedSecurityGroupTagNoneFound() mockFunc {
+	return func(s *MockCloudServices) {
+		s.TagMock.EXPECT().
+			ReadTag(gomock.Any(), gomock.Eq(tag.SecurityGroupResourceType), gomock.Eq(tag.MachineUIDKey), gomock.Any()).
+			Return(nil, nil)
+	}
+}
+
+func mockCreateMachineSecurityGroup(netId, clusterId, name, sgId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.SecurityGroupMock.EXPECT().
+			CreateMachineSecurityGroup(gomock.Any(), gomock.Eq(netId), gomock.Eq(clusterId), gomock.Any(), gomock.Eq(name), gomock.Any()).
+			Return(&osc.SecurityGroup{SecurityGroupId: &sgId}, nil)
+	}
+}
+
 func mockCreateVmNoVolumes(vmId, imageId, subnetId string, securityGroupIds, privateIps []string, vmName, clientToken string, vmTags map[string]string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.VMMock.
 			EXPECT().
 			CreateVm(gomock.Any(), gomock.Any(),
-				gomock.Any(), gomock.Eq(imageId), gomock.Eq(subnetId), gomock.Eq(securityGroupIds), gomock.Eq(privateIps), gomock.Eq(vmName), gomock.Eq(clientToken), gomock.Eq(vmTags), gomock.Any()).
+				gomock.Any(), gomock.Eq(imageId), gomock.Eq(subnetId), gomock.Eq(securityGroupIds), gomock.Eq(privateIps), gomock.Eq(""), gomock.Eq(vmName), gomock.Eq(clientToken), gomock.Eq(vmTags), gomock.Any()).
+			Return(&osc.Vm{
+				VmId:                ptr.To(vmId),
+				PrivateDnsName:      ptr.To(defaultPrivateDnsName),
+				PrivateIp:           ptr.To(defaultPrivateIp),
+				BlockDeviceMappings: &defaultVolumes,
+				State:               ptr.To("pending"),
+			}, nil)
+	}
+}
+
+// mockCreateVmWithUserData expects a VM whose user data contains all the strings.
+func mockCreateVmWithUserData(vmId string, contains ...string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.
+			EXPECT().
+			CreateVm(gomock.Any(), gomock.Cond(func(userData string) bool {
+				for _, c := range contains {
+					if !strings.Contains(userData, c) {
+						return false
+					}
+				}
+				return true
+			}),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(&osc.Vm{
 				VmId:                ptr.To(vmId),
 				PrivateDnsName:      ptr.To(defaultPrivateDnsName),
@@ -172,7 +392,7 @@ func mockCreateVmWithVolumes(vmId string, volumes []infrastructurev1beta1.OscVol
 		s.VMMock.
 			EXPECT().
 			CreateVm(gomock.Any(), gomock.Any(),
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(volumes)).
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(volumes)).
 			Return(&osc.Vm{
 				VmId:                ptr.To(vmId),
 				PrivateDnsName:      ptr.To(defaultPrivateDnsName),
@@ -183,6 +403,34 @@ func mockCreateVmWithVolumes(vmId string, volumes []infrastructurev1beta1.OscVol
 	}
 }
 
+func mockCreateVmWithNic(vmId, nicId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.
+			EXPECT().
+			CreateVm(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(nicId), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&osc.Vm{
+				VmId:                ptr.To(vmId),
+				PrivateDnsName:      ptr.To(defaultPrivateDnsName),
+				PrivateIp:           ptr.To(defaultPrivateIp),
+				BlockDeviceMappings: &defaultVolumes,
+				State:               ptr.To("pending"),
+			}, nil)
+	}
+}
+
+func mockGetNic(nicId, state, vmId string) mockFunc {
+	nic := &osc.Nic{NicId: &nicId, State: &state}
+	if vmId != "" {
+		nic.LinkNic = &osc.LinkNic{VmId: &vmId}
+	}
+	return func(s *MockCloudServices) {
+		s.NicMock.EXPECT().
+			GetNic(gomock.Any(), gomock.Eq(nicId)).
+			Return(nic, nil)
+	}
+}
+
 func mockLinkLoadBalancer(vmId, lb string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.LoadBalancerMock.EXPECT().
@@ -191,6 +439,46 @@ func mockLinkLoadBalancer(vmId, lb string) mockFunc {
 	}
 }
 
+func mockPutObject(bucket, key, data string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ObjectStorageMock.EXPECT().
+			PutObject(gomock.Any(), gomock.Eq(bucket), gomock.Eq(key), gomock.Eq([]byte(data))).
+			Return(nil)
+	}
+}
+
+func mockPresignGetObject(bucket, key, url string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ObjectStorageMock.EXPECT().
+			PresignGetObject(gomock.Any(), gomock.Eq(bucket), gomock.Eq(key), gomock.Eq(infrastructurev1beta1.DefaultBootstrapURLExpiration)).
+			Return(url, nil)
+	}
+}
+
+func mockDeleteObject(bucket, key string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.ObjectStorageMock.EXPECT().
+			DeleteObject(gomock.Any(), gomock.Eq(bucket), gomock.Eq(key)).
+			Return(nil)
+	}
+}
+
+func mockAddVmTags(vmId string, tags map[string]string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.VMMock.EXPECT().
+			AddTags(gomock.Any(), gomock.Eq(vmId), gomock.Eq(tags)).
+			Return(nil)
+	}
+}
+
+func mockLinkPublicIp(publicIpId, vmId string) mockFunc {
+	return func(s *MockCloudServices) {
+		s.PublicIpMock.EXPECT().
+			LinkPublicIp(gomock.Any(), gomock.Eq(publicIpId), gomock.Eq(vmId)).
+			Return(nil)
+	}
+}
+
 func mockVmSetCCMTag(vmId, clusterID string) mockFunc {
 	return func(s *MockCloudServices) {
 		s.VMMock.EXPECT().
@@ -209,6 +497,12 @@ func assertVmExists(vmId string, state infrastructurev1beta1.VmState, ready bool
 	}
 }
 
+func assertIPAddressClaimed(claimed bool) assertOSCMachineFunc {
+	return func(t *testing.T, m *infrastructurev1beta1.OscMachine) {
+		assert.Equal(t, claimed, conditions.IsTrue(m, infrastructurev1beta1.IPAddressClaimedCondition))
+	}
+}
+
 func assertHasMachineFinalizer() assertOSCMachineFunc {
 	return func(t *testing.T, m *infrastructurev1beta1.OscMachine) {
 		assert.True(t, controllerutil.ContainsFinalizer(m, controllers.OscMachineFinalizer))
@@ -221,6 +515,18 @@ func assertStatusMachineResources(rsrcs infrastructurev1beta1.OscMachineResource
 	}
 }
 
+func assertBootstrapDataKey(key string) assertOSCMachineFunc {
+	return func(t *testing.T, m *infrastructurev1beta1.OscMachine) {
+		assert.Equal(t, key, m.Status.Resources.BootstrapData["default"])
+	}
+}
+
+func assertAttachedSecurityGroups(securityGroupIds ...string) assertOSCMachineFunc {
+	return func(t *testing.T, m *infrastructurev1beta1.OscMachine) {
+		assert.Equal(t, securityGroupIds, m.Status.AttachedSecurityGroups)
+	}
+}
+
 func assertVolumesAreConfigured(deviceAndVolume ...string) assertOSCMachineFunc {
 	return func(t *testing.T, m *infrastructurev1beta1.OscMachine) {
 		expect := map[string]string{
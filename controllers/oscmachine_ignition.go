@@ -0,0 +1,115 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+)
+
+type ignitionResource struct {
+	Source string `json:"source"`
+}
+
+type ignitionConfigReferences struct {
+	// Append is used by the 2.x specs.
+	Append []ignitionResource `json:"append,omitempty"`
+	// Merge is used by the 3.x specs.
+	Merge []ignitionResource `json:"merge,omitempty"`
+	// Replace is used by all specs.
+	Replace *ignitionResource `json:"replace,omitempty"`
+}
+
+type ignitionMeta struct {
+	Version string                   `json:"version"`
+	Config  ignitionConfigReferences `json:"config,omitzero"`
+}
+
+type ignitionDropin struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
+}
+
+type ignitionUnit struct {
+	Name    string           `json:"name"`
+	Dropins []ignitionDropin `json:"dropins,omitempty"`
+}
+
+type ignitionSystemd struct {
+	Units []ignitionUnit `json:"units,omitempty"`
+}
+
+type ignitionConfig struct {
+	Ignition ignitionMeta     `json:"ignition"`
+	Systemd  *ignitionSystemd `json:"systemd,omitempty"`
+}
+
+func ignitionSource(content string) ignitionResource {
+	return ignitionResource{Source: "data:;base64," + base64.StdEncoding.EncodeToString([]byte(content))}
+}
+
+// ignitionUserData returns the Ignition bootstrap data untouched, or a config merging it with the proxy configuration and additional configs.
+func ignitionUserData(bootstrapData string, proxy infrastructurev1beta1.OscProxy, noProxy []string, additional []utils.UserDataPart) (string, error) {
+	if !proxy.IsEnabled() && len(additional) == 0 {
+		return bootstrapData, nil
+	}
+	var bootstrap ignitionConfig
+	if err := json.Unmarshal([]byte(bootstrapData), &bootstrap); err != nil {
+		return "", fmt.Errorf("invalid Ignition bootstrap data: %w", err)
+	}
+	version := bootstrap.Ignition.Version
+	if version == "" {
+		return "", errors.New("invalid Ignition bootstrap data: no version found")
+	}
+	sources := []ignitionResource{ignitionSource(bootstrapData)}
+	for _, part := range additional {
+		if !strings.HasPrefix(strings.TrimSpace(part.Content), "{") {
+			return "", errors.New("additional user data must be Ignition configs with the ignition bootstrap format")
+		}
+		sources = append(sources, ignitionSource(part.Content))
+	}
+	cfg := ignitionConfig{Ignition: ignitionMeta{Version: version}}
+	if strings.HasPrefix(version, "2.") {
+		cfg.Ignition.Config.Append = sources
+	} else {
+		cfg.Ignition.Config.Merge = sources
+	}
+	if proxy.IsEnabled() {
+		dropin := ignitionDropin{Name: "http-proxy.conf", Contents: proxyUnitDropin(proxy, noProxy)}
+		cfg.Systemd = &ignitionSystemd{Units: []ignitionUnit{
+			{Name: "containerd.service", Dropins: []ignitionDropin{dropin}},
+			{Name: "kubelet.service", Dropins: []ignitionDropin{dropin}},
+		}}
+	}
+	buf, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal Ignition config: %w", err)
+	}
+	return string(buf), nil
+}
+
+// ignitionFetcher returns a config replaced by the Ignition config at url.
+func ignitionFetcher(config, url string) (string, error) {
+	var cfg ignitionConfig
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return "", fmt.Errorf("invalid Ignition bootstrap data: %w", err)
+	}
+	fetcher := ignitionConfig{Ignition: ignitionMeta{
+		Version: cfg.Ignition.Version,
+		Config:  ignitionConfigReferences{Replace: &ignitionResource{Source: url}},
+	}}
+	buf, err := json.Marshal(fetcher)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal Ignition config: %w", err)
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,111 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getIPAddressClaimName returns the name of the claim for the i-th pool of a machine.
+func getIPAddressClaimName(machineScope *scope.MachineScope, i int) string {
+	return fmt.Sprintf("%s-%d", machineScope.GetName(), i)
+}
+
+// reconcileIPAddressClaims creates an IPAddressClaim for each pool and returns the allocated addresses.
+// The returned list is empty until all claims have been fulfilled by an IPAM provider.
+func (r *OscMachineReconciler) reconcileIPAddressClaims(ctx context.Context, machineScope *scope.MachineScope) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	pools := machineScope.GetVmAddressesFromPools()
+	ips := make([]string, 0, len(pools))
+	for i, pool := range pools {
+		claim := &ipamv1.IPAddressClaim{}
+		key := client.ObjectKey{Namespace: machineScope.GetNamespace(), Name: getIPAddressClaimName(machineScope, i)}
+		err := r.Client.Get(ctx, key, claim)
+		switch {
+		case apierrors.IsNotFound(err):
+			claim = &ipamv1.IPAddressClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel: machineScope.Cluster.Name,
+					},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: infrastructurev1beta1.GroupVersion.String(),
+						Kind:       "OscMachine",
+						Name:       machineScope.OscMachine.Name,
+						UID:        machineScope.OscMachine.UID,
+						Controller: ptr.To(true),
+					}},
+				},
+				Spec: ipamv1.IPAddressClaimSpec{
+					ClusterName: machineScope.Cluster.Name,
+					PoolRef:     pool,
+				},
+			}
+			log.V(2).Info("Creating IPAddressClaim", "claim", key.Name, "pool", pool.Name)
+			if err := r.Client.Create(ctx, claim); err != nil {
+				return nil, fmt.Errorf("cannot create IPAddressClaim %s: %w", key.Name, err)
+			}
+			r.Recorder.Eventf(machineScope.OscMachine, corev1.EventTypeNormal, infrastructurev1beta1.IPAddressClaimCreatedReason, "IPAddressClaim %s created", key.Name)
+		case err != nil:
+			return nil, fmt.Errorf("cannot get IPAddressClaim %s: %w", key.Name, err)
+		}
+		if claim.Status.AddressRef.Name == "" {
+			log.V(3).Info("IPAddressClaim is not fulfilled yet", "claim", key.Name)
+			continue
+		}
+		address := &ipamv1.IPAddress{}
+		err = r.Client.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: claim.Status.AddressRef.Name}, address)
+		switch {
+		case apierrors.IsNotFound(err):
+			log.V(3).Info("IPAddress is not available yet", "address", claim.Status.AddressRef.Name)
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("cannot get IPAddress %s: %w", claim.Status.AddressRef.Name, err)
+		}
+		ips = append(ips, address.Spec.Address)
+	}
+	if len(ips) < len(pools) {
+		conditions.MarkFalse(machineScope.OscMachine, infrastructurev1beta1.IPAddressClaimedCondition, infrastructurev1beta1.WaitingForIPAddressReason, clusterv1.ConditionSeverityInfo,
+			"%d/%d addresses allocated", len(ips), len(pools))
+		return nil, nil
+	}
+	conditions.MarkTrue(machineScope.OscMachine, infrastructurev1beta1.IPAddressClaimedCondition)
+	return ips, nil
+}
+
+// reconcileDeleteIPAddressClaims releases the IPAddressClaims of a machine.
+func (r *OscMachineReconciler) reconcileDeleteIPAddressClaims(ctx context.Context, machineScope *scope.MachineScope) error {
+	log := ctrl.LoggerFrom(ctx)
+	for i := range machineScope.GetVmAddressesFromPools() {
+		claim := &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machineScope.GetNamespace(),
+				Name:      getIPAddressClaimName(machineScope, i),
+			},
+		}
+		log.V(2).Info("Deleting IPAddressClaim", "claim", claim.Name)
+		err := r.Client.Delete(ctx, claim)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete IPAddressClaim %s: %w", claim.Name, err)
+		}
+	}
+	return nil
+}
@@ -10,10 +10,34 @@ import (
 	"fmt"
 
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	osc "github.com/outscale/osc-sdk-go/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// reconcileVmPublicIp links the public IP of a VM bootstrapped with Ignition, as the Outscale section cannot be used to auto-attach it.
+func (r *OscMachineReconciler) reconcileVmPublicIp(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope, vm *osc.Vm) error {
+	log := ctrl.LoggerFrom(ctx)
+	format, err := machineScope.GetBootstrapFormat(ctx)
+	if err != nil {
+		return err
+	}
+	if format != scope.BootstrapFormatIgnition {
+		return nil
+	}
+	publicIpId, publicIp, err := r.Tracker.IPAllocator(machineScope).AllocateIP(ctx, defaultResource, machineScope.GetName(), machineScope.GetVm().PublicIpPool, clusterScope)
+	if err != nil {
+		return fmt.Errorf("allocate IP: %w", err)
+	}
+	log.V(2).Info("Linking publicIp", "publicIpId", publicIpId, "vmId", vm.GetVmId())
+	err = r.Cloud.PublicIp(clusterScope.Tenant).LinkPublicIp(ctx, publicIpId, vm.GetVmId())
+	if err != nil {
+		return fmt.Errorf("cannot link publicIp %s: %w", publicIpId, err)
+	}
+	vm.SetPublicIp(publicIp)
+	return nil
+}
+
 // reconcileDeletePublicIp reconcile the destruction of the PublicIp of the cluster.
 func (r *OscMachineReconciler) reconcileDeletePublicIp(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -9,9 +9,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"slices"
 
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tag"
 	osc "github.com/outscale/osc-sdk-go/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -96,6 +100,18 @@ func (t *MachineResourceTracker) setVmId(machineScope *scope.MachineScope, id st
 	rsrc.Vm[defaultResource] = id
 }
 
+func (t *MachineResourceTracker) setBootstrapDataKey(machineScope *scope.MachineScope, key string) {
+	rsrc := machineScope.GetResources()
+	if key == "" {
+		delete(rsrc.BootstrapData, defaultResource)
+		return
+	}
+	if rsrc.BootstrapData == nil {
+		rsrc.BootstrapData = map[string]string{}
+	}
+	rsrc.BootstrapData[defaultResource] = key
+}
+
 func (t *MachineResourceTracker) setVolumeIds(machineScope *scope.MachineScope, devices []osc.BlockDeviceMappingCreated) {
 	rsrc := machineScope.GetResources()
 	if rsrc.Volumes == nil {
@@ -112,23 +128,7 @@ func (t *MachineResourceTracker) getImageId(ctx context.Context, machineScope *s
 	if id != "" {
 		return id, nil
 	}
-	var image *osc.Image
-	var err error
-	imageSpec := machineScope.GetImage()
-	if imageSpec.Name != "" {
-		var accountId string
-		switch {
-		case imageSpec.OutscaleOpenSource:
-			accountId = OutscaleOpenSourceAccounts[clusterScope.GetRegion()]
-		case imageSpec.AccountId == "":
-			ctrl.LoggerFrom(ctx).V(2).Info("[security] It is recommended to set the image account to control the origin of the image.")
-		default:
-			accountId = imageSpec.AccountId
-		}
-		image, err = t.Cloud.Image(clusterScope.Tenant).GetImageByName(ctx, imageSpec.Name, accountId)
-	} else {
-		image, err = t.Cloud.Image(clusterScope.Tenant).GetImage(ctx, machineScope.GetImageId())
-	}
+	image, err := findImage(ctx, t.Cloud.Image(clusterScope.Tenant), machineScope.GetImage(), machineScope.GetImageId(), machineScope.GetKubernetesVersion(), clusterScope.GetRegion())
 	if err != nil {
 		return "", fmt.Errorf("cannot get image: %w", err)
 	}
@@ -139,6 +139,62 @@ func (t *MachineResourceTracker) getImageId(ctx context.Context, machineScope *s
 	return image.GetImageId(), nil
 }
 
+// findImage finds the image of a machine, by name or name pattern if set, by id otherwise.
+func findImage(ctx context.Context, svc compute.OscImageInterface, imageSpec *infrastructurev1beta1.OscImage, imageId, k8sVersion, region string) (*osc.Image, error) {
+	if imageSpec.NamePattern != "" {
+		return findImageByPattern(ctx, svc, imageSpec, k8sVersion, region)
+	}
+	if imageSpec.Name == "" {
+		return svc.GetImage(ctx, imageId)
+	}
+	var accountId string
+	switch {
+	case imageSpec.OutscaleOpenSource:
+		accountId = OutscaleOpenSourceAccounts[region]
+	case imageSpec.AccountId == "":
+		ctrl.LoggerFrom(ctx).V(2).Info("[security] It is recommended to set the image account to control the origin of the image.")
+	default:
+		accountId = imageSpec.AccountId
+	}
+	return svc.GetImageByName(ctx, imageSpec.Name, accountId)
+}
+
+// findImageByPattern finds the newest image matching the name pattern of a machine.
+func findImageByPattern(ctx context.Context, svc compute.OscImageInterface, imageSpec *infrastructurev1beta1.OscImage, k8sVersion, region string) (*osc.Image, error) {
+	pattern, err := imageSpec.ResolveNamePattern(k8sVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image name pattern: %w", err)
+	}
+	accountIds := slices.Clone(imageSpec.AccountIds)
+	if imageSpec.AccountId != "" {
+		accountIds = append(accountIds, imageSpec.AccountId)
+	}
+	if imageSpec.OutscaleOpenSource {
+		accountIds = append(accountIds, OutscaleOpenSourceAccounts[region])
+	}
+	if len(accountIds) == 0 {
+		ctrl.LoggerFrom(ctx).V(2).Info("[security] It is recommended to set the image account to control the origin of the image.")
+	}
+	images, err := svc.ListImagesByName(ctx, pattern, accountIds)
+	if err != nil {
+		return nil, err
+	}
+	var newest *osc.Image
+	for i := range images {
+		// The pattern is checked again, in case wildcards are not supported by the API.
+		if ok, _ := path.Match(pattern, images[i].GetImageName()); !ok {
+			continue
+		}
+		if newest == nil || images[i].GetCreationDate() > newest.GetCreationDate() {
+			newest = &images[i]
+		}
+	}
+	if newest != nil {
+		ctrl.LoggerFrom(ctx).V(3).Info("Found image matching pattern", "pattern", pattern, "imageId", newest.GetImageId(), "imageName", newest.GetImageName())
+	}
+	return newest, nil
+}
+
 func (t *MachineResourceTracker) setImageId(machineScope *scope.MachineScope, imageId string) {
 	rsrc := machineScope.GetResources()
 	if rsrc.Image == nil {
@@ -180,3 +236,50 @@ func (t *MachineResourceTracker) untrackIP(machineScope *scope.MachineScope, nam
 	}
 	delete(rsrc.PublicIPs, name)
 }
+
+func (t *MachineResourceTracker) getDedicatedSecurityGroup(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (*osc.SecurityGroup, error) {
+	id, err := t.getDedicatedSecurityGroupId(ctx, machineScope, clusterScope)
+	if err != nil {
+		return nil, err
+	}
+	sg, err := t.Cloud.SecurityGroup(clusterScope.Tenant).GetSecurityGroup(ctx, id)
+	switch {
+	case err != nil:
+		return nil, err
+	case sg == nil:
+		return nil, fmt.Errorf("get securityGroup %s: %w", id, ErrMissingResource)
+	default:
+		return sg, nil
+	}
+}
+
+// getDedicatedSecurityGroupId returns the id of the securityGroup dedicated to the machine, a wrapped ErrNoResourceFound error otherwise.
+func (t *MachineResourceTracker) getDedicatedSecurityGroupId(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (string, error) {
+	rsrc := machineScope.GetResources()
+	id := getResource(defaultResource, rsrc.SecurityGroup)
+	if id != "" {
+		return id, nil
+	}
+	tg, err := t.Cloud.Tag(clusterScope.Tenant).ReadTag(ctx, tag.SecurityGroupResourceType, tag.MachineUIDKey, machineScope.GetUID())
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("get securityGroup: %w", err)
+	case tg.GetResourceId() == "":
+		return "", fmt.Errorf("get securityGroup: %w", ErrNoResourceFound)
+	}
+	t.setDedicatedSecurityGroupId(machineScope, tg.GetResourceId())
+	return tg.GetResourceId(), nil
+}
+
+func (t *MachineResourceTracker) setDedicatedSecurityGroupId(machineScope *scope.MachineScope, id string) {
+	rsrc := machineScope.GetResources()
+	if rsrc.SecurityGroup == nil {
+		rsrc.SecurityGroup = map[string]string{}
+	}
+	rsrc.SecurityGroup[defaultResource] = id
+}
+
+func (t *MachineResourceTracker) untrackDedicatedSecurityGroup(machineScope *scope.MachineScope) {
+	rsrc := machineScope.GetResources()
+	delete(rsrc.SecurityGroup, defaultResource)
+}
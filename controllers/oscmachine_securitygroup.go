@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileDedicatedSecurityGroup reconciles the securityGroup dedicated to the machine.
+func (r *OscMachineReconciler) reconcileDedicatedSecurityGroup(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !machineScope.NeedReconciliation(infrastructurev1beta1.ReconcilerSecurityGroup) {
+		log.V(4).Info("No need for securityGroup reconciliation")
+		return reconcile.Result{}, nil
+	}
+	sgSpec := machineScope.GetVm().DedicatedSecurityGroup
+	sg, err := r.Tracker.getDedicatedSecurityGroup(ctx, machineScope, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound):
+		netId, err := r.ClusterTracker.getNetId(ctx, clusterScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		name := machineScope.GetClientToken(clusterScope)
+		log.V(3).Info("Creating dedicated securityGroup", "securityGroupName", name)
+		sg, err = r.Cloud.SecurityGroup(clusterScope.Tenant).CreateMachineSecurityGroup(ctx, netId, clusterScope.GetUID(), machineScope.GetUID(), name, "Security group for machine "+machineScope.GetName())
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("cannot create securityGroup: %w", err)
+		}
+		log.V(2).Info("Created dedicated securityGroup", "securityGroupId", sg.GetSecurityGroupId())
+		r.Tracker.setDedicatedSecurityGroupId(machineScope, sg.GetSecurityGroupId())
+		r.Recorder.Event(machineScope.OscMachine, corev1.EventTypeNormal, infrastructurev1beta1.SecurityGroupCreatedReason, "Dedicated security group created")
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("cannot get securityGroup: %w", err)
+	}
+
+	// Rules are managed the same way as authoritative cluster securityGroups.
+	svc := r.Cloud.SecurityGroup(clusterScope.Tenant)
+	err = reconcileSecurityGroupAddRules(ctx, svc, r.ClusterTracker, clusterScope, sgSpec.SecurityGroupRules, sg)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	err = reconcileSecurityGroupDeleteRules(ctx, svc, r.ClusterTracker, clusterScope, sgSpec.SecurityGroupRules, sg)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	machineScope.SetReconciliationGeneration(infrastructurev1beta1.ReconcilerSecurityGroup)
+	return reconcile.Result{}, nil
+}
+
+// reconcileDeleteDedicatedSecurityGroup deletes the securityGroup dedicated to the machine, once the VM is gone.
+func (r *OscMachineReconciler) reconcileDeleteDedicatedSecurityGroup(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	id, err := r.Tracker.getDedicatedSecurityGroupId(ctx, machineScope, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound):
+		log.V(4).Info("Dedicated securityGroup is already deleted")
+		return reconcile.Result{}, nil
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("cannot get securityGroup: %w", err)
+	}
+	vm, err := r.Tracker.getVm(ctx, machineScope, clusterScope)
+	switch {
+	case errors.Is(err, ErrNoResourceFound) || errors.Is(err, ErrMissingResource):
+	case err != nil:
+		return reconcile.Result{}, fmt.Errorf("cannot get vm: %w", err)
+	case vm.GetState() != "terminated":
+		log.V(3).Info("Waiting for VM deletion before deleting dedicated securityGroup", "vmId", vm.GetVmId())
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	log.V(2).Info("Deleting dedicated securityGroup", "securityGroupId", id)
+	err = r.Cloud.SecurityGroup(clusterScope.Tenant).DeleteSecurityGroup(ctx, id)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot delete securityGroup: %w", err)
+	}
+	r.Tracker.untrackDedicatedSecurityGroup(machineScope)
+	return reconcile.Result{}, nil
+}
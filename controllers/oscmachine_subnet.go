@@ -0,0 +1,53 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// selectSubnet returns the subnet where a VM will be created.
+// If multiple subnets match, the one with the most available IPs is selected.
+func (r *OscMachineReconciler) selectSubnet(ctx context.Context, clusterScope *scope.ClusterScope, name string, role infrastructurev1beta1.OscRole, subregion string) (infrastructurev1beta1.OscSubnet, string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	candidates := clusterScope.GetSubnetCandidates(name, role, subregion)
+	switch len(candidates) {
+	case 0:
+		return infrastructurev1beta1.OscSubnet{}, "", scope.ErrNoSubnetFound
+	case 1:
+		id, err := r.ClusterTracker.getSubnetId(ctx, candidates[0], clusterScope)
+		return candidates[0], id, err
+	}
+	ids := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		id, err := r.ClusterTracker.getSubnetId(ctx, candidate, clusterScope)
+		if err != nil {
+			return infrastructurev1beta1.OscSubnet{}, "", err
+		}
+		ids = append(ids, id)
+	}
+	subnets, err := r.Cloud.Subnet(clusterScope.Tenant).ListSubnets(ctx, ids)
+	if err != nil {
+		return infrastructurev1beta1.OscSubnet{}, "", fmt.Errorf("cannot list subnets: %w", err)
+	}
+	available := make(map[string]int32, len(subnets))
+	for _, subnet := range subnets {
+		available[subnet.GetSubnetId()] = subnet.GetAvailableIpsCount()
+	}
+	best := 0
+	for i := range ids {
+		if available[ids[i]] > available[ids[best]] {
+			best = i
+		}
+	}
+	log.V(4).Info("Selected subnet with the most available IPs", "subnetId", ids[best], "availableIps", available[ids[best]])
+	return candidates[best], ids[best], nil
+}
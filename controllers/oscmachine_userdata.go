@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: 2025 Outscale SAS <opensource@outscale.com>
+
+SPDX-License-Identifier: BSD-3-Clause
+*/
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/utils"
+	"sigs.k8s.io/yaml"
+)
+
+// proxyMergeType prepends the proxy configuration to the lists of the bootstrap cloud-config,
+// containerd is then restarted before kubeadm pulls any image.
+const proxyMergeType = "list(prepend)+dict(no_replace,recurse_list)+str()"
+
+// getUserData returns the user data of a VM and the format of its bootstrap data.
+// The bootstrap data is merged with the parts required by the cluster and the additional parts of the VM.
+func (r *OscMachineReconciler) getUserData(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (string, string, error) {
+	bootstrapData, err := machineScope.GetBootstrapData(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode bootstrap data: %w", err)
+	}
+	format, err := machineScope.GetBootstrapFormat(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	var additional []utils.UserDataPart
+	for _, partSpec := range machineScope.GetVm().AdditionalUserData {
+		part, err := r.additionalUserData(ctx, machineScope, partSpec)
+		if err != nil {
+			return "", "", err
+		}
+		additional = append(additional, part)
+	}
+	var userData string
+	proxy := clusterScope.OscCluster.Spec.Proxy
+	switch format {
+	case scope.BootstrapFormatCloudConfig:
+		userData, err = cloudInitUserData(bootstrapData, proxy, clusterScope.GetNoProxy(), additional)
+	case scope.BootstrapFormatIgnition:
+		userData, err = ignitionUserData(bootstrapData, proxy, clusterScope.GetNoProxy(), additional)
+	default:
+		err = fmt.Errorf("unsupported bootstrap format %q", format)
+	}
+	return userData, format, err
+}
+
+// cloudInitUserData merges the bootstrap data with other parts in a MIME multipart document.
+func cloudInitUserData(bootstrapData string, proxy infrastructurev1beta1.OscProxy, noProxy []string, additional []utils.UserDataPart) (string, error) {
+	bootstrapPart := utils.NewUserDataPart(bootstrapData)
+	parts := []utils.UserDataPart{bootstrapPart}
+	if proxy.IsEnabled() {
+		part, err := proxyUserData(proxy, noProxy)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	parts = append(parts, additional...)
+	// Plain bootstrap data is kept as is, a single gzip'ed payload is wrapped in a MIME document.
+	if len(parts) == 1 && bootstrapPart.ContentType != "application/x-gzip" {
+		return bootstrapData, nil
+	}
+	return utils.MergeUserData(parts...)
+}
+
+// additionalUserData returns an additional part of the user data, inline or fetched from a secret.
+func (r *OscMachineReconciler) additionalUserData(ctx context.Context, machineScope *scope.MachineScope, spec infrastructurev1beta1.OscUserDataPart) (utils.UserDataPart, error) {
+	content := spec.Content
+	if spec.FromSecret != "" {
+		var err error
+		content, err = machineScope.GetSecretValue(ctx, spec.FromSecret, cmp.Or(spec.SecretKey, "value"))
+		if err != nil {
+			return utils.UserDataPart{}, fmt.Errorf("cannot get additional user data: %w", err)
+		}
+	}
+	part := utils.NewUserDataPart(content)
+	if spec.ContentType != "" {
+		part.ContentType = spec.ContentType
+	}
+	part.MergeType = spec.MergeType
+	return part, nil
+}
+
+type cloudConfigFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	Permissions string `json:"permissions,omitempty"`
+	Append      bool   `json:"append,omitempty"`
+}
+
+type cloudConfig struct {
+	WriteFiles []cloudConfigFile `json:"write_files,omitempty"`
+	RunCmd     []string          `json:"runcmd,omitempty"`
+}
+
+// proxyEnv returns the proxy environment variables, in upper and lower case.
+func proxyEnv(proxy infrastructurev1beta1.OscProxy, noProxy []string) [][2]string {
+	env := [][2]string{}
+	if proxy.HTTPProxy != "" {
+		env = append(env, [2]string{"HTTP_PROXY", proxy.HTTPProxy}, [2]string{"http_proxy", proxy.HTTPProxy})
+	}
+	if proxy.HTTPSProxy != "" {
+		env = append(env, [2]string{"HTTPS_PROXY", proxy.HTTPSProxy}, [2]string{"https_proxy", proxy.HTTPSProxy})
+	}
+	np := strings.Join(noProxy, ",")
+	return append(env, [2]string{"NO_PROXY", np}, [2]string{"no_proxy", np})
+}
+
+// proxyUnitDropin returns a systemd drop-in setting the proxy environment of a service.
+func proxyUnitDropin(proxy infrastructurev1beta1.OscProxy, noProxy []string) string {
+	unit := &strings.Builder{}
+	unit.WriteString("[Service]\n")
+	for _, kv := range proxyEnv(proxy, noProxy) {
+		_, _ = fmt.Fprintf(unit, "Environment=\"%s=%s\"\n", kv[0], kv[1])
+	}
+	return unit.String()
+}
+
+// proxyUserData returns a cloud-config part configuring the proxy for containerd, kubelet and login shells.
+func proxyUserData(proxy infrastructurev1beta1.OscProxy, noProxy []string) (utils.UserDataPart, error) {
+	environment := &strings.Builder{}
+	for _, kv := range proxyEnv(proxy, noProxy) {
+		_, _ = fmt.Fprintf(environment, "%s=%s\n", kv[0], kv[1])
+	}
+	dropin := proxyUnitDropin(proxy, noProxy)
+	cc := cloudConfig{
+		WriteFiles: []cloudConfigFile{
+			{Path: "/etc/systemd/system/containerd.service.d/http-proxy.conf", Content: dropin, Permissions: "0644"},
+			{Path: "/etc/systemd/system/kubelet.service.d/http-proxy.conf", Content: dropin, Permissions: "0644"},
+			{Path: "/etc/environment", Content: environment.String(), Append: true},
+		},
+		RunCmd: []string{
+			"systemctl daemon-reload",
+			"systemctl restart containerd",
+		},
+	}
+	buf, err := yaml.Marshal(cc)
+	if err != nil {
+		return utils.UserDataPart{}, fmt.Errorf("cannot marshal proxy cloud-config: %w", err)
+	}
+	return utils.UserDataPart{
+		ContentType: "text/cloud-config",
+		MergeType:   proxyMergeType,
+		Content:     "#cloud-config\n" + string(buf),
+	}, nil
+}
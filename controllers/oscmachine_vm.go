@@ -16,6 +16,7 @@ import (
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services/compute"
+	osc "github.com/outscale/osc-sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -24,9 +25,16 @@ import (
 // reconcileVm reconcile the vm of the machine
 func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
+	// Bootstrap data is no longer needed once the node has joined the cluster, usually after the VM has been reconciled.
+	if machineScope.Machine.Status.NodeRef != nil {
+		err := r.reconcileDeleteBootstrapData(ctx, clusterScope, machineScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	if !machineScope.NeedReconciliation(infrastructurev1beta1.ReconcilerVm) {
 		log.V(4).Info("No need for vm reconciliation")
-		return reconcile.Result{}, nil
+		return r.reconcileReadyVm(ctx, clusterScope, machineScope)
 	}
 
 	vmSpec := machineScope.GetVm()
@@ -49,26 +57,24 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 			subregionName = vmSpec.SubregionName
 		}
 
-		subnetSpec, err := clusterScope.GetSubnet(subnetName, vmSpec.GetRole(), subregionName)
+		subnetSpec, subnetId, err := r.selectSubnet(ctx, clusterScope, subnetName, vmSpec.GetRole(), subregionName)
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("reconcile vm: %w", err)
 		}
-		subnetId, err := r.ClusterTracker.getSubnetId(ctx, subnetSpec, clusterScope)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("reconcile vm: %w", err)
-		}
-		securityGroups, err := clusterScope.GetSecurityGroupsFor(machineScope.GetVmSecurityGroups(), vmSpec.GetRole())
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("cannot find securityGroup: %w", err)
-		}
-		securityGroupIds := make([]string, 0, len(securityGroups))
-		for _, sgSpec := range securityGroups {
-			securityGroupId, err := r.ClusterTracker.getSecurityGroupId(ctx, sgSpec, clusterScope)
-			log.V(4).Info("Found securityGroup", "securityGroupId", securityGroupId)
+		var nicId string
+		if vmSpec.GetRole() == infrastructurev1beta1.RoleControlPlane && clusterScope.GetNetwork().ControlPlaneNics.Enable {
+			nicId, err = r.getFreeControlPlaneNic(ctx, clusterScope, clusterScope.GetSubnetSubregion(subnetSpec))
 			if err != nil {
-				return reconcile.Result{}, err
+				return reconcile.Result{}, fmt.Errorf("cannot find controlplane nic: %w", err)
 			}
-			securityGroupIds = append(securityGroupIds, securityGroupId)
+			if nicId == "" {
+				log.V(3).Info("Waiting for a free controlplane nic", "subregion", clusterScope.GetSubnetSubregion(subnetSpec))
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+		}
+		securityGroupIds, err := r.getVmSecurityGroupIds(ctx, clusterScope, machineScope)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
 		vmPrivateIps := machineScope.GetVmPrivateIps()
 		privateIps := make([]string, 0, len(vmPrivateIps))
@@ -76,14 +82,34 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 			privateIp := vmPrivateIp.PrivateIp
 			privateIps = append(privateIps, privateIp)
 		}
+		if len(machineScope.GetVmAddressesFromPools()) > 0 && nicId == "" {
+			privateIps, err = r.reconcileIPAddressClaims(ctx, machineScope)
+			if err != nil {
+				return reconcile.Result{}, fmt.Errorf("reconcile IPAddressClaims: %w", err)
+			}
+			if len(privateIps) == 0 {
+				log.V(3).Info("Waiting for IPAM to allocate private IPs")
+				return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+		}
 		imageId, err := r.Tracker.getImageId(ctx, machineScope, clusterScope)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 		vmName := machineScope.GetName()
 		vmTags := vmSpec.Tags
+		userData, format, err := r.getUserData(ctx, clusterScope, machineScope)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		// The Outscale section of the user data would corrupt an Ignition config,
+		// tags are added once the VM is created, and the public IP is linked once the VM is running.
+		ignition := format == scope.BootstrapFormatIgnition
+		if ignition {
+			vmTags = nil
+		}
 
-		if vmSpec.PublicIp {
+		if vmSpec.PublicIp && !ignition {
 			_, publicIp, err := r.Tracker.IPAllocator(machineScope).AllocateIP(ctx, defaultResource, vmName, vmSpec.PublicIpPool, clusterScope)
 			if err != nil {
 				return reconcile.Result{}, fmt.Errorf("allocate IP: %w", err)
@@ -100,19 +126,36 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 		vmType := vmSpec.VmType
 		volumes := machineScope.GetVolumes()
 		clientToken := machineScope.GetClientToken(clusterScope)
-		log.V(3).Info("Creating VM", "vmName", vmName, "imageId", imageId, "keypairName", keypairName, "vmType", vmType, "tags", vmTags)
-		vm, err = r.Cloud.VM(clusterScope.Tenant).CreateVm(ctx, machineScope, &vmSpec, imageId, subnetId, securityGroupIds, privateIps, vmName, clientToken, vmTags, volumes)
+		if clusterScope.OscCluster.Spec.BootstrapStorage.Enable {
+			userData, err = r.uploadBootstrapData(ctx, clusterScope, machineScope, userData, format)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if err := compute.ValidateUserDataSize(userData, vmTags); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.V(3).Info("Creating VM", "vmName", vmName, "imageId", imageId, "keypairName", keypairName, "vmType", vmType, "tags", vmTags, "nicId", nicId)
+		vm, err = r.Cloud.VM(clusterScope.Tenant).CreateVm(ctx, userData, &vmSpec, imageId, subnetId, securityGroupIds, privateIps, nicId, vmName, clientToken, vmTags, volumes)
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("cannot create vm: %w", err)
 		}
 		vmId := vm.GetVmId()
 		log.V(2).Info("VM created", "vmId", vmId)
 		r.Tracker.trackVm(machineScope, vm)
+		machineScope.SetAttachedSecurityGroups(securityGroupIds)
 		machineScope.SetVmState(infrastructurev1beta1.VmState(vm.GetState()))
 		machineScope.SetProviderID(vm.Placement.GetSubregionName(), vmId)
 		r.Recorder.Event(machineScope.OscMachine, corev1.EventTypeNormal, infrastructurev1beta1.VmCreatedReason, "VM created")
 	}
 
+	// Tags are not set by the user data of Ignition VMs, and a VM may have been created without its tags being added.
+	// Once the VM is reconciled, tags are checked by reconcileReadyVm.
+	err = r.reconcileVmTags(ctx, clusterScope, vm, vmSpec.Tags)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if vm.GetState() != "running" {
 		log.V(4).Info(fmt.Sprintf("VM %s is not yet running", vm.GetVmId()))
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
@@ -120,6 +163,15 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 
 	machineScope.SetReady()
 
+	securityGroupIds, err := r.getVmSecurityGroupIds(ctx, clusterScope, machineScope)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	err = r.reconcileVmSecurityGroups(ctx, clusterScope, machineScope, vm, securityGroupIds)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if vmSpec.GetRole() == infrastructurev1beta1.RoleControlPlane {
 		svc := r.Cloud.LoadBalancer(clusterScope.Tenant)
 		loadBalancerName := clusterScope.GetLoadBalancer().LoadBalancerName
@@ -139,6 +191,13 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 		}
 	}
 
+	if vmSpec.PublicIp && vm.GetPublicIp() == "" {
+		err = r.reconcileVmPublicIp(ctx, clusterScope, machineScope, vm)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	privateDnsName, ok := vm.GetPrivateDnsNameOk()
 	if !ok {
 		return reconcile.Result{}, errors.New("cannot find privateDnsName")
@@ -178,6 +237,110 @@ func (r *OscMachineReconciler) reconcileVm(ctx context.Context, clusterScope *sc
 	return reconcile.Result{}, nil
 }
 
+// reconcileReadyVm checks, on each reconciliation, the parts of a reconciled vm that may drift out of band.
+func (r *OscMachineReconciler) reconcileReadyVm(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
+	vm, err := r.Tracker.getVm(ctx, machineScope, clusterScope)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot get VM: %w", err)
+	}
+	err = r.reconcileVmTags(ctx, clusterScope, vm, machineScope.GetVm().Tags)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// getVmSecurityGroupIds returns the ids of the securityGroups the vm must be attached to.
+func (r *OscMachineReconciler) getVmSecurityGroupIds(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	vmSpec := machineScope.GetVm()
+	securityGroups, err := clusterScope.GetSecurityGroupsFor(machineScope.GetVmSecurityGroups(), vmSpec.GetRole())
+	if err != nil {
+		return nil, fmt.Errorf("cannot find securityGroup: %w", err)
+	}
+	securityGroupIds := make([]string, 0, len(securityGroups)+1)
+	for _, sgSpec := range securityGroups {
+		securityGroupId, err := r.ClusterTracker.getSecurityGroupId(ctx, sgSpec, clusterScope)
+		log.V(4).Info("Found securityGroup", "securityGroupId", securityGroupId)
+		if err != nil {
+			return nil, err
+		}
+		securityGroupIds = append(securityGroupIds, securityGroupId)
+	}
+	if vmSpec.DedicatedSecurityGroup.Enable {
+		securityGroupId, err := r.Tracker.getDedicatedSecurityGroupId(ctx, machineScope, clusterScope)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find dedicated securityGroup: %w", err)
+		}
+		securityGroupIds = append(securityGroupIds, securityGroupId)
+	}
+	return securityGroupIds, nil
+}
+
+// reconcileVmSecurityGroups attaches the securityGroups of the spec to a running vm, and detaches those having been removed from the spec.
+// Only securityGroups previously attached by CAPOSC are detached, securityGroups attached out of band are kept.
+func (r *OscMachineReconciler) reconcileVmSecurityGroups(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope, vm *osc.Vm, securityGroupIds []string) error {
+	log := ctrl.LoggerFrom(ctx)
+	current := make([]string, 0, len(vm.GetSecurityGroups()))
+	for _, sg := range vm.GetSecurityGroups() {
+		current = append(current, sg.GetSecurityGroupId())
+	}
+	var added, removed []string
+	for _, id := range securityGroupIds {
+		if !slices.Contains(current, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range machineScope.GetAttachedSecurityGroups() {
+		if slices.Contains(current, id) && !slices.Contains(securityGroupIds, id) {
+			removed = append(removed, id)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		machineScope.SetAttachedSecurityGroups(securityGroupIds)
+		return nil
+	}
+	updated := slices.DeleteFunc(current, func(id string) bool { return slices.Contains(removed, id) })
+	updated = append(updated, added...)
+	log.V(2).Info("Updating VM securityGroups", "vmId", vm.GetVmId(), "added", added, "removed", removed)
+	err := r.Cloud.VM(clusterScope.Tenant).UpdateVmSecurityGroups(ctx, vm.GetVmId(), updated)
+	if err != nil {
+		return fmt.Errorf("cannot update vm securityGroups: %w", err)
+	}
+	machineScope.SetAttachedSecurityGroups(securityGroupIds)
+	for _, id := range added {
+		r.Recorder.Eventf(machineScope.OscMachine, corev1.EventTypeNormal, infrastructurev1beta1.VmSecurityGroupsUpdatedReason, "Security group %s added to VM", id)
+	}
+	for _, id := range removed {
+		r.Recorder.Eventf(machineScope.OscMachine, corev1.EventTypeNormal, infrastructurev1beta1.VmSecurityGroupsUpdatedReason, "Security group %s removed from VM", id)
+	}
+	return nil
+}
+
+// reconcileVmTags adds the tags of the spec that are missing on a vm, or have a different value.
+func (r *OscMachineReconciler) reconcileVmTags(ctx context.Context, clusterScope *scope.ClusterScope, vm *osc.Vm, tags map[string]string) error {
+	log := ctrl.LoggerFrom(ctx)
+	current := make(map[string]string, len(vm.GetTags()))
+	for _, tag := range vm.GetTags() {
+		current[tag.GetKey()] = tag.GetValue()
+	}
+	missing := map[string]string{}
+	for k, v := range tags {
+		if cv, found := current[k]; !found || cv != v {
+			missing[k] = v
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	log.V(2).Info("Adding VM tags", "vmId", vm.GetVmId(), "tags", missing)
+	err := r.Cloud.VM(clusterScope.Tenant).AddTags(ctx, vm.GetVmId(), missing)
+	if err != nil {
+		return fmt.Errorf("cannot add tags: %w", err)
+	}
+	return nil
+}
+
 // reconcileDeleteVm reconcile the destruction of the vm of the machine
 func (r *OscMachineReconciler) reconcileDeleteVm(ctx context.Context, clusterScope *scope.ClusterScope, machineScope *scope.MachineScope) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
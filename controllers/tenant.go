@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	infrastructurev1beta1 "github.com/outscale/cluster-api-provider-outscale/api/v1beta1"
+	"github.com/outscale/cluster-api-provider-outscale/cloud/scope"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/services"
 	"github.com/outscale/cluster-api-provider-outscale/cloud/tenant"
 	"github.com/outscale/osc-sdk-go/v2"
@@ -41,6 +42,18 @@ func getMgmtTenant(ctx context.Context, cl client.Client, c services.Servicer, c
 	}
 }
 
+func getAdditionalNetPeeringTenant(ctx context.Context, cl client.Client, clusterScope *scope.ClusterScope, np infrastructurev1beta1.OscAdditionalNetPeering) (tenant.Tenant, error) {
+	creds := np.Credentials
+	switch {
+	case creds.FromFile != "":
+		return tenant.TenantFromFile(creds.FromFile, creds.Profile)
+	case creds.FromSecret != "":
+		return getTenantFromSecret(ctx, cl, creds.FromSecret, clusterScope.GetNamespace())
+	default:
+		return clusterScope.Tenant, nil
+	}
+}
+
 func getTenantFromSecret(ctx context.Context, cl client.Client, name, ns string) (tenant.Tenant, error) {
 	var secret corev1.Secret
 	err := cl.Get(ctx, client.ObjectKey{
@@ -56,3 +69,14 @@ func getTenantFromSecret(ctx context.Context, cl client.Client, name, ns string)
 		Region:    ptr.To(string(secret.Data["region"])),
 	})
 }
+
+func getTenantFromCredentials(ctx context.Context, cl client.Client, c services.Servicer, creds infrastructurev1beta1.OscCredentials, ns string) (tenant.Tenant, error) {
+	switch {
+	case creds.FromFile != "":
+		return tenant.TenantFromFile(creds.FromFile, creds.Profile)
+	case creds.FromSecret != "":
+		return getTenantFromSecret(ctx, cl, creds.FromSecret, ns)
+	default:
+		return c.DefaultTenant()
+	}
+}
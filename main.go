@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -44,6 +45,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
 	utilruntime.Must(bootstrapv1.AddToScheme(scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme))
 	utilruntime.Must(infrastructurev1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -188,6 +190,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.OscImageBuildReconciler{
+		Client:           mgr.GetClient(),
+		Cloud:            cs,
+		Recorder:         mgr.GetEventRecorderFor("oscimagebuild-controller"),
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
+		logger.Error(err, "unable to create controller", "controller", "OscImageBuild")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OscImageDistributionReconciler{
+		Client:           mgr.GetClient(),
+		Cloud:            cs,
+		Recorder:         mgr.GetEventRecorderFor("oscimagedistribution-controller"),
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
+		logger.Error(err, "unable to create controller", "controller", "OscImageDistribution")
+		os.Exit(1)
+	}
+
 	if err = (&infrastructurev1beta1.OscMachine{}).SetupWebhookWithManager(mgr); err != nil {
 		logger.Error(err, "unable to create webhook", "webhook", "OscMachine")
 		os.Exit(1)